@@ -0,0 +1,50 @@
+package spew
+
+import (
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// addrDigits is the number of hex digits of a padded address, which is the
+// width of a pointer on the platform.
+const addrDigits = strconv.IntSize / 4
+
+// addrSymbol returns the name of the function whose code holds addr, with the
+// offset into it when addr is not its entry point, and false when addr isn't
+// known to be code.
+func addrSymbol(addr uintptr) (string, bool) {
+	fn := runtime.FuncForPC(addr)
+	if fn == nil {
+		return "", false
+	}
+	if off := addr - fn.Entry(); off != 0 {
+		return fn.Name() + "+0x" + strconv.FormatUint(uint64(off), 16), true
+	}
+	return fn.Name(), true
+}
+
+// writeAddress writes the uintptr or unsafe.Pointer value addr as hex.  It is
+// padded with zeros to the width of a pointer when PadPointers is set, and
+// followed by the symbol it points to when PointerSymbols is set and it can
+// be resolved.  Addresses are labeled by their identifier instead when ids is
+// not nil.
+func writeAddress(w io.Writer, cs *ConfigState, ids *pointerIDs, addr uintptr) {
+	if ids != nil || addr == 0 {
+		ids.write(w, addr)
+		return
+	}
+	if cs.PadPointers {
+		hex := strconv.FormatUint(uint64(addr), 16)
+		w.Write([]byte("0x" + strings.Repeat("0", max(addrDigits-len(hex), 0)) + hex))
+	} else {
+		printHexPtr(w, addr)
+	}
+	if !cs.PointerSymbols {
+		return
+	}
+	if sym, ok := addrSymbol(addr); ok {
+		w.Write([]byte(" <" + sym + ">"))
+	}
+}
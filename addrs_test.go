@@ -0,0 +1,41 @@
+package spew_test
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func addrsTarget() {}
+
+var _ = Describe("Address Tests", func() {
+	It("pads addresses to the width of a pointer", func() {
+		cs := spew.NewTestConfig()
+		cs.PadPointers = true
+		want := fmt.Sprintf("0x%0*x", strconv.IntSize/4, 0xff)
+		Expect(cs.Sdump(uintptr(0xff))).To(Equal("(uintptr) " + want + "\n"))
+		Expect(cs.Sprintf("%v", uintptr(0xff))).To(Equal(want))
+		Expect(cs.Sdump(uintptr(0))).To(Equal("(uintptr) <nil>\n"))
+	})
+
+	It("annotates addresses within functions with their symbol", func() {
+		cs := spew.NewTestConfig()
+		cs.PointerSymbols = true
+		pc := reflect.ValueOf(addrsTarget).Pointer()
+		Expect(cs.Sdump(pc)).To(Equal(fmt.Sprintf("(uintptr) %#x <github.com/ehowe/rainbow-spew_test.addrsTarget>\n", pc)))
+		Expect(cs.Sdump(pc + 1)).To(Equal(fmt.Sprintf("(uintptr) %#x <github.com/ehowe/rainbow-spew_test.addrsTarget+0x1>\n", pc+1)))
+	})
+
+	It("does not annotate addresses of data", func() {
+		cs := spew.NewTestConfig()
+		cs.PointerSymbols = true
+		i := 1
+		p := unsafe.Pointer(&i)
+		Expect(cs.Sdump(p)).To(Equal(fmt.Sprintf("(unsafe.Pointer) %p\n", p)))
+	})
+})
@@ -0,0 +1,36 @@
+package spew
+
+import "reflect"
+
+// loadedBytes marks the values loaded from the types of the sync/atomic
+// package for the AtomicValues option.
+var loadedBytes = []byte("loaded")
+
+// atomicValue returns the value loaded from v when it is one of the types of
+// the sync/atomic package, such as atomic.Int64, atomic.Pointer[T] and
+// atomic.Value, and false otherwise.  The value is loaded through the Load
+// method of the type, so values which aren't addressable are copied first.
+func atomicValue(v reflect.Value) (reflect.Value, bool) {
+	t := v.Type()
+	if t.Kind() != reflect.Struct || t.PkgPath() != "sync/atomic" {
+		return reflect.Value{}, false
+	}
+	load, ok := reflect.PointerTo(t).MethodByName("Load")
+	if !ok || load.Type.NumIn() != 1 || load.Type.NumOut() != 1 {
+		return reflect.Value{}, false
+	}
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return reflect.Value{}, false
+		}
+		v = unsafeReflectValue(v)
+	}
+
+	p := reflect.New(t)
+	if v.CanAddr() {
+		p = v.Addr()
+	} else {
+		p.Elem().Set(v)
+	}
+	return p.Method(load.Index).Call(nil)[0], true
+}
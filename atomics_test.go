@@ -0,0 +1,69 @@
+package spew_test
+
+import (
+	"sync/atomic"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type atomicsCounters struct {
+	hits   atomic.Int64
+	ready  atomic.Bool
+	last   atomic.Pointer[int]
+	config atomic.Value
+}
+
+var _ = Describe("Atomic Values Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.AtomicValues = true
+		cs.DisablePointerAddresses = true
+		return cs
+	}
+
+	It("displays atomic types as their loaded value", func() {
+		if spew.UnsafeDisabled {
+			Skip("loading atomics held by unexported fields requires the unsafe package")
+		}
+		cs := newConfig()
+		c := &atomicsCounters{}
+		Expect(cs.Sdump(c)).To(Equal("(*spew_test.atomicsCounters)({\n" +
+			"  hits: (atomic.Int64) (loaded) 0,\n" +
+			"  ready: (atomic.Bool) (loaded) false,\n" +
+			"  last: (atomic.Pointer[int]) (loaded) (*int)(<nil>),\n" +
+			"  config: (atomic.Value) (loaded) (interface {}) <nil>\n" +
+			"})\n"))
+
+		n := 7
+		c.hits.Store(42)
+		c.ready.Store(true)
+		c.last.Store(&n)
+		c.config.Store("prod")
+		Expect(cs.Sdump(c)).To(Equal("(*spew_test.atomicsCounters)({\n" +
+			"  hits: (atomic.Int64) (loaded) 42,\n" +
+			"  ready: (atomic.Bool) (loaded) true,\n" +
+			"  last: (atomic.Pointer[int]) (loaded) (*int)(7),\n" +
+			"  config: (atomic.Value) (loaded) (string) (len: 4) \"prod\"\n" +
+			"})\n"))
+		Expect(cs.Sprintf("%v", c)).To(Equal("<*>{42 true <*>7 prod}"))
+	})
+
+	It("displays atomic types which aren't addressable", func() {
+		cs := newConfig()
+		var n atomic.Uint32
+		n.Store(3)
+		Expect(cs.Sdump([]interface{}{&n})).To(Equal("([]interface {}) (len: 1 cap: 1) {\n" +
+			"  (*atomic.Uint32)((loaded) 3)\n" +
+			"}\n"))
+		Expect(cs.Sdump(map[string]atomic.Int32{"a": {}})).To(Equal("(map[string]atomic.Int32) (len: 1) {\n" +
+			"  (string) (len: 1) \"a\": (atomic.Int32) (loaded) 0\n" +
+			"}\n"))
+	})
+
+	It("displays the internal representation without the option", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(atomic.Bool{})).To(ContainSubstring("v: (uint32) 0"))
+	})
+})
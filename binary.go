@@ -0,0 +1,275 @@
+package spew
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BinaryField describes how a single struct field of a registered binary
+// layout is encoded on the wire.
+type BinaryField struct {
+	// Name is the name of the struct field.
+	Name string
+
+	// Bits is the width of the field on the wire in bits, which is at most
+	// 64.  The default, 0, means the natural size of the field's type.
+	// Widths which are not a multiple of eight describe bit fields.
+	Bits int
+}
+
+// BinaryLayout describes the wire format of a struct type registered with
+// RegisterBinaryLayout.  Fields are packed most significant bit first in the
+// order they are listed.
+type BinaryLayout struct {
+	// Order is the byte order used for byte aligned multi-byte fields.  Big
+	// endian (network byte order) is used when it is nil.
+	Order binary.ByteOrder
+
+	// Fields lists the struct fields in wire order.  When it is empty, all
+	// fields of the struct are used in declaration order with their natural
+	// sizes.
+	Fields []BinaryField
+}
+
+// binaryLayouts houses the layouts registered with RegisterBinaryLayout.
+var binaryLayouts = struct {
+	sync.RWMutex
+	m map[reflect.Type]BinaryLayout
+}{m: make(map[reflect.Type]BinaryLayout)}
+
+/*
+RegisterBinaryLayout registers the wire format of the struct type typ so Dump
+renders each of its fields with the field's bit offsets and raw bytes next to
+the decoded value.  This turns Dump into a lightweight protocol decoder for
+structs which represent custom binary formats.  For example:
+
+	spew.RegisterBinaryLayout(reflect.TypeOf(Header{}), spew.BinaryLayout{
+		Fields: []spew.BinaryField{
+			{Name: "Version", Bits: 4},
+			{Name: "Flags", Bits: 4},
+			{Name: "Length"},
+		},
+	})
+
+results in output such as:
+
+	(main.Header) (wire: 24 bits) {
+	 Version: bits 0-3 [0100] (uint8) 4,
+	 Flags: bits 4-7 [0001] (uint8) 1,
+	 Length: bits 8-23 [00 1c] (uint16) 28
+	}
+
+Fields which are fixed size integers, booleans, or arrays of bytes may be part
+of a layout.  RegisterBinaryLayout panics if typ is not a struct or the layout
+refers to a field typ does not have, whose type is not supported, or whose
+width is negative or more than 64 bits.
+*/
+func RegisterBinaryLayout(typ reflect.Type, layout BinaryLayout) {
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("spew: binary layout for non-struct type %v", typ))
+	}
+	if len(layout.Fields) == 0 {
+		for i := 0; i < typ.NumField(); i++ {
+			layout.Fields = append(layout.Fields, BinaryField{Name: typ.Field(i).Name})
+		}
+	}
+	for _, f := range layout.Fields {
+		sf, ok := typ.FieldByName(f.Name)
+		if !ok {
+			panic(fmt.Sprintf("spew: binary layout for %v refers to unknown field %q", typ, f.Name))
+		}
+		if naturalBits(sf.Type) == 0 {
+			panic(fmt.Sprintf("spew: binary layout for %v has unsupported field %q of type %v",
+				typ, f.Name, sf.Type))
+		}
+		if f.Bits < 0 || f.Bits > 64 {
+			panic(fmt.Sprintf("spew: binary layout for %v has field %q of invalid width %d bits",
+				typ, f.Name, f.Bits))
+		}
+	}
+
+	binaryLayouts.Lock()
+	binaryLayouts.m[typ] = layout
+	binaryLayouts.Unlock()
+}
+
+// lookupBinaryLayout returns the layout registered for typ, if any.
+func lookupBinaryLayout(typ reflect.Type) (BinaryLayout, bool) {
+	binaryLayouts.RLock()
+	layout, ok := binaryLayouts.m[typ]
+	binaryLayouts.RUnlock()
+	return layout, ok
+}
+
+// naturalBits returns the size in bits of types supported in binary layouts
+// or 0 for unsupported types.
+func naturalBits(typ reflect.Type) int {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return 8
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(typ.Size()) * 8
+	case reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return typ.Len() * 8
+		}
+	}
+	return 0
+}
+
+// binaryFieldInfo is a field of a binary layout resolved against a value.
+type binaryFieldInfo struct {
+	index  []int
+	name   string
+	offset int
+	bits   int
+	raw    string
+}
+
+// resolveBinaryLayout computes the bit offsets and raw wire representation of
+// each field of layout for the struct value v.  The total number of bits is
+// returned along with the fields.
+func resolveBinaryLayout(v reflect.Value, layout BinaryLayout) ([]binaryFieldInfo, int) {
+	order := layout.Order
+	if order == nil {
+		order = binary.BigEndian
+	}
+
+	fields := make([]binaryFieldInfo, 0, len(layout.Fields))
+	offset := 0
+	for _, f := range layout.Fields {
+		sf, _ := v.Type().FieldByName(f.Name)
+		bits := f.Bits
+		if bits == 0 {
+			bits = naturalBits(sf.Type)
+		}
+		fv := v.FieldByIndex(sf.Index)
+
+		var raw string
+		switch {
+		case fv.Kind() == reflect.Array:
+			// Arrays are taken as big endian numbers, so like integers
+			// narrower widths keep their last bits and wider ones are
+			// padded with leading zeros.
+			b := make([]byte, fv.Len())
+			for i := range b {
+				b[i] = byte(fv.Index(i).Uint())
+			}
+			if bits%8 == 0 && offset%8 == 0 {
+				if pad := bits/8 - len(b); pad > 0 {
+					b = append(make([]byte, pad), b...)
+				}
+				raw = hexBytes(b[len(b)-bits/8:])
+				break
+			}
+			var sb strings.Builder
+			for _, c := range b {
+				fmt.Fprintf(&sb, "%08b", c)
+			}
+			raw = sb.String()
+			if len(raw) < bits {
+				raw = strings.Repeat("0", bits-len(raw)) + raw
+			}
+			raw = raw[len(raw)-bits:]
+
+		case bits%8 == 0 && offset%8 == 0 && bits <= 64:
+			b := make([]byte, 8)
+			order.PutUint64(b, binaryUint(fv))
+			if order.Uint16([]byte{0, 1}) == 1 {
+				b = b[8-bits/8:]
+			} else {
+				b = b[:bits/8]
+			}
+			raw = hexBytes(b)
+
+		default:
+			raw = strconv.FormatUint(binaryUint(fv), 2)
+			if len(raw) < bits {
+				raw = strings.Repeat("0", bits-len(raw)) + raw
+			}
+			raw = raw[len(raw)-bits:]
+		}
+
+		fields = append(fields, binaryFieldInfo{
+			index:  sf.Index,
+			name:   f.Name,
+			offset: offset,
+			bits:   bits,
+			raw:    raw,
+		})
+		offset += bits
+	}
+	return fields, offset
+}
+
+// binaryUint returns the integer or boolean value v as a uint64 with the same
+// bit pattern.
+func binaryUint(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(v.Int())
+	}
+	return v.Uint()
+}
+
+// hexBytes returns b as space separated hex bytes.
+func hexBytes(b []byte) string {
+	parts := make([]string, len(b))
+	for i, c := range b {
+		parts[i] = fmt.Sprintf("%02x", c)
+	}
+	return strings.Join(parts, " ")
+}
+
+// dumpBinary handles formatting of structs with a registered binary layout.
+func (d *dumpState) dumpBinary(v reflect.Value, layout BinaryLayout) {
+	fields, total := resolveBinaryLayout(v, layout)
+	withParens(d, func(d *dumpState) {
+		withColor(d.w, wireEqualsBytes, d.cs.Color.Length...)
+		printNumber(d.w, d.cs, total)
+		d.w.Write(bitsBytes)
+	})
+	d.w.Write(spaceBytes)
+
+	d.punct(openBraceNewlineBytes)
+	d.depth++
+	if d.depth > d.maxDepth {
+		d.indent()
+		d.line(maxSymbol(d.cs))
+		d.countTruncation()
+		d.depth--
+		d.indent()
+		d.punct(closeBraceBytes)
+		return
+	}
+	for i, f := range fields {
+		d.indent()
+		writeFieldName(d.w, d.cs, f.name)
+		d.punct(colonSpaceBytes)
+		fmt.Fprintf(d.w, "bits %d-%d ", f.offset, f.offset+f.bits-1)
+		d.punct(openBracketBytes)
+		d.w.Write([]byte(f.raw))
+		d.punct(closeBracketBytes)
+		d.w.Write(spaceBytes)
+		d.ignoreNextIndent = true
+		d.dump(v.FieldByIndex(f.index))
+		if i < len(fields)-1 {
+			d.punct(commaNewlineBytes)
+		} else {
+			d.line(newlineBytes)
+		}
+	}
+	d.depth--
+	d.indent()
+	d.punct(closeBraceBytes)
+}
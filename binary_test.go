@@ -0,0 +1,119 @@
+package spew_test
+
+import (
+	"encoding/binary"
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type wireHeader struct {
+	Version uint8
+	Flags   uint8
+	Length  uint16
+	Magic   [2]byte
+}
+
+type wireLE struct {
+	ID   uint32
+	Ok   bool
+	Skip int8
+}
+
+type wireTag struct {
+	Kind   uint8
+	Tag    [2]byte
+	Serial [4]byte
+}
+
+func registerWireTag() {
+	spew.RegisterBinaryLayout(reflect.TypeOf(wireTag{}), spew.BinaryLayout{
+		Fields: []spew.BinaryField{
+			{Name: "Kind", Bits: 4},
+			{Name: "Tag", Bits: 12},
+			{Name: "Serial", Bits: 16},
+		},
+	})
+}
+
+var _ = Describe("Binary Layout Tests", func() {
+	It("renders registered binary layouts", func() {
+		spew.RegisterBinaryLayout(reflect.TypeOf(wireHeader{}), spew.BinaryLayout{
+			Fields: []spew.BinaryField{
+				{Name: "Version", Bits: 4},
+				{Name: "Flags", Bits: 4},
+				{Name: "Length"},
+				{Name: "Magic"},
+			},
+		})
+		s := spew.Sdump(wireHeader{Version: 4, Flags: 1, Length: 28, Magic: [2]byte{0xca, 0xfe}})
+		expected := "(spew_test.wireHeader) (wire: 40 bits) {\n" +
+			"  Version: bits 0-3 [0100] (uint8) 4,\n" +
+			"  Flags: bits 4-7 [0001] (uint8) 1,\n" +
+			"  Length: bits 8-23 [00 1c] (uint16) 28,\n" +
+			"  Magic: bits 24-39 [ca fe] ([2]uint8) (len: 2 cap: 2) {\n" +
+			"    00000000  ca fe                                             |..|\n" +
+			"  }\n" +
+			"}\n"
+		Expect(s).To(Equal(expected))
+	})
+
+	It("uses natural sizes and the configured byte order", func() {
+		spew.RegisterBinaryLayout(reflect.TypeOf(wireLE{}), spew.BinaryLayout{Order: binary.LittleEndian})
+		s := spew.Sdump(wireLE{ID: 0x01020304, Ok: true, Skip: -1})
+		expected := "(spew_test.wireLE) (wire: 48 bits) {\n" +
+			"  ID: bits 0-31 [04 03 02 01] (uint32) 16909060,\n" +
+			"  Ok: bits 32-39 [01] (bool) true,\n" +
+			"  Skip: bits 40-47 [ff] (int8) -1\n" +
+			"}\n"
+		Expect(s).To(Equal(expected))
+	})
+
+	It("panics for invalid layouts", func() {
+		Expect(func() {
+			spew.RegisterBinaryLayout(reflect.TypeOf(1), spew.BinaryLayout{})
+		}).To(Panic())
+		Expect(func() {
+			spew.RegisterBinaryLayout(reflect.TypeOf(wireLE{}), spew.BinaryLayout{
+				Fields: []spew.BinaryField{{Name: "Missing"}},
+			})
+		}).To(Panic())
+		Expect(func() {
+			spew.RegisterBinaryLayout(reflect.TypeOf(wireLE{}), spew.BinaryLayout{
+				Fields: []spew.BinaryField{{Name: "ID", Bits: -1}},
+			})
+		}).To(PanicWith(`spew: binary layout for spew_test.wireLE has field "ID" of invalid width -1 bits`))
+		Expect(func() {
+			spew.RegisterBinaryLayout(reflect.TypeOf(wireLE{}), spew.BinaryLayout{
+				Fields: []spew.BinaryField{{Name: "ID", Bits: 65}},
+			})
+		}).To(Panic())
+	})
+
+	It("shows exactly the bits of array fields", func() {
+		registerWireTag()
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		s := cs.Sdump(wireTag{Kind: 1, Tag: [2]byte{0xab, 0xcd}, Serial: [4]byte{1, 2, 3, 4}})
+		Expect(s).To(ContainSubstring("  Tag: bits 4-15 [101111001101] ([2]uint8)"))
+		Expect(s).To(ContainSubstring("  Serial: bits 16-31 [03 04] ([4]uint8)"))
+	})
+
+	It("applies the depth limit and field casing", func() {
+		registerWireTag()
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		type wrapper struct{ H wireTag }
+		Expect(cs.Sdump(wrapper{})).To(Equal("(spew_test.wrapper) {\n" +
+			"  H: (spew_test.wireTag) (wire: 32 bits) {\n" +
+			"    <max depth reached>\n" +
+			"  }\n" +
+			"}\n"))
+
+		cs = spew.NewTestConfig()
+		cs.FieldCase = spew.FieldCaseSnake
+		Expect(cs.Sdump(wireTag{})).To(ContainSubstring("\n  serial /* Serial */: bits 16-31 "))
+	})
+})
@@ -0,0 +1,131 @@
+package spew
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BytesMode specifies how Dump displays byte arrays and slices.
+type BytesMode int
+
+const (
+	// BytesHexdump displays bytes in hexdump -C fashion.  This is the
+	// default.
+	BytesHexdump BytesMode = iota
+
+	// BytesBase64 displays bytes as a quoted standard base64 string.
+	BytesBase64
+
+	// BytesString displays bytes as a quoted string when they are valid
+	// UTF-8 made up entirely of printable characters and whitespace, and
+	// falls back to a hexdump otherwise.
+	BytesString
+
+	// BytesGoLiteral displays bytes as a Go byte slice literal such as
+	// []byte{0x68, 0x69}.
+	BytesGoLiteral
+
+	// BytesLength displays only the number of bytes, which keeps dumps of
+	// large binary payloads short.
+	BytesLength
+)
+
+var (
+	base64Bytes        = []byte("(base64) ")
+	byteLitBytes       = []byte("[]byte{")
+	byteCountBytes     = []byte(" bytes>")
+	bytesAsStringBytes = []byte("([]byte as string) ")
+)
+
+// maxUnprintableRatio is the largest fraction of the characters of a byte
+// slice which may be neither printable nor whitespace for the slice to be
+// considered mostly printable by the BytesAsText option.
+const maxUnprintableRatio = 0.05
+
+// unprintable returns the number of characters of the valid UTF-8 buf which
+// are neither printable nor whitespace, and the total number of characters.
+func unprintable(buf []byte) (bad, total int) {
+	for _, r := range string(buf) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			bad++
+		}
+		total++
+	}
+	return bad, total
+}
+
+// isPrintableText returns whether buf is valid UTF-8 made up entirely of
+// printable characters and whitespace.
+func isPrintableText(buf []byte) bool {
+	if !utf8.Valid(buf) {
+		return false
+	}
+	bad, _ := unprintable(buf)
+	return bad == 0
+}
+
+// isMostlyPrintableText returns whether buf is valid UTF-8 made up mostly of
+// printable characters and whitespace.
+func isMostlyPrintableText(buf []byte) bool {
+	if !utf8.Valid(buf) {
+		return false
+	}
+	bad, total := unprintable(buf)
+	return float64(bad) <= maxUnprintableRatio*float64(total)
+}
+
+// dumpBytes displays the byte array or slice v according to the BytesMode
+// and BytesAsText options and returns whether it did.  Nothing is written for
+// values which are not bytes, which are empty, or which are displayed as a
+// hexdump.
+func (d *dumpState) dumpBytes(v reflect.Value) bool {
+	if d.cs.BytesMode == BytesHexdump && !d.cs.BytesAsText {
+		return false
+	}
+	buf, ok := byteSlice(v)
+	if !ok {
+		return false
+	}
+
+	switch d.cs.BytesMode {
+	case BytesHexdump:
+		if !isMostlyPrintableText(buf) {
+			return false
+		}
+		d.w.Write(bytesAsStringBytes)
+		printString(d.w, d.cs, strconv.Quote(string(buf)))
+
+	case BytesBase64:
+		d.w.Write(base64Bytes)
+		printString(d.w, d.cs, strconv.Quote(base64.StdEncoding.EncodeToString(buf)))
+
+	case BytesString:
+		if !isPrintableText(buf) {
+			return false
+		}
+		printString(d.w, d.cs, strconv.Quote(string(buf)))
+
+	case BytesGoLiteral:
+		d.w.Write(byteLitBytes)
+		lits := make([]string, len(buf))
+		for i, b := range buf {
+			lits[i] = fmt.Sprintf("0x%02x", b)
+		}
+		d.w.Write([]byte(strings.Join(lits, ", ")))
+		d.w.Write(closeBraceBytes)
+
+	case BytesLength:
+		d.w.Write(openAngleBytes)
+		printNumber(d.w, d.cs, len(buf))
+		d.w.Write(byteCountBytes)
+
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BytesMode Tests", func() {
+	text := []byte("hi \"you\"\n")
+	binary := []byte{0x00, 0xff}
+
+	It("displays bytes as a hexdump by default", func() {
+		Expect(spew.NewTestConfig().Sdump(binary)).To(Equal(
+			"([]uint8) (len: 2 cap: 2) {\n  00000000  00 ff                                             |..|\n}\n"))
+	})
+
+	It("displays bytes in the configured mode", func() {
+		cfg := spew.NewTestConfig()
+		cfg.BytesMode = spew.BytesBase64
+		Expect(cfg.Sdump(binary)).To(Equal("([]uint8) (len: 2 cap: 2) (base64) \"AP8=\"\n"))
+
+		cfg.BytesMode = spew.BytesGoLiteral
+		Expect(cfg.Sdump([2]byte{1, 2})).To(Equal("([2]uint8) (len: 2 cap: 2) []byte{0x01, 0x02}\n"))
+
+		cfg.BytesMode = spew.BytesLength
+		Expect(cfg.Sdump(binary)).To(Equal("([]uint8) (len: 2 cap: 2) <2 bytes>\n"))
+
+		cfg.BytesMode = spew.BytesString
+		Expect(cfg.Sdump(text)).To(Equal("([]uint8) (len: 9 cap: 9) \"hi \\\"you\\\"\\n\"\n"))
+		Expect(cfg.Sdump(binary)).To(HaveSuffix("|..|\n}\n"))
+	})
+
+	It("leaves empty and non-byte slices alone", func() {
+		cfg := spew.NewTestConfig()
+		cfg.BytesMode = spew.BytesBase64
+		Expect(cfg.Sdump([]byte{})).To(Equal("([]uint8) {\n}\n"))
+		Expect(cfg.Sdump([]int{1})).To(Equal("([]int) (len: 1 cap: 1) {\n  (int) 1\n}\n"))
+	})
+
+	It("displays mostly printable bytes as text when requested", func() {
+		cfg := spew.NewTestConfig()
+		cfg.BytesAsText = true
+		body := []byte(`{"id": 1, "name": "caf` + "é" + `"}` + "\x00")
+		Expect(cfg.Sdump(body)).To(Equal(
+			"([]uint8) (len: 27 cap: 27) ([]byte as string) \"{\\\"id\\\": 1, \\\"name\\\": \\\"café\\\"}\\x00\"\n"))
+		Expect(cfg.Sdump(binary)).To(HaveSuffix("|..|\n}\n"))
+		Expect(cfg.Sdump([]byte{'a', 0xff})).To(HaveSuffix("|a.|\n}\n"))
+
+		cfg.BytesMode = spew.BytesBase64
+		Expect(cfg.Sdump(text)).To(HavePrefix("([]uint8) (len: 9 cap: 9) (base64) "))
+	})
+})
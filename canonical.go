@@ -0,0 +1,217 @@
+package spew
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// canonicalConfig is the configuration used to walk values for Canonical.
+// Methods are never invoked since their output is outside of spew's control
+// and frequently includes non-deterministic data such as addresses.
+var canonicalConfig = ConfigState{DisableMethods: true}
+
+// canonicalState returns the configuration used to walk values for Canonical,
+// which redacts values according to the Redact options of the global
+// configuration.
+func canonicalState() *ConfigState {
+	cs := canonicalConfig
+	g := global()
+	cs.Redact = g.Redact
+	cs.RedactFields = g.RedactFields
+	cs.RedactTypes = g.RedactTypes
+	cs.RedactFunc = g.RedactFunc
+	return &cs
+}
+
+/*
+Canonical returns a minimal, deterministic encoding of the observable structure
+of v.  It is intended for deduplicating fuzzing corpora and for regression
+fixtures, so the output is optimized for being small and byte-stable rather
+than for readability:
+
+  - The root value is tagged with its type and nested values are only tagged
+    when their dynamic type differs from the static type of their container,
+    as happens with interfaces
+  - Map entries are ordered by the encoding of their keys
+  - Pointer addresses are never included.  Pointers are encoded as & followed
+    by the value they point to, and a pointer, map or slice referring back to
+    a value which is still being encoded is encoded as @ followed by the
+    number of levels up to it
+  - Channels, functions and unsafe pointers only record whether they are nil
+  - Error and Stringer interfaces are never invoked
+  - Values are redacted according to the Redact, RedactFields, RedactTypes
+    and RedactFunc options of the global configuration, and are encoded as
+    their placeholder
+
+For example, a pointer to a struct with an int field and an interface field
+holding a string is encoded as:
+
+	(*main.T)&{A:1 B:(string)"b"}
+*/
+func Canonical(v interface{}) []byte {
+	var buf bytes.Buffer
+	if v == nil {
+		buf.WriteString("nil")
+		return buf.Bytes()
+	}
+	root := buildTree(canonicalState(), reflect.ValueOf(v))
+	writeCanonical(&buf, root, nil)
+	return buf.Bytes()
+}
+
+// writeCanonical writes the canonical encoding of n to buf.  The static type
+// is the type the container of n declares for it, or nil when unknown.
+func writeCanonical(buf *bytes.Buffer, n *node, static reflect.Type) {
+	if n.kind == reflect.Interface && n.isNil {
+		buf.WriteString("nil")
+		return
+	}
+	if n.typ != static {
+		buf.WriteByte('(')
+		buf.WriteString(n.typ.String())
+		buf.WriteByte(')')
+	}
+	if n.str != "" {
+		// Only redacted values have text since methods are never invoked.
+		buf.WriteString(n.str)
+		return
+	}
+
+	switch n.kind {
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(n.value.(bool)))
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		buf.WriteString(strconv.FormatInt(n.value.(int64), 10))
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		buf.WriteString(strconv.FormatUint(n.value.(uint64), 10))
+
+	case reflect.Uintptr:
+		buf.WriteString(strconv.FormatUint(uint64(n.value.(uintptr)), 10))
+
+	case reflect.Float32:
+		buf.WriteString(strconv.FormatFloat(n.value.(float64), 'g', -1, 32))
+
+	case reflect.Float64:
+		buf.WriteString(strconv.FormatFloat(n.value.(float64), 'g', -1, 64))
+
+	case reflect.Complex64:
+		buf.WriteString(strconv.FormatComplex(n.value.(complex128), 'g', -1, 64))
+
+	case reflect.Complex128:
+		buf.WriteString(strconv.FormatComplex(n.value.(complex128), 'g', -1, 128))
+
+	case reflect.String:
+		buf.WriteString(strconv.Quote(n.value.(string)))
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if n.isNil {
+			buf.WriteString("nil")
+		} else {
+			buf.WriteByte('_')
+		}
+
+	case reflect.Ptr:
+		switch {
+		case n.isNil:
+			buf.WriteString("nil")
+		case n.cycle:
+			writeCanonicalCycle(buf, n)
+		default:
+			buf.WriteByte('&')
+			writeCanonical(buf, n.children[0], n.typ.Elem())
+		}
+
+	case reflect.Slice, reflect.Array:
+		if n.isNil {
+			buf.WriteString("nil")
+			break
+		}
+		if n.cycle {
+			writeCanonicalCycle(buf, n)
+			break
+		}
+		buf.WriteByte('[')
+		writeCanonicalTruncated(buf, n)
+		for i, c := range n.children {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			writeCanonical(buf, c, n.typ.Elem())
+		}
+		buf.WriteByte(']')
+
+	case reflect.Map:
+		if n.isNil {
+			buf.WriteString("nil")
+			break
+		}
+		if n.cycle {
+			writeCanonicalCycle(buf, n)
+			break
+		}
+		entries := make([][2][]byte, len(n.children))
+		for i, c := range n.children {
+			var k, v bytes.Buffer
+			writeCanonical(&k, c.key, n.typ.Key())
+			writeCanonical(&v, c, n.typ.Elem())
+			entries[i] = [2][]byte{k.Bytes(), v.Bytes()}
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i][0], entries[j][0]) < 0
+		})
+		buf.WriteByte('{')
+		writeCanonicalTruncated(buf, n)
+		for i, e := range entries {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.Write(e[0])
+			buf.WriteByte(':')
+			buf.Write(e[1])
+		}
+		buf.WriteByte('}')
+
+	case reflect.Struct:
+		buf.WriteByte('{')
+		writeCanonicalTruncated(buf, n)
+		for i, c := range n.children {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(c.name)
+			buf.WriteByte(':')
+			writeCanonical(buf, c, n.typ.Field(c.field).Type)
+		}
+		buf.WriteByte('}')
+	}
+}
+
+// writeCanonicalTruncated writes the marker for containers whose elements
+// were not visited due to the MaxDepth option.
+func writeCanonicalTruncated(buf *bytes.Buffer, n *node) {
+	if n.truncated {
+		buf.WriteString("...")
+	}
+}
+
+// writeCanonicalCycle writes the reference for a circular node, which is the
+// number of levels up to the node it refers back to.
+func writeCanonicalCycle(buf *bytes.Buffer, n *node) {
+	buf.WriteByte('@')
+	buf.WriteString(strconv.Itoa(cycleDistance(n)))
+}
+
+// cycleDistance returns the number of levels between the circular node n and
+// the node of the same kind with the same address higher up in the tree.
+func cycleDistance(n *node) int {
+	for p := n.parent; p != nil; p = p.parent {
+		if p.kind == n.kind && p.addr == n.addr {
+			return n.depth - p.depth
+		}
+	}
+	return 0
+}
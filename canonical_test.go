@@ -0,0 +1,80 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type canonicalTester struct {
+	A int
+	B interface{}
+	m map[string]int
+}
+
+type canonicalLogin struct {
+	User     string
+	Password string
+}
+
+type canonicalHidden struct {
+	A int `spew:"-"`
+	B interface{}
+}
+
+var _ = Describe("Canonical Tests", func() {
+	It("encodes values with minimal type tags", func() {
+		v := &canonicalTester{A: 1, B: "b", m: map[string]int{"z": 26, "a": 1}}
+		Expect(string(spew.Canonical(v))).To(Equal(`(*spew_test.canonicalTester)&{A:1 B:(string)"b" m:{"a":1 "z":26}}`))
+	})
+
+	It("encodes nil values", func() {
+		Expect(string(spew.Canonical(nil))).To(Equal("nil"))
+		Expect(string(spew.Canonical([]int(nil)))).To(Equal("([]int)nil"))
+		Expect(string(spew.Canonical([]interface{}{nil, 1}))).To(Equal("([]interface {})[nil (int)1]"))
+	})
+
+	It("is stable across map iteration order", func() {
+		m := map[int]string{}
+		for i := 0; i < 50; i++ {
+			m[i] = "v"
+		}
+		first := spew.Canonical(m)
+		for i := 0; i < 10; i++ {
+			Expect(spew.Canonical(m)).To(Equal(first))
+		}
+	})
+
+	It("encodes circular references without addresses", func() {
+		ic := indirCir1{}
+		ic2 := indirCir2{}
+		ic3 := indirCir3{}
+		ic.ps2 = &ic2
+		ic2.ps3 = &ic3
+		ic3.ps1 = &ic
+		Expect(string(spew.Canonical(&ic))).To(Equal("(*spew_test.indirCir1)&{ps2:&{ps3:&{ps1:@6}}}"))
+	})
+
+	It("does not invoke methods", func() {
+		Expect(string(spew.Canonical(stringer("x")))).To(Equal(`(spew_test.stringer)"x"`))
+	})
+
+	It("encodes circular maps", func() {
+		m := map[string]interface{}{}
+		m["self"] = m
+		Expect(string(spew.Canonical(m))).To(Equal(`(map[string]interface {}){"self":(map[string]interface {})@1}`))
+	})
+
+	It("uses the static type of fields after hidden ones", func() {
+		Expect(string(spew.Canonical(canonicalHidden{B: 3}))).To(Equal(`(spew_test.canonicalHidden){B:(int)3}`))
+	})
+
+	It("redacts values according to the global configuration", func() {
+		cs := spew.CurrentConfig().Clone()
+		cs.Redact = true
+		spew.SetConfig(cs)
+		defer spew.SetConfig(nil)
+		Expect(string(spew.Canonical(canonicalLogin{User: "bob", Password: "hunter2"}))).To(Equal(
+			`(spew_test.canonicalLogin){User:"bob" Password:[REDACTED len=7]}`))
+	})
+})
@@ -0,0 +1,61 @@
+package spew
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+)
+
+// CapabilitySet reports which optional subsystems are compiled in or active in
+// the running program.  See Capabilities.
+type CapabilitySet struct {
+	// Unsafe reports whether access to the unsafe package is available, which
+	// is required to invoke Stringer and error interfaces on unexported
+	// fields and to dump unexported values which can't be interfaced.  It is
+	// the inverse of UnsafeDisabled.
+	Unsafe bool
+
+	// Color reports whether colored output is active.  Colors are disabled
+	// when the output is not a terminal or the NO_COLOR environment variable
+	// is set.
+	Color bool
+
+	// TrueColor reports whether colored output is active and the terminal
+	// advertises 24-bit color support through the COLORTERM environment
+	// variable.
+	TrueColor bool
+
+	// WellKnownTypes reports whether the well-known types of the standard
+	// library, such as the database/sql Null types, net/netip addresses,
+	// slog values and contexts, are displayed by what they hold with the
+	// WellKnownTypes option.  They are always compiled in.
+	WellKnownTypes bool
+
+	// ProtoText reports whether values can be written in the protobuf text
+	// format with FdumpProtoText and ProtoTextRenderer, which is always
+	// compiled in.
+	ProtoText bool
+
+	// Zstd reports whether ZstdSink is available, which requires building
+	// with the zstd build tag.
+	Zstd bool
+}
+
+// Capabilities returns the optional subsystems which are compiled in or
+// active in the running program, so libraries embedding spew can adapt their
+// behavior and tests can be skipped on restricted platforms.  Color and
+// TrueColor reflect the environment at the time of the call.
+func Capabilities() CapabilitySet {
+	c := CapabilitySet{
+		Unsafe:         !UnsafeDisabled,
+		Color:          !color.NoColor,
+		WellKnownTypes: true,
+		ProtoText:      true,
+		Zstd:           zstdAvailable,
+	}
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		c.TrueColor = c.Color
+	}
+	return c
+}
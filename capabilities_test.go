@@ -0,0 +1,50 @@
+package spew_test
+
+import (
+	"os"
+
+	spew "github.com/ehowe/rainbow-spew"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Capabilities Tests", func() {
+	It("reports unsafe access", func() {
+		Expect(spew.Capabilities().Unsafe).To(Equal(!spew.UnsafeDisabled))
+	})
+
+	It("reports the well-known types and protobuf text output", func() {
+		c := spew.Capabilities()
+		Expect(c.WellKnownTypes).To(BeTrue())
+		Expect(c.ProtoText).To(BeTrue())
+	})
+
+	It("reports color support", func() {
+		noColor := color.NoColor
+		defer func() { color.NoColor = noColor }()
+		colorTerm, ok := os.LookupEnv("COLORTERM")
+		defer func() {
+			if ok {
+				os.Setenv("COLORTERM", colorTerm)
+			} else {
+				os.Unsetenv("COLORTERM")
+			}
+		}()
+
+		os.Setenv("COLORTERM", "truecolor")
+		color.NoColor = false
+		c := spew.Capabilities()
+		Expect(c.Color).To(BeTrue())
+		Expect(c.TrueColor).To(BeTrue())
+
+		os.Setenv("COLORTERM", "")
+		Expect(spew.Capabilities().TrueColor).To(BeFalse())
+
+		os.Setenv("COLORTERM", "truecolor")
+		color.NoColor = true
+		c = spew.Capabilities()
+		Expect(c.Color).To(BeFalse())
+		Expect(c.TrueColor).To(BeFalse())
+	})
+})
@@ -0,0 +1,85 @@
+package spew
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// FieldCase specifies the casing struct field names are displayed in.
+type FieldCase int
+
+const (
+	// FieldCaseGo displays field names as they are declared in Go.  This is
+	// the default.
+	FieldCaseGo FieldCase = iota
+
+	// FieldCaseSnake displays field names in snake_case, so UserID is
+	// displayed as user_id.
+	FieldCaseSnake
+
+	// FieldCaseCamel displays field names in camelCase, so UserID is
+	// displayed as userId.
+	FieldCaseCamel
+)
+
+// fieldWords splits the Go field name into its lowercase words.  Runs of
+// capitals are treated as a single word, such as ID in UserID, except for
+// their last capital when it starts a new word, as in HTTPServer.
+func fieldWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		boundary := i == len(runes) || runes[i] == '_'
+		if !boundary {
+			prev, cur := runes[i-1], runes[i]
+			switch {
+			case unicode.IsUpper(cur) && !unicode.IsUpper(prev) && prev != '_':
+				boundary = true
+			case unicode.IsUpper(cur) && unicode.IsUpper(prev) &&
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+				boundary = true
+			}
+		}
+		if !boundary {
+			continue
+		}
+		if word := strings.Trim(string(runes[start:i]), "_"); word != "" {
+			words = append(words, strings.ToLower(word))
+		}
+		start = i
+	}
+	return words
+}
+
+// caseFieldName returns the Go field name in the passed casing.  Names which
+// can't be split into words, such as _, are returned unchanged.
+func caseFieldName(name string, fc FieldCase) string {
+	words := fieldWords(name)
+	if fc == FieldCaseGo || len(words) == 0 {
+		return name
+	}
+	if fc == FieldCaseSnake {
+		return strings.Join(words, "_")
+	}
+	for i := 1; i < len(words); i++ {
+		r := []rune(words[i])
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, "")
+}
+
+// writeFieldName outputs the name of a struct field to Writer w in the
+// casing specified by the FieldCase option.  The Go name follows in a comment
+// when it differs so the field can still be found in the source.
+func writeFieldName(w io.Writer, cs *ConfigState, name string) {
+	cased := caseFieldName(name, cs.FieldCase)
+	w.Write([]byte(cased))
+	if cased != name {
+		w.Write(openCommentBytes)
+		w.Write([]byte(name))
+		w.Write(closeCommentBytes)
+	}
+}
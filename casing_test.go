@@ -0,0 +1,56 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type casedFields struct {
+	UserID     int
+	HTTPServer string
+	Name       string
+	Field2     bool
+	user_name  string
+}
+
+var _ = Describe("FieldCase Tests", func() {
+	v := casedFields{UserID: 1, HTTPServer: "a", Name: "b", Field2: true, user_name: "c"}
+
+	It("displays field names as declared by default", func() {
+		cfg := spew.NewTestConfig()
+		Expect(cfg.Sdump(v)).To(ContainSubstring("\n  UserID: (int) 1,\n"))
+	})
+
+	It("displays field names in snake_case", func() {
+		cfg := spew.NewTestConfig()
+		cfg.FieldCase = spew.FieldCaseSnake
+		Expect(cfg.Sdump(v)).To(Equal(`(spew_test.casedFields) {
+  user_id /* UserID */: (int) 1,
+  http_server /* HTTPServer */: (string) (len: 1) "a",
+  name /* Name */: (string) (len: 1) "b",
+  field2 /* Field2 */: (bool) true,
+  user_name: (string) (len: 1) "c"
+}
+`))
+	})
+
+	It("displays field names in camelCase", func() {
+		cfg := spew.NewTestConfig()
+		cfg.FieldCase = spew.FieldCaseCamel
+		Expect(cfg.Sdump(v)).To(Equal(`(spew_test.casedFields) {
+  userId /* UserID */: (int) 1,
+  httpServer /* HTTPServer */: (string) (len: 1) "a",
+  name /* Name */: (string) (len: 1) "b",
+  field2 /* Field2 */: (bool) true,
+  userName /* user_name */: (string) (len: 1) "c"
+}
+`))
+	})
+
+	It("renames fields in trees", func() {
+		cfg := spew.NewTestConfig()
+		cfg.FieldCase = spew.FieldCaseSnake
+		Expect(cfg.SdumpTree(struct{ UserID int }{1})).To(Equal("(struct { UserID int })\n└── user_id /* UserID */: (int) 1\n"))
+	})
+})
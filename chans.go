@@ -0,0 +1,56 @@
+package spew
+
+import "reflect"
+
+var (
+	dirEqualsBytes   = []byte("dir: ")
+	elemEqualsBytes  = []byte("elem: ")
+	unbufferedBytes  = []byte("unbuffered")
+	fullBufferBytes  = []byte("full")
+	chanDirRecvBytes = []byte("recv")
+	chanDirSendBytes = []byte("send")
+	chanDirBothBytes = []byte("both")
+)
+
+// chanDirBytes returns the name of the direction dir of a channel.
+func chanDirBytes(dir reflect.ChanDir) []byte {
+	switch dir {
+	case reflect.RecvDir:
+		return chanDirRecvBytes
+	case reflect.SendDir:
+		return chanDirSendBytes
+	}
+	return chanDirBothBytes
+}
+
+// chanDetails handles the ChannelDetails option by writing the direction,
+// element type and buffer usage of the channel v after its address, as in
+// (dir: both elem: int len: 4 cap: 4 full).  Nil channels have no buffer,
+// so nothing is written for them.
+func (d *dumpState) chanDetails(v reflect.Value) {
+	if !d.cs.ChannelDetails || v.IsNil() {
+		return
+	}
+	d.w.Write(spaceBytes)
+	withParens(d, func(d *dumpState) {
+		withColor(d.w, dirEqualsBytes, d.cs.Color.Length...)
+		d.w.Write(chanDirBytes(v.Type().ChanDir()))
+		d.w.Write(spaceBytes)
+		withColor(d.w, elemEqualsBytes, d.cs.Color.Length...)
+		printType(d.w, d.cs, v.Type().Elem().String())
+		d.w.Write(spaceBytes)
+		if v.Cap() == 0 {
+			withColor(d.w, unbufferedBytes, d.cs.Color.Length...)
+			return
+		}
+		withColor(d.w, lenEqualsBytes, d.cs.Color.Length...)
+		printNumber(d.w, d.cs, v.Len())
+		d.w.Write(spaceBytes)
+		withColor(d.w, capEqualsBytes, d.cs.Color.Length...)
+		printNumber(d.w, d.cs, v.Cap())
+		if v.Len() == v.Cap() {
+			d.w.Write(spaceBytes)
+			withColor(d.w, fullBufferBytes, d.cs.Color.Length...)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Channel Details Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.ChannelDetails = true
+		return cs
+	}
+
+	It("displays the buffer usage of buffered channels", func() {
+		ch := make(chan int, 4)
+		ch <- 1
+		Expect(newConfig().Sdump(ch)).To(Equal(fmt.Sprintf("(chan int) %p (dir: both elem: int len: 1 cap: 4)\n", ch)))
+	})
+
+	It("marks full channels", func() {
+		ch := make(chan string, 1)
+		ch <- "x"
+		var recv <-chan string = ch
+		Expect(newConfig().Sdump(recv)).To(Equal(fmt.Sprintf("(<-chan string) %p (dir: recv elem: string len: 1 cap: 1 full)\n", ch)))
+	})
+
+	It("displays unbuffered channels", func() {
+		ch := make(chan struct{})
+		var send chan<- struct{} = ch
+		Expect(newConfig().Sdump(send)).To(Equal(fmt.Sprintf("(chan<- struct {}) %p (dir: send elem: struct {} unbuffered)\n", ch)))
+	})
+
+	It("leaves nil channels unchanged", func() {
+		var ch chan int
+		Expect(newConfig().Sdump(ch)).To(Equal("(chan int) <nil>\n"))
+	})
+})
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// segment is a single step of a filter path.  Keys are matched against map
+// keys as globs, while indexes select elements of arrays.
+type segment struct {
+	key     string
+	index   int
+	isIndex bool
+	anyElem bool
+}
+
+// filter selects the values of a parsed document matched by its path and,
+// when op is set, compares them against value.
+type filter struct {
+	path  []segment
+	op    string
+	value interface{}
+}
+
+// match is a value selected by a filter along with its concrete path.
+type match struct {
+	path  string
+	value interface{}
+}
+
+// operators lists the supported comparison operators.  Longer operators come
+// first so they are matched before their prefixes.
+var operators = []string{">=", "<=", "==", "!=", ">", "<"}
+
+// parseFilter parses a filter expression such as .items[*].price > 1_000.
+// The expression is a path, optionally followed by a comparison against a
+// number, a quoted string, true, false or null.
+func parseFilter(expr string) (*filter, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, ".") {
+		return nil, fmt.Errorf("filter %q: path must start with '.'", expr)
+	}
+
+	f := &filter{}
+	rest := expr
+	for rest != "" && rest[0] != ' ' && !strings.ContainsRune("<>=!", rune(rest[0])) {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[ <>=!")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			if key := rest[1 : end+1]; key != "" {
+				if _, err := path.Match(key, ""); err != nil {
+					return nil, fmt.Errorf("filter %q: bad key pattern %q", expr, key)
+				}
+				f.path = append(f.path, segment{key: key})
+			}
+			rest = rest[end+1:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("filter %q: unterminated '['", expr)
+			}
+			seg, err := parseIndex(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("filter %q: %v", expr, err)
+			}
+			f.path = append(f.path, seg)
+			rest = rest[end+1:]
+
+		default:
+			return nil, fmt.Errorf("filter %q: unexpected %q", expr, rest[0])
+		}
+	}
+
+	rest = strings.TrimSpace(rest)
+	if rest == "" {
+		return f, nil
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			f.op = op
+			break
+		}
+	}
+	if f.op == "" {
+		return nil, fmt.Errorf("filter %q: expected a comparison operator", expr)
+	}
+	value, err := parseLiteral(strings.TrimSpace(rest[len(f.op):]))
+	if err != nil {
+		return nil, fmt.Errorf("filter %q: %v", expr, err)
+	}
+	f.value = value
+	return f, nil
+}
+
+// parseIndex parses the contents of a bracketed path segment, which is either
+// an array index, * for every element, or a quoted map key.
+func parseIndex(s string) (segment, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "*":
+		return segment{isIndex: true, anyElem: true}, nil
+	case strings.HasPrefix(s, `"`):
+		key, err := strconv.Unquote(s)
+		if err != nil {
+			return segment{}, fmt.Errorf("bad quoted key %s", s)
+		}
+		return segment{key: key}, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return segment{}, fmt.Errorf("bad index %q", s)
+	}
+	return segment{isIndex: true, index: i}, nil
+}
+
+// parseLiteral parses the value a filter compares against.
+func parseLiteral(s string) (interface{}, error) {
+	switch s {
+	case "":
+		return nil, fmt.Errorf("missing value to compare against")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'") {
+		if s[0] == '\'' && len(s) > 1 && s[len(s)-1] == '\'' {
+			s = `"` + strings.ReplaceAll(s[1:len(s)-1], `"`, `\"`) + `"`
+		}
+		str, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("bad string %s", s)
+		}
+		return str, nil
+	}
+	if n, ok := parseNumber(s); ok {
+		return n, nil
+	}
+	return nil, fmt.Errorf("bad value %q", s)
+}
+
+// parseNumber parses s as a number which may group the digits of its integer
+// part in thousands with underscores, commas or spaces, as in 1_000, 1,000 or
+// 1 000.  Every group after the first must have exactly three digits so a
+// list such as 1,2 is not mistaken for a number.  Only decimal digits,
+// separators, signs, a decimal point and an exponent are accepted, so words
+// such as NaN and Inf are not numbers.
+func parseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if strings.Trim(s, "0123456789_, +-.eE") != "" {
+		return 0, false
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexAny(s, ".eE"); i != -1 {
+		intPart, frac = s[:i], s[i:]
+	}
+
+	groups := strings.FieldsFunc(intPart, func(r rune) bool {
+		return r == '_' || r == ',' || r == ' '
+	})
+	if len(groups) > 1 {
+		if strings.Count(intPart, "_")+strings.Count(intPart, ",")+
+			strings.Count(intPart, " ") != len(groups)-1 {
+			return 0, false
+		}
+		for _, g := range groups[1:] {
+			if len(g) != 3 {
+				return 0, false
+			}
+		}
+	}
+
+	n, err := strconv.ParseFloat(strings.Join(groups, "")+frac, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// apply returns the values of doc matched by f in document order.
+func (f *filter) apply(doc interface{}) []match {
+	var matches []match
+	var walk func(v interface{}, p string, segs []segment)
+	walk = func(v interface{}, p string, segs []segment) {
+		if len(segs) == 0 {
+			if f.op == "" || compare(v, f.op, f.value) {
+				matches = append(matches, match{path: p, value: v})
+			}
+			return
+		}
+		seg := segs[0]
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if seg.isIndex {
+				return
+			}
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				if ok, _ := path.Match(seg.key, k); ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				walk(v[k], p+keyPath(k), segs[1:])
+			}
+
+		case []interface{}:
+			if !seg.isIndex {
+				return
+			}
+			for i, elem := range v {
+				if seg.anyElem || seg.index == i {
+					walk(elem, p+"["+strconv.Itoa(i)+"]", segs[1:])
+				}
+			}
+		}
+	}
+	walk(doc, "", f.path)
+	return matches
+}
+
+// keyPath returns the path segment which selects the map key k.
+func keyPath(k string) string {
+	for _, r := range k {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return "[" + strconv.Quote(k) + "]"
+		}
+	}
+	return "." + k
+}
+
+// toNumber returns v as a float64 when it is a number or a string which holds
+// a number.
+func toNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case string:
+		return parseNumber(v)
+	}
+	return 0, false
+}
+
+// compare returns whether the relation op holds between the document value v
+// and the filter value want.  Values of different types are only ever not
+// equal.
+func compare(v interface{}, op string, want interface{}) bool {
+	var c int
+	switch want := want.(type) {
+	case float64:
+		n, ok := toNumber(v)
+		if !ok {
+			return op == "!="
+		}
+		switch {
+		case n < want:
+			c = -1
+		case n > want:
+			c = 1
+		}
+
+	case string:
+		s, ok := v.(string)
+		if !ok {
+			return op == "!="
+		}
+		c = strings.Compare(s, want)
+
+	default:
+		// Booleans and null only support equality.
+		equal := v == want
+		switch op {
+		case "==":
+			return equal
+		case "!=":
+			return !equal
+		}
+		return false
+	}
+
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
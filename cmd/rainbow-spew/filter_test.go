@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter Tests", func() {
+	doc := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a", "price": 1500.0},
+			map[string]interface{}{"name": "b", "price": "2,500"},
+			map[string]interface{}{"name": "c", "price": 10},
+		},
+		"user name": "x",
+	}
+
+	paths := func(ms []match) []string {
+		out := make([]string, len(ms))
+		for i, m := range ms {
+			out[i] = m.path
+		}
+		return out
+	}
+
+	It("parses numbers grouped in thousands", func() {
+		for s, want := range map[string]float64{
+			"1_000": 1000, "1,000": 1000, "1 000": 1000, "-12,345.5": -12345.5, "1e3": 1000,
+		} {
+			n, ok := parseNumber(s)
+			Expect(ok).To(BeTrue(), s)
+			Expect(n).To(Equal(want), s)
+		}
+		for _, s := range []string{"1,2", "1__000", "1,0000", "abc", "NaN", "inf", "-Infinity", "0x10"} {
+			_, ok := parseNumber(s)
+			Expect(ok).To(BeFalse(), s)
+		}
+	})
+
+	It("selects values by path", func() {
+		f, err := parseFilter(".items[*].name")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths(f.apply(doc))).To(Equal([]string{".items[0].name", ".items[1].name", ".items[2].name"}))
+
+		f, err = parseFilter(`.["user name"]`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.apply(doc)).To(Equal([]match{{path: `["user name"]`, value: "x"}}))
+
+		f, err = parseFilter(".it*[1]")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths(f.apply(doc))).To(Equal([]string{".items[1]"}))
+	})
+
+	It("compares the selected values", func() {
+		f, err := parseFilter(".items[*].price > 1_000")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths(f.apply(doc))).To(Equal([]string{".items[0].price", ".items[1].price"}))
+
+		f, err = parseFilter(`.items[*].name == "c"`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths(f.apply(doc))).To(Equal([]string{".items[2].name"}))
+	})
+
+	It("rejects malformed filters", func() {
+		for _, expr := range []string{"items", ".items[", ".items[x]", ".a >", ".a ~ 1", ".a > 1,2"} {
+			_, err := parseFilter(expr)
+			Expect(err).To(HaveOccurred(), expr)
+		}
+	})
+
+	It("dumps the filtered document", func() {
+		var out, errOut bytes.Buffer
+		in := strings.NewReader("items:\n  - price: 1500\n  - price: 5\n")
+		code := run([]string{"-no-color", "-filter", ".items[*].price >= 1,000"}, in, &out, &errOut)
+		Expect(code).To(Equal(0), errOut.String())
+		Expect(out.String()).To(Equal(".items[0].price: (int) 1500\n"))
+	})
+
+	It("does not take words for numbers", func() {
+		f, err := parseFilter(".x == 5")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.apply(map[string]interface{}{"x": "NaN"})).To(BeEmpty())
+
+		_, err = parseFilter(".x > inf")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("dumps each of the files named", func() {
+		dir := GinkgoT().TempDir()
+		var names []string
+		for i, body := range []string{`{"a": 1}`, "b: 2\n"} {
+			name := filepath.Join(dir, []string{"a.json", "b.yaml"}[i])
+			Expect(os.WriteFile(name, []byte(body), 0o600)).To(Succeed())
+			names = append(names, name)
+		}
+		var out, errOut bytes.Buffer
+		code := run(append([]string{"-no-color"}, names...), nil, &out, &errOut)
+		Expect(code).To(Equal(0), errOut.String())
+		Expect(out.String()).To(ContainSubstring(`"a"`))
+		Expect(out.String()).To(ContainSubstring(`"b"`))
+	})
+})
@@ -0,0 +1,161 @@
+/*
+Command rainbow-spew dumps JSON and YAML documents with spew.
+
+Usage:
+
+	rainbow-spew [flags] [file ...]
+
+The documents are read from the named files, or from standard input when no
+files are given.  The flags are:
+
+	-format string
+		input format: json, yaml or auto (default "auto")
+	-filter string
+		only dump the values matched by the filter expression
+	-no-color
+		disable colored output
+
+A filter expression is a path, optionally followed by a comparison.  Paths
+select map keys with .name, where the name may be a glob such as .user*, or
+with ["quoted name"], and array elements with [N] or [*] for every element.
+Comparisons use one of == != < <= > >= against a number, a quoted string,
+true, false or null.  Numbers may group their digits in thousands with
+underscores, commas or spaces, and strings in the document which hold such
+numbers are compared numerically.  For example:
+
+	rainbow-spew -filter '.items[*].price > 1_000' orders.json
+
+dumps the price of every item costing more than 1000, each preceded by its
+path in the document.
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	spew "github.com/ehowe/rainbow-spew"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the passed arguments and returns its exit
+// status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("rainbow-spew", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "auto", "input format: json, yaml or auto")
+	filterExpr := fs.String("filter", "", "only dump the values matched by the filter expression")
+	noColor := fs.Bool("no-color", false, "disable colored output")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var f *filter
+	if *filterExpr != "" {
+		var err error
+		if f, err = parseFilter(*filterExpr); err != nil {
+			fmt.Fprintf(stderr, "rainbow-spew: %v\n", err)
+			return 2
+		}
+	}
+
+	cs := spew.NewDefaultConfig()
+	cs.SortKeys = true
+	cs.DisableDumpColor = *noColor
+
+	// The files named by the arguments are opened when they are decoded.
+	type input struct {
+		name string
+		r    io.Reader
+	}
+	var inputs []input
+	if fs.NArg() == 0 {
+		inputs = append(inputs, input{name: "-", r: stdin})
+	}
+	for _, name := range fs.Args() {
+		inputs = append(inputs, input{name: name})
+	}
+
+	for _, in := range inputs {
+		r := in.r
+		var file *os.File
+		if r == nil {
+			var err error
+			if file, err = os.Open(in.name); err != nil {
+				fmt.Fprintf(stderr, "rainbow-spew: %v\n", err)
+				return 1
+			}
+			r = file
+		}
+		doc, err := decode(r, inputFormat(*format, in.name))
+		// Files are closed once decoded so they aren't all held open.
+		if file != nil {
+			file.Close()
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "rainbow-spew: %s: %v\n", in.name, err)
+			return 1
+		}
+		if f == nil {
+			cs.Fdump(stdout, doc)
+			continue
+		}
+		for _, m := range f.apply(doc) {
+			fmt.Fprintf(stdout, "%s: ", m.path)
+			cs.Fdump(stdout, m.value)
+		}
+	}
+	return 0
+}
+
+// inputFormat returns the format of the input named name.  The auto format
+// is resolved from the file extension, or left to decode when it is unknown.
+func inputFormat(format, name string) string {
+	if format != "auto" {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	return "auto"
+}
+
+// decode parses the document read from r in the passed format.  Documents in
+// the auto format are parsed as JSON and, when that fails, as YAML.
+func decode(r io.Reader, format string) (interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	switch format {
+	case "json":
+		err = json.Unmarshal(data, &doc)
+	case "yaml":
+		err = yaml.Unmarshal(data, &doc)
+	case "auto":
+		if err = json.Unmarshal(data, &doc); err != nil {
+			doc = nil
+			err = yaml.Unmarshal(data, &doc)
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -34,6 +34,7 @@ var (
 	trueBytes             = []byte("true")
 	falseBytes            = []byte("false")
 	interfaceBytes        = []byte("(interface {})")
+	commaBytes            = []byte(",")
 	commaNewlineBytes     = []byte(",\n")
 	newlineBytes          = []byte("\n")
 	openBraceBytes        = []byte("{")
@@ -60,21 +61,36 @@ var (
 	closeAngleBytes       = []byte(">")
 	openMapBytes          = []byte("map[")
 	closeMapBytes         = []byte("]")
+	groupHeaderBytes      = []byte("-- ")
+	groupFooterBytes      = []byte(" --\n")
+	openCommentBytes      = []byte(" /* ")
+	closeCommentBytes     = []byte(" */")
 	lenEqualsBytes        = []byte(fmt.Sprintf("len%s", colonSpaceBytes))
 	capEqualsBytes        = []byte(fmt.Sprintf("cap%s", colonSpaceBytes))
+	wireEqualsBytes       = []byte(fmt.Sprintf("wire%s", colonSpaceBytes))
+	dumpIDEqualsBytes     = []byte(fmt.Sprintf("dump%s", colonSpaceBytes))
+	traceIDEqualsBytes    = []byte(fmt.Sprintf("trace%s", colonSpaceBytes))
+	sizeEqualsBytes       = []byte(fmt.Sprintf("size%s", colonSpaceBytes))
+	retainedEqualsBytes   = []byte(fmt.Sprintf("retained%s", colonSpaceBytes))
+	linesEqualsBytes      = []byte(fmt.Sprintf("lines%s", colonSpaceBytes))
+	bitsBytes             = []byte(" bits")
 )
 
 // hexDigits is used to map a decimal value to a hex digit.
 var hexDigits = "0123456789abcdef"
 
-// catchPanic handles any panics that might occur during the handleMethods
-// calls.
-func catchPanic(w io.Writer, v reflect.Value) {
-	if err := recover(); err != nil {
-		w.Write(panicBytes)
-		fmt.Fprintf(w, "%v", err)
-		w.Write(closeParenBytes)
-	}
+// visitKey identifies the internals of a map or slice.  It is used to detect
+// circular references which do not go through a pointer, such as a map which
+// contains itself through an interface value.
+type visitKey struct {
+	typ  reflect.Type
+	addr uintptr
+	len  int
+}
+
+// newVisitKey returns the visitKey for the map or slice v.
+func newVisitKey(v reflect.Value) visitKey {
+	return visitKey{typ: v.Type(), addr: v.Pointer(), len: v.Len()}
 }
 
 // handleMethods attempts to call the Error and String methods on the underlying
@@ -110,10 +126,25 @@ func handleMethods(cs *ConfigState, w io.Writer, v reflect.Value) (handled bool)
 		v = v.Addr()
 	}
 
+	// Is it a GoStringer, TextMarshaler or json.Marshaler whose text was
+	// requested?  These take precedence over error and Stringer since they
+	// are only used when asked for.
+	if text, ok := methodText(cs, w, v); ok {
+		if cs.ContinueOnMethod {
+			w.Write(openParenBytes)
+			w.Write([]byte(text))
+			w.Write(closeParenBytes)
+			w.Write(spaceBytes)
+			return false
+		}
+		w.Write([]byte(text))
+		return true
+	}
+
 	// Is it an error or Stringer?
 	switch iface := v.Interface().(type) {
 	case error:
-		defer catchPanic(w, v)
+		defer catchPanic(cs, w, v, "Error()")
 		if cs.ContinueOnMethod {
 			w.Write(openParenBytes)
 			w.Write([]byte(iface.Error()))
@@ -126,7 +157,7 @@ func handleMethods(cs *ConfigState, w io.Writer, v reflect.Value) (handled bool)
 		return true
 
 	case fmt.Stringer:
-		defer catchPanic(w, v)
+		defer catchPanic(cs, w, v, "String()")
 		if cs.ContinueOnMethod {
 			w.Write(openParenBytes)
 			w.Write([]byte(iface.String()))
@@ -140,6 +171,30 @@ func handleMethods(cs *ConfigState, w io.Writer, v reflect.Value) (handled bool)
 	return false
 }
 
+// handleFmtFallback writes v as formatted by fmt's %+v verb when its type is
+// one of the FmtFallbackTypes and returns whether it did so.
+func handleFmtFallback(cs *ConfigState, w io.Writer, v reflect.Value) bool {
+	if len(cs.FmtFallbackTypes) == 0 {
+		return false
+	}
+	typ := v.Type()
+	for _, t := range cs.FmtFallbackTypes {
+		if t != typ {
+			continue
+		}
+		if !v.CanInterface() {
+			if UnsafeDisabled {
+				return false
+			}
+			v = unsafeReflectValue(v)
+		}
+		defer catchPanic(cs, w, v, "%+v")
+		fmt.Fprintf(w, "%+v", v.Interface())
+		return true
+	}
+	return false
+}
+
 // printComplex outputs a complex value using the specified float precision
 // for the real and imaginary parts to Writer w.
 func printComplex(w io.Writer, c complex128, floatPrecision int) {
@@ -218,8 +273,21 @@ func newValuesSorter(values []reflect.Value, cs *ConfigState) sort.Interface {
 	}
 	if vs.strings == nil && cs.SpewKeys {
 		vs.strings = make([]string, len(values))
-		for i := range vs.values {
-			vs.strings[i] = Sprintf("%#v", vs.values[i].Interface())
+		for i, v := range vs.values {
+			// Keys of maps held in unexported fields can't be converted
+			// to an interface without bypassing the restriction, so
+			// their canonical encoding, which reads their fields through
+			// reflection, is compared instead.
+			if !v.CanInterface() {
+				if UnsafeDisabled {
+					var buf bytes.Buffer
+					writeCanonical(&buf, buildTree(&canonicalConfig, v), nil)
+					vs.strings[i] = buf.String()
+					continue
+				}
+				v = unsafeReflectValue(v)
+			}
+			vs.strings[i] = Sprintf("%#v", v.Interface())
 		}
 	}
 	return vs
@@ -312,5 +380,12 @@ func sortValues(values []reflect.Value, cs *ConfigState) {
 	if len(values) == 0 {
 		return
 	}
+	// The values are put in the default order first, so the values which
+	// KeyLess does not order keep it.
 	sort.Sort(newValuesSorter(values, cs))
+	if cs.KeyLess != nil {
+		sort.SliceStable(values, func(i, j int) bool {
+			return cs.KeyLess(values[i], values[j])
+		})
+	}
 }
@@ -19,6 +19,7 @@ package spew_test
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	spew "github.com/ehowe/rainbow-spew"
 	. "github.com/onsi/ginkgo/v2"
@@ -298,4 +299,31 @@ var _ = Describe("Common Tests", func() {
 		cs := spew.ConfigState{DisableMethods: true, SpewKeys: true}
 		helpTestSortValues(tests, &cs)
 	})
+
+	// TestSortValuesWithKeyLess ensures the sort functionality for
+	// relect.Value based sorting uses the KeyLess comparison when set.
+	It("sorts values with a custom comparison", func() {
+		v := reflect.ValueOf
+		tests := []sortTestCase{
+			// Case-insensitive strings.
+			{
+				[]reflect.Value{v("b"), v("C"), v("a")},
+				[]reflect.Value{v("a"), v("b"), v("C")},
+			},
+		}
+		cs := spew.ConfigState{KeyLess: func(a, b reflect.Value) bool {
+			return strings.ToLower(a.String()) < strings.ToLower(b.String())
+		}}
+		helpTestSortValues(tests, &cs)
+
+		m := map[string]int{"b": 2, "C": 3, "a": 1}
+		cs.SortKeys = true
+		Expect(fmt.Sprintf("%v", cs.NewFormatter(m))).To(Equal("map[a:1 b:2 C:3]"))
+
+		// Keys which compare equal keep their default order.
+		m = map[string]int{"b": 2, "a": 1, "A": 0, "B": 3}
+		for i := 0; i < 20; i++ {
+			Expect(fmt.Sprintf("%v", cs.NewFormatter(m))).To(Equal("map[A:0 a:1 B:3 b:2]"))
+		}
+	})
 })
@@ -20,7 +20,10 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"reflect"
+	"slices"
 
 	"github.com/fatih/color"
 )
@@ -34,6 +37,25 @@ type ColorConfiguration struct {
 	Bool   []color.Attribute
 	Type   []color.Attribute
 	Length []color.Attribute
+
+	// Redacted is applied to the placeholder printed in place of a value
+	// that has been hidden by redaction.  It should include a background
+	// color so that hidden values stand out from genuinely empty ones.
+	Redacted []color.Attribute
+
+	// Keyword is applied to the SQL keywords highlighted within strings when
+	// the HighlightSQL option is set.
+	Keyword []color.Attribute
+
+	// Depth lists the colors cycled through for the guides drawn by
+	// SdumpTree, one per level of nesting, so the levels of a deeply nested
+	// value are easy to tell apart.
+	Depth []color.Attribute
+
+	// Label is applied to the labels written by Dumpl and its variants.
+	// The built-in themes make it bold so labels stand out from the values
+	// they precede.
+	Label []color.Attribute
 }
 
 // ConfigState houses the configuration options used by spew to format and
@@ -54,6 +76,15 @@ type ConfigState struct {
 	// set this to a tab with "\t" or perhaps two spaces with "  ".
 	Indent string
 
+	// IndentLevels, when set, specifies the string to use for each
+	// indentation level in place of Indent, starting with the outermost
+	// level, with the last string used for all deeper levels.  This allows
+	// guides such as "│  " to be drawn.  Control characters other than tabs
+	// in indent strings are escaped, since they would corrupt the layout of
+	// the output, and tabs are assumed to advance to the next multiple of 8
+	// columns when wrapping strings and aligning tables.
+	IndentLevels []string
+
 	// MaxDepth controls the maximum number of levels to descend into nested
 	// data structures.  The default, 0, means there is no limit.
 	//
@@ -62,6 +93,52 @@ type ConfigState struct {
 	// nested data structures.
 	MaxDepth int
 
+	// MaxDepthTypes specifies the maximum number of levels to descend into
+	// values of particular types, counted from the value, such as 1 to show
+	// the fields of a *http.Request without the values nested within them.
+	// Pointer types and the types they point to are matched separately.  The
+	// limits only lower the one set by MaxDepth.
+	MaxDepthTypes map[reflect.Type]int
+
+	// ExportedOnly specifies that unexported struct fields should be
+	// omitted, which restricts the output to the fields visible to other
+	// packages, such as those encoding/json marshals.
+	ExportedOnly bool
+
+	// IncludeFields narrows the struct fields displayed to those matched by
+	// one of its regular expressions, such as .*Status.* to show only the
+	// status fields of a large object.  Each expression must match the
+	// whole of a field name or of its path of field names from the root
+	// joined by dots, as in Spec.Template.Status.  The fields of matched
+	// fields are all displayed, and other fields are kept only when their
+	// type could hold a matched field, which includes every interface.
+	// Expressions which are not valid are ignored.
+	IncludeFields []string
+
+	// ExcludeFields hides the struct fields matched by one of its regular
+	// expressions in the same way as IncludeFields, along with everything
+	// within them.  It takes precedence over IncludeFields.
+	ExcludeFields []string
+
+	// OmitZero specifies that struct fields holding the zero value of their
+	// type should be omitted, which greatly shrinks the output for sparse
+	// structs such as API objects.
+	OmitZero bool
+
+	// OmitNil specifies that struct fields holding nil pointers, interfaces,
+	// maps, slices, channels or functions should be omitted rather than
+	// displayed as <nil>.  Dump output gives the number of fields omitted
+	// from each struct.
+	OmitNil bool
+
+	// FlattenEmbedded specifies whether the fields of embedded structs, and
+	// of non-nil pointers to them, are displayed at the level of the struct
+	// embedding them as Go promotes them, which reduces the nesting of types
+	// built from many embedded mixins.  Promoted fields whose name is shared
+	// by another displayed field are qualified by the embedded field, as in
+	// Base.ID.  It applies to Dump and the Formatter.
+	FlattenEmbedded bool
+
 	// DisableMethods specifies whether or not error and Stringer interfaces are
 	// invoked for types that implement them.
 	DisableMethods bool
@@ -84,6 +161,23 @@ type ConfigState struct {
 	// pointer addresses. This is useful when diffing data structures in tests.
 	DisablePointerAddresses bool
 
+	// PointerIDs specifies whether pointer addresses are replaced by short
+	// sequential identifiers such as #1, assigned in the order pointers
+	// are first encountered by each call, as in (*main.Foo)(#1).  Unlike
+	// DisablePointerAddresses, this still shows which pointers are aliases
+	// of each other, while the output is reproducible across runs for use
+	// in golden files.  Stringer values are unaffected.
+	PointerIDs bool
+
+	// SharedRefs specifies whether Dump displays the value behind a pointer
+	// only the first time the pointer is encountered within an argument,
+	// with later occurrences replaced by a reference such as -> see #3
+	// above.  Pointers are labeled with identifiers as with PointerIDs so
+	// that the references can be followed, even when
+	// DisablePointerAddresses is set.  This keeps substructures which are
+	// shared by many values from being displayed in full each time.
+	SharedRefs bool
+
 	// DisableCapacities specifies whether to disable the printing of capacities
 	// for arrays, slices, maps and channels. This is useful when diffing
 	// data structures in tests.
@@ -99,6 +193,49 @@ type ConfigState struct {
 	// via the DisableMethods or DisablePointerMethods options.
 	ContinueOnMethod bool
 
+	// UseGoStringer specifies whether types implementing fmt.GoStringer are
+	// displayed by the result of their GoString method in the same way as
+	// error and Stringer types, which gives the authors of domain types a
+	// hook they already implement.  It takes precedence over the error and
+	// Stringer interfaces, along with TextMarshalers and JSONMarshalers.
+	//
+	// NOTE: This flag does not have any effect if method invocation is disabled
+	// via the DisableMethods option.
+	UseGoStringer bool
+
+	// TextMarshalers specifies whether types implementing
+	// encoding.TextMarshaler are displayed by their marshaled text in the
+	// same way as error and Stringer types, which suits types such as
+	// time.Time, decimal.Decimal and resource.Quantity.  It comes after
+	// UseGoStringer, and values which fail to marshal are displayed as
+	// usual.
+	//
+	// NOTE: This flag does not have any effect if method invocation is disabled
+	// via the DisableMethods option.
+	TextMarshalers bool
+
+	// JSONMarshalers specifies whether types implementing json.Marshaler
+	// are displayed by their marshaled JSON in the same way as
+	// TextMarshalers, which it comes after.
+	JSONMarshalers bool
+
+	// VerbosePanics specifies whether panics recovered from the methods
+	// called to display values, such as String and Error, are written as
+	// <String() panicked: index out of range> instead of (PANIC: index out
+	// of range), so the misbehaving method is evident.
+	VerbosePanics bool
+
+	// PanicStackFrames specifies the number of frames of the stack of a
+	// recovered panic which are written after its value when VerbosePanics
+	// is set, starting at the function which panicked, as in
+	// <String() panicked: boom at main.T.String (t.go:12)>.
+	PanicStackFrames int
+
+	// OnMethodPanic, when set, is called with each panic recovered from the
+	// methods called to display values so they can be reported, such as to
+	// an error tracker.  See MethodPanic for the details given.
+	OnMethodPanic func(p MethodPanic)
+
 	// SortKeys specifies map keys should be sorted before being printed. Use
 	// this to have a more deterministic, diffable output.  Note that only
 	// native types (bool, int, uint, floats, uintptr and string) and types
@@ -112,21 +249,511 @@ type ConfigState struct {
 	// considered if SortKeys is true.
 	SpewKeys bool
 
+	// KeyLess, when set, reports whether the map key a sorts before the map
+	// key b and replaces the default ordering of SortKeys, which allows
+	// deterministic output for maps with any key type, such as struct or
+	// version string keys.  Keys it does not order either way keep their
+	// default order.  Keys of interface types are passed as is.  This is
+	// only considered if SortKeys is true.
+	KeyLess func(a, b reflect.Value) bool
+
+	// Deterministic specifies whether output is made byte-stable across
+	// runs for snapshot tests, which sets the PointerIDs, SortKeys,
+	// SpewKeys and DisableCapacities options so that pointers are labeled
+	// by sequential identifiers, the keys of all maps are sorted, including
+	// those held in unexported fields, and capacities which depend on how
+	// values were built are not displayed.
+	Deterministic bool
+
+	// ShowDumpID specifies whether Dump writes a header with the short unique
+	// identifier assigned to each invocation, along with the trace ID when
+	// the TraceID option is set.  The identifier is also returned by
+	// FdumpStats so a dump mentioned in a bug report can be located in
+	// aggregated logs.
+	ShowDumpID bool
+
+	// TraceID, when set, is called once for each invocation of Dump to obtain
+	// the identifier of the current trace.  It is included in the dump
+	// header and in the statistics returned by FdumpStats in order to
+	// correlate dumps with traces.
+	TraceID func() string
+
+	// ShowCaller specifies whether the output of each call to the Dump and
+	// Print families of functions, such as Dump, Sdump, Printf and
+	// Fprintln, is prefixed with the file and line of the call, as in
+	// main.go:42: (int) 1, so the dump site which produced each block of
+	// output can be told apart when many are active.
+	ShowCaller bool
+
+	// CallerFuncs specifies whether the name of the calling function is
+	// written after its file and line when the ShowCaller option is set, as
+	// in main.go:42 main.run: (int) 1.
+	CallerFuncs bool
+
+	// TimestampFormat, when set, is the layout used by time.Time.Format to
+	// write the current time ahead of the output of each call to the Dump
+	// and Print families of functions, as in 15:04:05.000 (int) 1, which
+	// helps to line dumps up with timestamped logs.  The timestamp comes
+	// before the location written for the ShowCaller option.
+	TimestampFormat string
+
+	// LineNumbers specifies whether Dump prefixes each line of its output
+	// with its line number, so a particular line of a large dump can be
+	// referred to in reviews and bug reports.
+	LineNumbers bool
+
+	// ShowLineCount specifies whether Dump ends its output with the total
+	// number of lines when the LineNumbers option is set.
+	ShowLineCount bool
+
+	// ShowSummary specifies whether Dump ends its output with a summary of
+	// the number of values displayed, the deepest level of nesting reached,
+	// the number of values truncated and redacted, the number of bytes
+	// written before the summary, and the number of values of each kind.
+	// This helps to tune options such as MaxDepth and MaxElements on real
+	// data.  The same statistics are returned by FdumpStats.
+	ShowSummary bool
+
+	// ShowInterfaceTypes specifies whether Dump displays the static type
+	// of values held by non-empty interfaces ahead of their dynamic type, as
+	// in (io.Reader)(*bytes.Buffer), which helps to debug interface wiring.
+	// Values held by empty interfaces only have their dynamic type
+	// displayed, since their static type says nothing about them.
+	ShowInterfaceTypes bool
+
+	// ShortGenericNames specifies whether the names of instantiated generic
+	// types are displayed with the package paths inside their type
+	// arguments elided to their last element, so
+	// map[string]pkg.Box[github.com/very/long/path.Thing] is displayed as
+	// map[string]pkg.Box[path.Thing].  The full names are displayed by
+	// default since the last element of a path may differ from the name of
+	// its package.
+	ShortGenericNames bool
+
+	// ReflectValues specifies whether reflect.Value values nested inside
+	// the values displayed, such as the fields of structs, are displayed
+	// as the value they hold instead of as the reflect.Value struct
+	// itself.  Held values which are the zero Value are displayed as
+	// <invalid>.
+	ReflectValues bool
+
+	// Quiet specifies whether Dump suppresses its normal output and instead
+	// reports only the values which the checks in QuietChecks find
+	// interesting, one per line along with their path from the root.  This
+	// is a triage view over structures which are too large to read in full.
+	Quiet bool
+
+	// QuietChecks is the set of heuristics used to find interesting values
+	// when Quiet is set.  The default, 0, means QuietDefaultChecks.  Struct
+	// fields are considered required when their spew or validate tag
+	// includes the required option.
+	QuietChecks QuietCheck
+
+	// Compact specifies whether Dump writes each argument on a single line
+	// with the newlines between elements replaced by spaces and no
+	// indentation.  This is useful for embedding dumps in structured log
+	// messages.
+	Compact bool
+
+	// MaxWidth specifies the number of columns Dump soft-wraps long strings
+	// to, with the continuation lines indented one level deeper than the
+	// value.  MaxWidthTerminal wraps to the width of the terminal Dump writes
+	// to, or of the COLUMNS environment variable when it is not writing to a
+	// terminal.  The numbers written for the LineNumbers option count toward
+	// the width.  The default, 0, means strings are never wrapped.  It has no
+	// effect when the Compact option is set.
+	MaxWidth int
+
+	// MaxBytes specifies the maximum number of bytes written by each call of
+	// the Dump family of functions, which protects logs from accidentally huge
+	// dumps.  Output beyond the limit is discarded and replaced with a notice
+	// that it was truncated.  Output is cut at line boundaries where possible.
+	// Output is not limited when it is zero.
+	MaxBytes int
+
+	// MaxElements specifies the maximum number of elements of each array,
+	// slice and map displayed, so values holding huge collections stay
+	// readable.  The remaining elements are replaced by a marker giving their
+	// number, such as "... (+99000 more)".  The elements of maps are limited
+	// after sorting when SortKeys is set.  Collections are not limited when
+	// it is zero.
+	MaxElements int
+
+	// TailElements specifies the number of the last elements of each
+	// array, slice and map which are displayed after the marker when
+	// MaxElements limits it, so both ends of time-ordered slices can be
+	// seen.  MaxElements then gives the number of leading elements.  Byte
+	// arrays and slices displayed as hexdumps only show the leading bytes.
+	TailElements int
+
+	// CollapseRuns specifies whether consecutive identical elements of
+	// arrays and slices are displayed once followed by their count, as in
+	// (string) "x" ×1024, which keeps zero-initialized buffers and padding
+	// arrays from filling the output.  Byte slices and arrays displayed as
+	// hexdumps are not affected.
+	CollapseRuns bool
+
+	// ElementIndices specifies whether each element of arrays and slices is
+	// prefixed with its index, as in [17]: (int) 5, which makes it easier to
+	// correlate the output of Dump with code accessing specific indices.
+	// The index of the first element is given for runs collapsed by
+	// CollapseRuns.
+	ElementIndices bool
+
+	// ChannelDetails specifies whether channels are displayed with their
+	// direction, element type and buffer usage after their address, as in
+	// (dir: both elem: int len: 4 cap: 4 full), so it can be seen at a
+	// glance whether a buffered channel is full.
+	ChannelDetails bool
+
+	// FuncNames specifies whether functions are displayed by their name and
+	// the file and line of their entry point, as resolved by
+	// runtime.FuncForPC, instead of by their address.  For example:
+	// (func(int) error) github.com/acme/pkg.Handler at handlers.go:42.
+	// Functions whose name can't be resolved are displayed by address.
+	FuncNames bool
+
+	// HumanTimes specifies whether time.Time values are displayed in the
+	// RFC 3339 format and time.Duration values in hours, minutes and
+	// seconds, as in (time.Duration) 2m13s, instead of by their internal
+	// fields, which are noise.  It applies even when methods are disabled.
+	// Formatters registered with RegisterFormatter take precedence.
+	HumanTimes bool
+
+	// RelativeTimes specifies whether time.Time values displayed for the
+	// HumanTimes option are followed by how long ago or ahead of now they
+	// are, as in 2024-05-01T12:00:00Z (2m13s ago).
+	RelativeTimes bool
+
+	// WellKnownTypes specifies whether values of well-known types whose
+	// fields are noise are displayed by what they represent.  The database/sql
+	// Null types, such as sql.NullString and sql.Null[T], are displayed as
+	// the value they hold, or as NULL when it is not valid, as in
+	// (sql.NullInt64) NULL.  The address types of the net and net/netip
+	// packages, such as net.IP, net.IPNet, netip.Addr and netip.Prefix, are
+	// displayed in their canonical text form, as in (net.IP) 10.0.0.1,
+	// instead of as raw bytes or opaque structs, and the JSON documents held
+	// by json.RawMessage values are displayed indented and marked as parsed
+	// in Dump output.  The contexts of the context package are displayed in
+	// Dump output by their deadline, their error once they are done, and the
+	// keys and values they carry, nearest first.  The slog.Value and slog.Attr types are displayed
+	// in Dump output by the value they hold for their kind, with groups
+	// displayed by their attributes.  The sync.Mutex and sync.RWMutex types
+	// are displayed by their state, as in
+	// (sync.RWMutex) read-locked (readers: 2), along with the number of
+	// goroutines waiting on a locked mutex.  URLs are displayed with their
+	// password redacted, time.Location values by their name, os.File values
+	// by their name and descriptor, bytes.Buffer values by their unread
+	// contents, and http.Request values by their method, URL and headers.
+	// Types registered with RegisterWellKnownType are displayed by the text
+	// they register.
+	// Formatters registered with RegisterFormatter take precedence.
+	WellKnownTypes bool
+
+	// UUIDs specifies whether Dump displays 16 byte arrays and slices whose
+	// type or field name suggests they hold a UUID in the canonical
+	// hex-hyphen form instead of as a hexdump, as in
+	// (uuid.UUID) 6ba7b810-9dad-11d1-80b4-00c04fd430c8.  Names containing
+	// UUID or GUID qualify, and so do field names which are or end with ID
+	// when the bytes have the version and variant bits of a UUID.
+	UUIDs bool
+
+	// ReflectTypes specifies whether Dump displays reflect.Type values by
+	// the type they describe, its kind, and the import path of its package
+	// when it is a named type, as in
+	// (*reflect.rtype) main.Foo (kind: struct pkg: example.com/app), instead
+	// of by the internals of the reflect package describing it.
+	ReflectTypes bool
+
+	// Regexps specifies whether Dump displays regexp.Regexp values by their
+	// source pattern, followed by (longest) when they prefer
+	// leftmost-longest matches, as in (*regexp.Regexp) "^[a-z]+$", instead
+	// of by their compiled program.
+	Regexps bool
+
+	// ParseJSONStrings specifies whether Dump displays strings holding JSON
+	// objects or arrays as the parsed document, indented and colored,
+	// which suits JSON stored in string columns.  The document is marked
+	// as parsed, as in (string) (parsed json) {.  Strings which are not
+	// valid JSON are displayed as usual.
+	ParseJSONStrings bool
+
+	// AtomicValues specifies whether the types of the sync/atomic package,
+	// such as atomic.Int64, atomic.Bool, atomic.Pointer[T] and atomic.Value,
+	// are displayed as the value loaded from them instead of their internal
+	// representation.  Dump marks the value as loaded, as in
+	// (atomic.Int64) (loaded) 42.
+	AtomicValues bool
+
+	// DrainIterators specifies whether Dump displays iterator functions,
+	// such as iter.Seq[V] and iter.Seq2[K, V], as the values they yield,
+	// which are drained up to MaxElements, or 100 when it is zero.  The
+	// values are marked as consumed, as in (iter.Seq[int]) (consumed) {,
+	// since iterators can't be rewound, and the pairs yielded by
+	// iter.Seq2 are displayed like the entries of maps.
+	DrainIterators bool
+
+	// UnwrapErrors specifies whether Dump displays errors wrapping other
+	// errors, through an Unwrap() error or Unwrap() []error method, as a
+	// tree of the errors they wrap.  Errors such as those created by
+	// fmt.Errorf with %w are displayed with their message followed by the
+	// error they wrap, and multi-errors such as those created by
+	// errors.Join by the errors they hold, instead of as an opaque struct
+	// with a slice of interfaces.
+	UnwrapErrors bool
+
+	// PadPointers specifies whether uintptr and unsafe.Pointer values are
+	// padded with zeros to the width of a pointer on the platform, as in
+	// 0x000000c000012345, so the addresses of a dump line up.
+	PadPointers bool
+
+	// PointerSymbols specifies whether uintptr and unsafe.Pointer values
+	// holding an address within the code of a function are followed by its
+	// name and the offset into it as resolved by runtime.FuncForPC, as in
+	// 0x4a1b20 <main.handler+0x20>.
+	PointerSymbols bool
+
+	// MaxStringLength specifies the maximum number of bytes of string values
+	// displayed.  Longer strings are cut at the limit, without splitting a
+	// UTF-8 sequence, and followed by a marker giving the number of bytes
+	// removed, such as "... (+512 bytes)".  The displayed length is that of
+	// the whole string.  Strings are not truncated when it is zero.
+	MaxStringLength int
+
+	// PeekReaders specifies the maximum number of the upcoming bytes of
+	// readers which Dump displays instead of their fields, as in
+	// (*strings.Reader) (unread: 11) "hello"... (+6 bytes).  It applies to
+	// *bytes.Buffer, *bytes.Reader and *strings.Reader values, whose
+	// contents are read without moving their position, and to the bytes
+	// buffered by *bufio.Reader values, such as those returned by
+	// PeekReader.  Readers are displayed as usual when it is zero.
+	PeekReaders int
+
+	// HighlightSQL specifies whether SQL keywords within strings are
+	// highlighted with the Keyword color.  Strings are treated as SQL when
+	// the name of the struct field holding them mentions a query or SQL, or
+	// when they read like a statement such as SELECT ... FROM.
+	HighlightSQL bool
+
+	// ShowTags lists the keys of the struct tags displayed after the names
+	// of struct fields in Dump output, such as json and db, which helps when
+	// debugging mismatches between field names and the names used by
+	// encoders.  Tags are displayed as a raw string in the order of the keys,
+	// such as `json:"user_id" db:"uid"`.
+	ShowTags []string
+
+	// FieldCase specifies the casing struct field names are displayed in by
+	// Dump and SdumpTree.  Displaying names in the casing of a wire format,
+	// such as the snake_case of many JSON APIs, makes dumps easier to compare
+	// against payloads.  The Go name of each field whose displayed name
+	// differs follows it in a comment.  The default, FieldCaseGo, displays
+	// names as declared.
+	FieldCase FieldCase
+
+	// IntBase specifies the base integers are displayed in, which makes
+	// bitfields and flags far easier to read in hex or binary.  The default,
+	// IntBaseDecimal, displays integers in base 10.  The base can be
+	// overridden for specific types with IntBaseTypes, and for struct fields
+	// in Dump output with the hex, bin, oct or dec options of their spew tag,
+	// as in `spew:"hex"`.
+	IntBase IntBase
+
+	// IntBaseTypes maps integer types to the base they are displayed in,
+	// overriding the IntBase option.
+	IntBaseTypes map[reflect.Type]IntBase
+
+	// IntBasePrefix specifies whether integers displayed in hex, binary or
+	// octal are prefixed with 0x, 0b or 0o respectively.
+	IntBasePrefix bool
+
+	// ThousandsSeparator, when set, is inserted between each group of three
+	// digits of integers displayed in decimal, such as "_" for 1_234_567 or
+	// "," for 1,234,567.  This makes large counters and byte sizes easier to
+	// scan.  Lengths and capacities are not affected.
+	ThousandsSeparator string
+
+	// FloatFormat specifies the notation floats are displayed in.  The
+	// default, FloatShortest, uses the shorter of decimal and scientific
+	// notation.
+	FloatFormat FloatFormat
+
+	// FloatPrecision specifies the number of digits floats are displayed
+	// with.  It is the number of digits after the decimal point for
+	// FloatFixed and FloatScientific, and the number of significant digits
+	// for FloatShortest.  The default, 0, means the smallest number of
+	// digits necessary to represent the value exactly, so the displayed
+	// value round-trips to the same float.
+	FloatPrecision int
+
+	// BytesMode specifies how Dump displays byte arrays and slices.  The
+	// default, BytesHexdump, displays them in hexdump -C fashion, while the
+	// other modes display them as base64, as a string when they hold
+	// printable text, as a Go byte slice literal, or as just their length.
+	// Empty byte slices are always displayed as empty slices.
+	BytesMode BytesMode
+
+	// BytesAsText specifies whether byte arrays and slices which would be
+	// displayed as a hexdump are instead displayed as a quoted string,
+	// annotated with ([]byte as string), when they are valid UTF-8 made up
+	// mostly of printable characters.  This suits payloads such as JSON
+	// bodies.  Characters which are not printable are escaped.
+	BytesAsText bool
+
+	// Hexdump configures the layout of the hexdumps byte arrays and slices
+	// are displayed as, such as the number of bytes per line and whether to
+	// display offsets and an ASCII gutter.  The zero value is the layout of
+	// hexdump -C.
+	Hexdump HexdumpLayout
+
+	// FmtFallbackTypes lists types which are rendered exactly as fmt's %+v
+	// verb renders them instead of being inspected through reflection.  This
+	// is useful for types whose fmt output was carefully crafted by their
+	// authors and is more readable than their internals.  The types are
+	// matched exactly, so pointers to the listed types are still dereferenced
+	// as usual.
+	FmtFallbackTypes []reflect.Type
+
+	// SkipTypes lists types whose values are displayed as <skipped> along
+	// with their type instead of being expanded, which keeps dumps focused
+	// and avoids reading the internals of types such as *sql.DB or those
+	// holding mutexes.  Pointer types and the types they point to are
+	// matched separately, and interface types such as context.Context skip
+	// every type which implements them.
+	SkipTypes []reflect.Type
+
+	// Redact specifies whether values which could hold credentials are
+	// replaced by a [REDACTED] placeholder, which gives the length of
+	// strings, slices, arrays and maps, so output can be logged safely.
+	// This applies to the struct fields and map entries with string keys
+	// whose names match RedactFields, and to the values of RedactTypes, in
+	// the output of every function except the Go source written by SdumpGo
+	// and ToGoFixture, which can't hold placeholders.  Canonical applies
+	// the options of the global configuration.
+	Redact bool
+
+	// RedactFields lists the regular expressions which struct field names
+	// and string map keys are matched against, ignoring case, when Redact
+	// is set.  A name matches when any part of it matches.  The default,
+	// nil, means DefaultRedactFields.  Expressions which are not valid are
+	// ignored.
+	RedactFields []string
+
+	// RedactTypes lists types whose values are redacted when Redact is
+	// set.  Interface types redact every type which implements them.
+	RedactTypes []reflect.Type
+
+	// RedactFunc is called with each value and its path of struct field
+	// names from the root joined by dots, as in Users.Email, to let
+	// applications implement their own masking, such as showing only the
+	// last digits of card numbers.  The values of map entries with string
+	// keys have the key appended to the path.  When it returns true, the
+	// returned text is displayed in place of the value in the output of
+	// every function except SdumpGo.  It is called regardless of the Redact
+	// option, including for pointers and the values they point to, and the
+	// values may be unexported fields which can't be converted to an
+	// interface.
+	RedactFunc func(path string, v reflect.Value) (string, bool)
+
+	// ShowSizes specifies whether Dump annotates pointers and composite
+	// values with their estimated shallow and retained sizes in bytes.  The
+	// retained size of a value includes the objects reachable from it, such
+	// as the targets of pointers, with objects which are reachable from
+	// several values attributed to the first one displayed.  This shows which
+	// field is actually responsible for the memory footprint of a structure.
+	// See SizeOf for details.
+	ShowSizes bool
+
+	// ShowMethods specifies whether Dump follows each argument with a line
+	// listing the method set of its type, and a line listing the methods
+	// only a pointer to it has when there are any, which helps to debug
+	// why a value does not satisfy an interface.  See Methods for details.
+	ShowMethods bool
+
+	// ShowLayout specifies whether Dump annotates each struct field with a
+	// comment giving its offset and size in bytes, and the padding following
+	// it when there is any, as in // off=8 size=8 pad=4, which turns Dump
+	// into a quick struct layout inspection tool.  Offsets are relative to
+	// the struct directly holding the field, including for the fields
+	// promoted by FlattenEmbedded.  It has no effect when the Compact option
+	// is set.
+	ShowLayout bool
+
+	// MapTables specifies that maps whose keys and values are all scalars or
+	// strings are displayed as a Markdown table with aligned columns of keys
+	// and values, which is far easier to read for configuration maps.  Pipes
+	// in the cells are escaped and line breaks written as <br>.  It has no
+	// effect when the Compact option is set.  Only Dump style output is
+	// affected.
+	MapTables bool
+
+	// GroupByType specifies that the elements of slices, arrays, and maps
+	// whose elements are interfaces should be clustered by the concrete type
+	// they hold, with a header giving the type and the number of elements of
+	// that type.  This makes heterogeneous payloads such as parsed JSON
+	// easier to survey.  Only Dump style output is affected.
+	GroupByType bool
+
+	// DimPunctuation specifies that structural punctuation such as parens,
+	// braces, brackets, commas, and colons should be rendered in a faint
+	// style so the data itself stands out.
+	DimPunctuation bool
+
+	// Glyphs specifies whether sections of Dump output are prefixed with
+	// glyphs such as a table icon before maps and an arrow before pointers.
+	// GlyphsNerdFont requires a font patched with the Nerd Fonts glyphs while
+	// GlyphsASCII uses pure ASCII equivalents.  Glyphs are disabled by
+	// default.
+	Glyphs GlyphMode
+
+	// Symbols specifies whether sentinel values in Dump output are written
+	// as symbols rather than words, such as ∅ for nil and ✓ and ✗ for true
+	// and false, which makes them quicker to spot when scanning.
+	// SymbolsASCII uses pure ASCII equivalents.  The output of the
+	// formatter, as used by Printf and friends, is not affected.  Symbols
+	// are disabled by default.
+	Symbols SymbolMode
+
+	// Renderer, when set, replaces the text output of Dump, Fdump and Sdump
+	// for each argument with the output of rendering the tree returned by
+	// Parse.  The dump header written for the ShowDumpID option and the
+	// output of the Quiet option are not affected.  See TextRenderer,
+	// TreeRenderer and SexpRenderer for the built-in renderers.
+	Renderer Renderer
+
 	// Color is a ColorConfiguration object that defines the ANSI colors to output.
 	Color ColorConfiguration
+
+	// DisableDumpColor specifies whether to disable colors, including dimmed
+	// punctuation, in Dump style output while leaving Formatter output
+	// unaffected.
+	DisableDumpColor bool
+
+	// DisableFormatterColor specifies whether to disable colors, including
+	// dimmed punctuation, in Formatter output such as that of Printf and
+	// Sprint while leaving Dump style output unaffected.  This is useful
+	// since Formatter output is frequently interpolated into log lines where
+	// escape sequences are unwanted.
+	DisableFormatterColor bool
+
+	// AlsoWrite, when set, receives a copy of the output of each call to
+	// the Dump and Print families of functions with its colors removed,
+	// such as a log file or a RingBuffer collecting dumps for post-mortem
+	// inspection, while the output to the terminal stays colored.  It is
+	// flushed after each dump when it is a Sink.
+	AlsoWrite io.Writer
+
+	// formatters houses the formatters registered with RegisterFormatter.
+	formatters map[reflect.Type]func(w io.Writer, v interface{})
 }
 
-// Config is the active configuration of the top-level functions.
-// The configuration can be changed by modifying the contents of spew.Config.
+// Config is the active configuration of the top-level functions until
+// SetConfig is called.  The configuration can be changed by modifying the
+// contents of spew.Config before values are dumped, but modifying it while
+// other goroutines are dumping values is a data race.  Use SetConfig or
+// UpdateConfig to change the configuration at any time.
 var Config = ConfigState{
 	Indent: "  ",
-	Color: ColorConfiguration{
-		String: []color.Attribute{color.FgRed},
-		Number: []color.Attribute{color.FgMagenta},
-		Bool:   []color.Attribute{color.FgYellow},
-		Type:   []color.Attribute{color.FgGreen, color.Underline},
-		Length: []color.Attribute{color.FgCyan},
-	},
+	Color:  cloneColors(themes["default"]),
 }
 
 // Errorf is a wrapper for fmt.Errorf that treats each argument as if it were
@@ -150,7 +777,7 @@ func (c *ConfigState) Errorf(format string, a ...interface{}) (err error) {
 //
 //	fmt.Fprint(w, c.NewFormatter(a), c.NewFormatter(b))
 func (c *ConfigState) Fprint(w io.Writer, a ...interface{}) (n int, err error) {
-	return fmt.Fprint(w, c.convertArgs(a)...)
+	return fmt.Fprint(outputWriter(c, w), c.convertArgs(a)...)
 }
 
 // Fprintf is a wrapper for fmt.Fprintf that treats each argument as if it were
@@ -162,7 +789,7 @@ func (c *ConfigState) Fprint(w io.Writer, a ...interface{}) (n int, err error) {
 //
 //	fmt.Fprintf(w, format, c.NewFormatter(a), c.NewFormatter(b))
 func (c *ConfigState) Fprintf(w io.Writer, format string, a ...interface{}) (n int, err error) {
-	return fmt.Fprintf(w, format, c.convertArgs(a)...)
+	return fmt.Fprintf(outputWriter(c, w), format, c.convertArgs(a)...)
 }
 
 // Fprintln is a wrapper for fmt.Fprintln that treats each argument as if it
@@ -173,7 +800,7 @@ func (c *ConfigState) Fprintf(w io.Writer, format string, a ...interface{}) (n i
 //
 //	fmt.Fprintln(w, c.NewFormatter(a), c.NewFormatter(b))
 func (c *ConfigState) Fprintln(w io.Writer, a ...interface{}) (n int, err error) {
-	return fmt.Fprintln(w, c.convertArgs(a)...)
+	return fmt.Fprintln(outputWriter(c, w), c.convertArgs(a)...)
 }
 
 // Print is a wrapper for fmt.Print that treats each argument as if it were
@@ -185,7 +812,7 @@ func (c *ConfigState) Fprintln(w io.Writer, a ...interface{}) (n int, err error)
 //
 //	fmt.Print(c.NewFormatter(a), c.NewFormatter(b))
 func (c *ConfigState) Print(a ...interface{}) (n int, err error) {
-	return fmt.Print(c.convertArgs(a)...)
+	return fmt.Fprint(outputWriter(c, os.Stdout), c.convertArgs(a)...)
 }
 
 // Printf is a wrapper for fmt.Printf that treats each argument as if it were
@@ -197,7 +824,7 @@ func (c *ConfigState) Print(a ...interface{}) (n int, err error) {
 //
 //	fmt.Printf(format, c.NewFormatter(a), c.NewFormatter(b))
 func (c *ConfigState) Printf(format string, a ...interface{}) (n int, err error) {
-	return fmt.Printf(format, c.convertArgs(a)...)
+	return fmt.Fprintf(outputWriter(c, os.Stdout), format, c.convertArgs(a)...)
 }
 
 // Println is a wrapper for fmt.Println that treats each argument as if it were
@@ -209,7 +836,7 @@ func (c *ConfigState) Printf(format string, a ...interface{}) (n int, err error)
 //
 //	fmt.Println(c.NewFormatter(a), c.NewFormatter(b))
 func (c *ConfigState) Println(a ...interface{}) (n int, err error) {
-	return fmt.Println(c.convertArgs(a)...)
+	return fmt.Fprintln(outputWriter(c, os.Stdout), c.convertArgs(a)...)
 }
 
 // Sprint is a wrapper for fmt.Sprint that treats each argument as if it were
@@ -318,6 +945,48 @@ func (c *ConfigState) convertArgs(args []interface{}) (formatters []interface{})
 	return formatters
 }
 
+// Clone returns a copy of c which can be modified without affecting c.  The
+// maps and slices held by c, such as MaxDepthTypes and the attributes of Color,
+// are copied as well, while functions, the Renderer and AlsoWrite are shared.
+func (c *ConfigState) Clone() *ConfigState {
+	cc := *c
+	cc.Color = cloneColors(c.Color)
+	cc.MaxDepthTypes = maps.Clone(c.MaxDepthTypes)
+	cc.IntBaseTypes = maps.Clone(c.IntBaseTypes)
+	cc.IndentLevels = slices.Clone(c.IndentLevels)
+	cc.IncludeFields = slices.Clone(c.IncludeFields)
+	cc.ExcludeFields = slices.Clone(c.ExcludeFields)
+	cc.ShowTags = slices.Clone(c.ShowTags)
+	cc.FmtFallbackTypes = slices.Clone(c.FmtFallbackTypes)
+	cc.SkipTypes = slices.Clone(c.SkipTypes)
+	cc.RedactFields = slices.Clone(c.RedactFields)
+	cc.RedactTypes = slices.Clone(c.RedactTypes)
+	cc.formatters = maps.Clone(c.formatters)
+	return &cc
+}
+
+// plain returns a copy of c which does not output any ANSI color sequences.
+func (c *ConfigState) plain() *ConfigState {
+	pc := *c
+	pc.Color = ColorConfiguration{}
+	pc.DimPunctuation = false
+	return &pc
+}
+
+// resolve returns c with the options implied by the Deterministic option
+// set when it is set, and c itself otherwise.
+func (c *ConfigState) resolve() *ConfigState {
+	if !c.Deterministic {
+		return c
+	}
+	dc := *c
+	dc.PointerIDs = true
+	dc.SortKeys = true
+	dc.SpewKeys = true
+	dc.DisableCapacities = true
+	return &dc
+}
+
 // NewDefaultConfig returns a ConfigState with the following default settings.
 //
 //	Indent: "  "
@@ -329,13 +998,7 @@ func (c *ConfigState) convertArgs(args []interface{}) (formatters []interface{})
 func NewDefaultConfig() *ConfigState {
 	return &ConfigState{
 		Indent: "  ",
-		Color: ColorConfiguration{
-			String: []color.Attribute{color.FgRed},
-			Number: []color.Attribute{color.FgMagenta},
-			Bool:   []color.Attribute{color.FgYellow},
-			Type:   []color.Attribute{color.FgGreen, color.Underline},
-			Length: []color.Attribute{color.FgCyan},
-		},
+		Color:  cloneColors(themes["default"]),
 	}
 }
 
@@ -344,11 +1007,15 @@ func NewTestConfig() *ConfigState {
 	return &ConfigState{
 		Indent: "  ",
 		Color: ColorConfiguration{
-			String: []color.Attribute{},
-			Number: []color.Attribute{},
-			Bool:   []color.Attribute{},
-			Type:   []color.Attribute{},
-			Length: []color.Attribute{},
+			String:   []color.Attribute{},
+			Number:   []color.Attribute{},
+			Bool:     []color.Attribute{},
+			Type:     []color.Attribute{},
+			Length:   []color.Attribute{},
+			Redacted: []color.Attribute{},
+			Keyword:  []color.Attribute{},
+			Depth:    []color.Attribute{},
+			Label:    []color.Attribute{},
 		},
 	}
 }
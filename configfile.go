@@ -0,0 +1,150 @@
+package spew
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// keyValue is a single setting read from a configuration file.
+type keyValue struct {
+	key, val string
+}
+
+// parseJSONConfig returns the settings of the JSON object in data.
+func parseJSONConfig(data []byte) ([]keyValue, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if t, err := dec.Token(); err != nil {
+		return nil, err
+	} else if t != json.Delim('{') {
+		return nil, errors.New("expected a JSON object")
+	}
+	var kvs []keyValue
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := t.(string)
+		var val interface{}
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case string:
+			kvs = append(kvs, keyValue{key, v})
+		case json.Number:
+			kvs = append(kvs, keyValue{key, v.String()})
+		case bool:
+			kvs = append(kvs, keyValue{key, strconv.FormatBool(v)})
+		default:
+			return nil, fmt.Errorf("%s: expected a string, number or bool", key)
+		}
+	}
+	return kvs, nil
+}
+
+// parseTOMLConfig returns the settings of the TOML document in data, which
+// may only hold key = value pairs, comments and blank lines.
+func parseTOMLConfig(data []byte) ([]keyValue, error) {
+	var kvs []keyValue
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			if line[0] == '[' {
+				return nil, fmt.Errorf("line %d: tables are not supported", i+1)
+			}
+			return nil, fmt.Errorf("line %d: expected key = value", i+1)
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+
+		var rest string
+		switch {
+		case strings.HasPrefix(val, `"`):
+			quoted, err := strconv.QuotedPrefix(val)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid string", i+1)
+			}
+			rest = val[len(quoted):]
+			val, _ = strconv.Unquote(quoted)
+		case strings.HasPrefix(val, "'"):
+			end := strings.IndexByte(val[1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", i+1)
+			}
+			val, rest = val[1:end+1], val[end+2:]
+		default:
+			val, _, _ = strings.Cut(val, "#")
+			val = strings.TrimSpace(val)
+		}
+		if rest = strings.TrimSpace(rest); rest != "" && rest[0] != '#' {
+			return nil, fmt.Errorf("line %d: unexpected text after value", i+1)
+		}
+		kvs = append(kvs, keyValue{key, val})
+	}
+	return kvs, nil
+}
+
+/*
+LoadConfig returns a ConfigState with the default settings of NewDefaultConfig
+modified by the settings in the configuration file at path, so teams can share
+dump settings across services.  Files with a .toml extension are read as TOML
+documents holding key = value pairs, and other files as JSON objects.  For
+example:
+
+	# spew.toml
+	indent = "\t"
+	max_depth = 3
+	theme = "dracula"
+	sort_keys = true
+
+The settings are named as the environment variables read by LoadEnv without
+their SPEW_ prefix and in lowercase, such as max_depth for SPEW_MAX_DEPTH.
+Unknown settings and invalid values are reported as errors.
+*/
+func LoadConfig(path string) (*ConfigState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("spew: %w", err)
+	}
+	var kvs []keyValue
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		kvs, err = parseTOMLConfig(data)
+	} else {
+		kvs, err = parseJSONConfig(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("spew: %s: %w", path, err)
+	}
+
+	c := NewDefaultConfig()
+	for _, kv := range kvs {
+		if err := c.applySetting(kv.key, kv.val); err != nil {
+			return nil, fmt.Errorf("spew: %s: %w", path, err)
+		}
+	}
+	return c, nil
+}
+
+// applySetting sets the option of c with the setting name to val.
+func (c *ConfigState) applySetting(name, val string) error {
+	for _, s := range settings {
+		if s.name == name {
+			if err := s.set(c, val); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, val, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown setting %q", name)
+}
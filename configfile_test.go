@@ -0,0 +1,56 @@
+package spew_test
+
+import (
+	"os"
+	"path/filepath"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// writeConfig writes data to a file named name in a temporary directory and
+// returns its path.
+func writeConfig(name, data string) string {
+	path := filepath.Join(GinkgoT().TempDir(), name)
+	Expect(os.WriteFile(path, []byte(data), 0o600)).To(Succeed())
+	return path
+}
+
+var _ = Describe("Config File Tests", func() {
+	It("loads JSON configuration files", func() {
+		cs, err := spew.LoadConfig(writeConfig("spew.json",
+			`{"indent": "\t", "max_depth": 3, "theme": "none", "sort_keys": true}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cs.Indent).To(Equal("\t"))
+		Expect(cs.MaxDepth).To(Equal(3))
+		Expect(cs.Color).To(Equal(spew.ColorConfiguration{}))
+		Expect(cs.SortKeys).To(BeTrue())
+	})
+
+	It("loads TOML configuration files", func() {
+		cs, err := spew.LoadConfig(writeConfig("spew.toml", `# shared settings
+indent = "\t"
+max_depth = 2 # levels
+theme = 'none'
+
+disable_methods = true
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cs.Indent).To(Equal("\t"))
+		Expect(cs.MaxDepth).To(Equal(2))
+		Expect(cs.Color).To(Equal(spew.ColorConfiguration{}))
+		Expect(cs.DisableMethods).To(BeTrue())
+	})
+
+	It("reports invalid configuration files", func() {
+		_, err := spew.LoadConfig(writeConfig("spew.json", `{"depth": 3}`))
+		Expect(err).To(MatchError(ContainSubstring(`unknown setting "depth"`)))
+		_, err = spew.LoadConfig(writeConfig("spew.json", `{"max_depth": "deep"}`))
+		Expect(err).To(MatchError(ContainSubstring(`invalid max_depth "deep"`)))
+		_, err = spew.LoadConfig(writeConfig("spew.toml", "[spew]\nindent = \" \"\n"))
+		Expect(err).To(MatchError(ContainSubstring("line 1: tables are not supported")))
+		_, err = spew.LoadConfig(filepath.Join(GinkgoT().TempDir(), "missing.json"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,140 @@
+package spew
+
+import (
+	"context"
+	"reflect"
+)
+
+// contextValue returns the context held by v when v is one of the contexts of
+// the context package, and false otherwise.
+func contextValue(v reflect.Value) (context.Context, bool) {
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		t = t.Elem()
+	}
+	if t.PkgPath() != "context" {
+		return nil, false
+	}
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return nil, false
+		}
+		v = unsafeReflectValue(v)
+	}
+	ctx, ok := v.Interface().(context.Context)
+	return ctx, ok
+}
+
+// contextValues returns the keys and values of the chain of contexts created
+// by context.WithValue leading to the context v, nearest first.  It relies on
+// the names of the fields of the contexts of the context package, so
+// contexts created by other packages end the chain.
+func contextValues(v reflect.Value) (keys, vals []reflect.Value) {
+	for {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return keys, vals
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct || v.Type().PkgPath() != "context" {
+			return keys, vals
+		}
+		if key, val := v.FieldByName("key"), v.FieldByName("val"); key.IsValid() && val.IsValid() {
+			keys = append(keys, key)
+			vals = append(vals, val)
+		}
+		parent := v.FieldByName("Context")
+		if !parent.IsValid() {
+			// Contexts created by context.WithoutCancel hold their
+			// parent in c.
+			parent = v.FieldByName("c")
+		}
+		if !parent.IsValid() {
+			return keys, vals
+		}
+		v = parent
+	}
+}
+
+// dumpContext writes the context v holding ctx by its deadline, its error
+// once it is done, and the keys and values it carries, instead of by the
+// internals of its implementation.
+func (d *dumpState) dumpContext(v reflect.Value, staticType reflect.Type, ctx context.Context) {
+	d.indent()
+	d.writeStaticType(staticType)
+	writeGlyph(d.w, d.cs, v.Kind())
+	withParens(d, func(d *dumpState) {
+		printType(d.w, d.cs, v.Type().String())
+	})
+	d.w.Write(spaceBytes)
+	d.punct(openBraceNewlineBytes)
+	d.depth++
+	if d.depth > d.maxDepth {
+		d.indent()
+		d.line(maxSymbol(d.cs))
+		d.countTruncation()
+	} else {
+		var fields []func()
+		if deadline, ok := ctx.Deadline(); ok {
+			fields = append(fields, func() {
+				d.contextField("deadline")
+				d.dump(reflect.ValueOf(deadline))
+			})
+		}
+		if err := ctx.Err(); err != nil {
+			fields = append(fields, func() {
+				d.contextField("err")
+				d.dump(reflect.ValueOf(err))
+			})
+		}
+		if keys, vals := contextValues(v); len(keys) > 0 {
+			fields = append(fields, func() {
+				d.contextField("values")
+				d.dumpContextValues(keys, vals)
+			})
+		}
+		for i, field := range fields {
+			field()
+			if i < len(fields)-1 {
+				d.punct(commaBytes)
+			}
+			d.line(newlineBytes)
+		}
+	}
+	d.depth--
+	d.indent()
+	d.punct(closeBraceBytes)
+}
+
+// contextField writes the name of a field of a context, ahead of its value.
+func (d *dumpState) contextField(name string) {
+	d.indent()
+	writeFieldName(d.w, d.cs, name)
+	d.punct(colonSpaceBytes)
+	d.ignoreNextIndent = true
+}
+
+// dumpContextValues writes the passed keys and values carried by a context
+// like the entries of a map.
+func (d *dumpState) dumpContextValues(keys, vals []reflect.Value) {
+	d.ignoreNextIndent = false
+	d.punct(openBraceNewlineBytes)
+	d.depth++
+	for i, key := range keys {
+		d.dump(d.unpackValue(key))
+		d.punct(colonSpaceBytes)
+		d.ignoreNextIndent = true
+		d.dump(d.unpackValue(vals[i]))
+		if i < len(keys)-1 {
+			d.punct(commaBytes)
+		}
+		d.line(newlineBytes)
+	}
+	d.depth--
+	d.indent()
+	d.punct(closeBraceBytes)
+}
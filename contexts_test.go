@@ -0,0 +1,55 @@
+package spew_test
+
+import (
+	"context"
+	"time"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type contextsKey int
+
+var _ = Describe("Context Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.WellKnownTypes = true
+		cs.HumanTimes = true
+		cs.DisablePointerAddresses = true
+		return cs
+	}
+
+	It("displays the values carried by a context", func() {
+		cs := newConfig()
+		ctx := context.WithValue(context.Background(), contextsKey(1), "bob")
+		ctx, cancel := context.WithCancel(ctx)
+		ctx = context.WithValue(ctx, "id", 2)
+		cancel()
+		Expect(cs.Sdump(ctx)).To(Equal("(*context.valueCtx) {\n" +
+			"  err: (*errors.errorString)(context canceled),\n" +
+			"  values: {\n" +
+			"    (string) (len: 2) \"id\": (int) 2,\n" +
+			"    (spew_test.contextsKey) 1: (string) (len: 3) \"bob\"\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("displays the deadline of a context", func() {
+		cs := newConfig()
+		deadline := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+		Expect(cs.Sdump(ctx)).To(Equal("(*context.timerCtx) {\n" +
+			"  deadline: (time.Time) 2100-01-01T00:00:00Z\n" +
+			"}\n"))
+	})
+
+	It("displays empty contexts", func() {
+		cs := newConfig()
+		Expect(cs.Sdump(struct{ Ctx context.Context }{context.Background()})).To(Equal("(struct { Ctx context.Context }) {\n" +
+			"  Ctx: (context.backgroundCtx) {\n" +
+			"  }\n" +
+			"}\n"))
+	})
+})
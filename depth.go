@@ -0,0 +1,26 @@
+package spew
+
+import (
+	"math"
+	"reflect"
+)
+
+// rootMaxDepth returns the depth beyond which the elements of containers are
+// not displayed according to the MaxDepth option alone.
+func (c *ConfigState) rootMaxDepth() int {
+	if c.MaxDepth == 0 {
+		return math.MaxInt
+	}
+	return c.MaxDepth
+}
+
+// typeMaxDepth returns the depth beyond which the elements of containers are
+// not displayed within a value of type typ visited at depth, given the limit
+// of the enclosing values.  The MaxDepthTypes option can only lower the
+// limit.
+func (c *ConfigState) typeMaxDepth(limit int, typ reflect.Type, depth int) int {
+	if n, ok := c.MaxDepthTypes[typ]; ok && depth+n < limit {
+		return depth + n
+	}
+	return limit
+}
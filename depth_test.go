@@ -0,0 +1,64 @@
+package spew_test
+
+import (
+	"fmt"
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type depthLeaf struct {
+	N int
+}
+
+type depthClient struct {
+	Leaf depthLeaf
+}
+
+type depthTypesTester struct {
+	Client *depthClient
+	Other  depthClient
+}
+
+var _ = Describe("MaxDepthTypes Tests", func() {
+	v := depthTypesTester{Client: &depthClient{Leaf: depthLeaf{1}}, Other: depthClient{Leaf: depthLeaf{2}}}
+
+	It("limits the depth within values of registered types in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.DisablePointerAddresses = true
+		cs.MaxDepthTypes = map[reflect.Type]int{reflect.TypeOf(&depthClient{}): 1}
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.depthTypesTester) {\n" +
+			"  Client: (*spew_test.depthClient)({\n" +
+			"    Leaf: (spew_test.depthLeaf) {\n" +
+			"      <max depth reached>\n" +
+			"    }\n" +
+			"  }),\n" +
+			"  Other: (spew_test.depthClient) {\n" +
+			"    Leaf: (spew_test.depthLeaf) {\n" +
+			"      N: (int) 2\n" +
+			"    }\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("only lowers the MaxDepth limit", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		cs.MaxDepthTypes = map[reflect.Type]int{reflect.TypeOf(depthClient{}): 5}
+		Expect(cs.Sdump(v.Other)).To(Equal("(spew_test.depthClient) {\n" +
+			"  Leaf: (spew_test.depthLeaf) {\n" +
+			"    <max depth reached>\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("limits the depth in Formatter output and parsed trees", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepthTypes = map[reflect.Type]int{reflect.TypeOf(depthClient{}): 0}
+		Expect(fmt.Sprintf("%v", cs.NewFormatter(v.Other))).To(Equal("{<max>}"))
+		Expect(cs.Parse(v).Children[1].Truncated).To(BeTrue())
+		Expect(cs.Parse(v).Children[0].Children[0].Truncated).To(BeTrue())
+	})
+})
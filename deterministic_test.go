@@ -0,0 +1,48 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type deterministicKey struct {
+	name string
+}
+
+type deterministicState struct {
+	items []int
+	index map[deterministicKey]*int
+}
+
+var _ = Describe("Deterministic Tests", func() {
+	It("produces byte-stable output", func() {
+		n := 1
+		v := deterministicState{
+			items: make([]int, 1, 8),
+			index: map[deterministicKey]*int{{"c"}: &n, {"a"}: &n, {"b"}: nil},
+		}
+		cs := spew.NewTestConfig()
+		cs.Deterministic = true
+		expected := "(spew_test.deterministicState) {\n" +
+			"  items: ([]int) (len: 1) {\n" +
+			"    (int) 0\n" +
+			"  },\n" +
+			"  index: (map[spew_test.deterministicKey]*int) (len: 3) {\n" +
+			"    (spew_test.deterministicKey) {\n" +
+			"      name: (string) (len: 1) \"a\"\n" +
+			"    }: (*int)(#1)(1),\n" +
+			"    (spew_test.deterministicKey) {\n" +
+			"      name: (string) (len: 1) \"b\"\n" +
+			"    }: (*int)(<nil>),\n" +
+			"    (spew_test.deterministicKey) {\n" +
+			"      name: (string) (len: 1) \"c\"\n" +
+			"    }: (*int)(#1)(1)\n" +
+			"  }\n" +
+			"}\n"
+		for i := 0; i < 5; i++ {
+			Expect(cs.Sdump(v)).To(Equal(expected))
+		}
+		Expect(cs.DisableCapacities).To(BeFalse())
+	})
+})
@@ -69,22 +69,66 @@ printing style, use the convenience wrappers Printf, Fprintf, etc with
 
 Configuration of spew is handled by fields in the ConfigState type.  For
 convenience, all of the top-level functions use a global state available
-via the spew.Config global.
+via the spew.Config global.  Since modifying spew.Config while other
+goroutines are dumping values is a data race, SetConfig and UpdateConfig
+atomically replace the global configuration instead, and CurrentConfig
+returns a copy of it.
 
 It is also possible to create a ConfigState instance that provides methods
 equivalent to the top-level functions.  This allows concurrent configuration
 options.  See the ConfigState documentation for more details.
 
+NewConfig creates a ConfigState from the default settings and a list of
+options, such as WithMaxDepth(3) or WithTheme("dracula"), which keeps working
+as new fields are added.  See LookupTheme for the available color themes.
+
+The global spew.Config is also adjusted at startup from SPEW_ environment
+variables such as SPEW_MAX_DEPTH and SPEW_THEME.  See ConfigState.LoadEnv for
+the full list.  LoadConfig reads the same settings from a JSON or TOML file.
+
 The following configuration options are available:
 
   - Indent
     String to use for each indentation level for Dump functions.
     It is a single space by default.  A popular alternative is "\t".
 
+  - IndentLevels
+    Strings to use for each indentation level in place of Indent, with the
+    last one repeated for deeper levels, such as guides like "│  ".
+
   - MaxDepth
     Maximum number of levels to descend into nested data structures.
     There is no limit by default.
 
+  - MaxDepthTypes
+    Maximum number of levels to descend into values of particular types,
+    such as one level for *http.Request, while MaxDepth applies to the rest of
+    the value.
+
+  - ExportedOnly
+    Omits unexported struct fields.
+
+  - IncludeFields
+    Regular expressions matched against struct field names and paths, such
+    as Spec.Status, which narrow the fields displayed to those matched and
+    the fields leading to them.
+
+  - ExcludeFields
+    Regular expressions matched against struct field names and paths which
+    hide the matched fields.
+
+  - OmitZero
+    Omits struct fields holding the zero value of their type.
+
+  - OmitNil
+    Omits struct fields holding nil pointers, interfaces, maps, slices,
+    channels or functions, with a count of the omitted fields per struct in
+    Dump output.
+
+  - FlattenEmbedded
+    Displays the fields of embedded structs at the level of the struct
+    embedding them, qualifying those whose name is shared such as Base.ID.
+
   - DisableMethods
     Disables invocation of error and Stringer interface methods.
     Method invocation is enabled by default.
@@ -98,6 +142,16 @@ The following configuration options are available:
     DisablePointerAddresses specifies whether to disable the printing of
     pointer addresses. This is useful when diffing data structures in tests.
 
+  - PointerIDs
+    Replaces pointer addresses with sequential identifiers such as #1,
+    assigned in traversal order, so output is reproducible across runs
+    while aliasing is still shown.
+
+  - SharedRefs
+    Displays the value behind a pointer in Dump output only the first time
+    it is encountered, with later occurrences replaced by a reference to
+    the identifier of the first, such as -> see #3 above.
+
   - DisableCapacities
     DisableCapacities specifies whether to disable the printing of
     capacities for arrays, slices, maps and channels. This is useful when
@@ -107,6 +161,30 @@ The following configuration options are available:
     Enables recursion into types after invoking error and Stringer interface
     methods. Recursion after method invocation is disabled by default.
 
+  - UseGoStringer
+    Displays types implementing fmt.GoStringer by the result of GoString,
+    like error and Stringer types.
+
+  - TextMarshalers
+    Displays types implementing encoding.TextMarshaler by their marshaled
+    text, like error and Stringer types.
+
+  - JSONMarshalers
+    Displays types implementing json.Marshaler by their marshaled JSON,
+    like error and Stringer types.
+
+  - VerbosePanics
+    Writes panics recovered from methods such as String as
+    <String() panicked: value> instead of (PANIC: value).
+
+  - PanicStackFrames
+    Number of frames of the stack of recovered panics to write after their
+    value when VerbosePanics is set.
+
+  - OnMethodPanic
+    A function called with each panic recovered from methods such as
+    String, for reporting them.
+
   - SortKeys
     Specifies map keys should be sorted before being printed. Use
     this to have a more deterministic, diffable output.  Note that
@@ -121,6 +199,297 @@ The following configuration options are available:
     spewed to strings and sorted by those strings.  This is only
     considered if SortKeys is true.
 
+  - Deterministic
+    Makes output byte-stable across runs for snapshot tests by enabling
+    PointerIDs, SortKeys, SpewKeys and DisableCapacities.
+
+  - KeyLess
+    A comparison function which replaces the default ordering of map keys
+    when SortKeys is true, such as for case-insensitive ordering.
+
+  - ShowDumpID
+    Writes a header with the short unique identifier assigned to each
+    invocation of Dump, which is also returned by FdumpStats.
+
+  - TraceID
+    A function returning the current trace identifier, which is included in
+    the dump header and statistics for correlating dumps with traces.
+
+  - ShowCaller
+    Prefixes the output of each call to the Dump and Print families of
+    functions with the file and line of the call.
+
+  - CallerFuncs
+    Includes the name of the calling function after its file and line when
+    ShowCaller is set.
+
+  - TimestampFormat
+    The layout used to write the current time ahead of the output of each
+    call to the Dump and Print families of functions, such as
+    time.RFC3339.  Timestamps are not written by default.
+
+  - LineNumbers
+    Prefixes each line of Dump output with its line number.
+
+  - ShowLineCount
+    Ends Dump output with the total number of lines when LineNumbers is
+    set.
+
+  - ShowSummary
+    Ends Dump output with the number of values displayed, the depth
+    reached, the number of truncations and redactions, the output size and
+    the number of values of each kind.
+
+  - ShowInterfaceTypes
+    Displays the static type of values held by non-empty interfaces ahead
+    of their dynamic type in Dump output, as in (io.Reader)(*bytes.Buffer).
+
+  - ShortGenericNames
+    Elides the package paths inside the type arguments of instantiated
+    generic types to their last element, as in pkg.Box[path.Thing].
+
+  - ReflectValues
+    Displays reflect.Value values nested inside the values displayed as
+    the value they hold instead of as the reflect.Value struct.
+
+  - Quiet
+    Suppresses normal Dump output and reports only the values considered
+    anomalous by the heuristics in QuietChecks, such as nil required fields
+    and NaN floats, along with their paths.
+
+  - Compact
+    Writes each argument passed to Dump on a single line without
+    indentation, which is useful for embedding dumps in log messages.
+
+  - MaxWidth
+    Soft-wraps long strings in Dump output to the number of columns, or to
+    the width of the terminal for MaxWidthTerminal, with hanging
+    indentation.
+
+  - MaxBytes
+    Maximum number of bytes written by each Dump call.  Output beyond the
+    limit is discarded and replaced with a truncation notice.  Output is not
+    limited by default.
+
+  - MaxElements
+    Maximum number of elements of each array, slice and map to display.
+    The rest are replaced by a "... (+N more)" marker.  Collections are not
+    limited by default.
+
+  - TailElements
+    Number of the last elements of each collection limited by MaxElements
+    to display after the marker, so both of its ends are shown.
+
+  - CollapseRuns
+    Displays runs of identical elements of arrays and slices once, followed
+    by their count such as ×1024.
+
+  - ElementIndices
+    Prefixes each element of arrays and slices with its index, as in
+    [17]: (int) 5.
+
+  - ChannelDetails
+    Displays the direction, element type and buffer usage of channels after
+    their address, and marks full buffered channels.
+
+  - FuncNames
+    Displays functions by their name, file and line instead of by their
+    address.
+
+  - HumanTimes
+    Displays time.Time values in the RFC 3339 format and time.Duration
+    values in human units instead of by their internal fields.
+
+  - RelativeTimes
+    Follows times displayed for HumanTimes with how long ago or ahead of
+    now they are, as in (2m13s ago).
+
+  - WellKnownTypes
+    Displays values of well-known types by what they represent, such as
+    the database/sql Null types as their value or NULL, the address types
+    of the net and net/netip packages in their canonical text form,
+    json.RawMessage values as indented JSON, contexts by their deadline,
+    error and values, slog values by what they hold, sync mutexes as locked
+    or unlocked, and other common standard library types, such as url.URL,
+    os.File and http.Request, concisely.  See RegisterWellKnownType for
+    adding types.
+
+  - UUIDs
+    Displays 16 byte arrays and slices whose type or field name suggests
+    they hold a UUID in the canonical hex-hyphen form in Dump output.
+
+  - ReflectTypes
+    Displays reflect.Type values by the type they describe, its kind and
+    package in Dump output, instead of by the internals of the reflect
+    package.
+
+  - Regexps
+    Displays regexp.Regexp values by their source pattern in Dump output,
+    instead of by their compiled program.
+
+  - ParseJSONStrings
+    Displays strings holding JSON objects or arrays as the parsed document
+    in Dump output, marked as parsed.
+
+  - AtomicValues
+    Displays the types of the sync/atomic package as the value loaded from
+    them instead of their internal representation.
+
+  - DrainIterators
+    Displays iterator functions such as iter.Seq and iter.Seq2 as the
+    values they yield, drained up to MaxElements and marked as consumed, in
+    Dump output.
+
+  - UnwrapErrors
+    Displays errors wrapping other errors, such as those created by
+    errors.Join and fmt.Errorf with %w, as a tree of the errors they wrap in
+    Dump output.
+
+  - PadPointers
+    Pads uintptr and unsafe.Pointer values with zeros to the width of a
+    pointer.
+
+  - PointerSymbols
+    Follows uintptr and unsafe.Pointer values pointing into the code of a
+    function with its name, such as <main.handler+0x20>.
+
+  - MaxStringLength
+    Maximum number of bytes of string values to display.  Longer strings
+    are truncated with a "... (+N bytes)" marker.  Strings are not truncated
+    by default.
+
+  - PeekReaders
+    Maximum number of the upcoming bytes of *bytes.Buffer, *bytes.Reader,
+    *strings.Reader and *bufio.Reader values to display instead of their
+    fields, without consuming them.  See PeekReader for wrapping other
+    readers.
+
+  - HighlightSQL
+    Highlights SQL keywords within strings held by fields named like Query
+    or SQL, or which read like statements such as SELECT ... FROM.
+
+  - ShowTags
+    Keys of the struct tags, such as json and db, to display after the names
+    of struct fields in Dump output.
+
+  - FieldCase
+    Displays struct field names in snake_case or camelCase to match wire
+    formats such as JSON, with the Go name of each renamed field following
+    in a comment.  Field names are displayed as declared by default.
+
+  - IntBase
+    Displays integers in hex, binary or octal rather than decimal.  The
+    base may be overridden per type with IntBaseTypes and per struct field
+    with the hex, bin, oct or dec options of the spew tag.
+
+  - IntBasePrefix
+    Prefixes integers displayed in hex, binary or octal with 0x, 0b or 0o.
+
+  - ThousandsSeparator
+    Groups the digits of integers displayed in decimal in thousands with
+    the separator, as in 1_234_567 or 1,234,567.
+
+  - FloatFormat
+    Displays floats in fixed or scientific notation rather than the
+    shorter of the two.
+
+  - FloatPrecision
+    Limits the number of digits floats are displayed with.  By default
+    floats are displayed with the fewest digits which round-trip exactly.
+
+  - BytesMode
+    Displays byte arrays and slices as base64, a string when they hold
+    printable text, a Go byte slice literal, or just their length rather
+    than a hexdump.
+
+  - BytesAsText
+    Displays byte arrays and slices holding mostly printable UTF-8 text,
+    such as JSON bodies, as quoted strings rather than hexdumps.
+
+  - Hexdump
+    Configures the layout of hexdumps, including the bytes per line, their
+    grouping, and whether offsets and the ASCII gutter are displayed.
+
+  - FmtFallbackTypes
+    Lists types which are rendered exactly as fmt's %+v verb renders them
+    rather than being inspected through reflection.
+
+  - SkipTypes
+    Lists types whose values are displayed as <skipped> rather than being
+    expanded.  Interface types skip every type which implements them.
+
+  - Redact
+    Replaces the values of struct fields and map entries whose names
+    match RedactFields, such as Password or Authorization, and the values
+    of RedactTypes by a [REDACTED len=N] placeholder.
+
+  - RedactFields
+    Regular expressions matched against names when Redact is set.
+    Defaults to DefaultRedactFields.
+
+  - RedactTypes
+    Lists types whose values are redacted when Redact is set.
+
+  - RedactFunc
+    A callback given the path and value of each value, which returns the
+    text to display in place of values it masks, such as partially masked
+    email addresses.
+
+  - ShowSizes
+    Annotates pointers and composite values in Dump output with their
+    estimated shallow and retained sizes.  Objects reachable from several
+    values are attributed to the first one displayed.
+
+  - ShowMethods
+    Follows each argument in Dump output with the method set of its type,
+    along with the methods declared with a pointer receiver when it is not
+    a pointer.
+
+  - ShowLayout
+    Annotates each struct field in Dump output with its offset, size and
+    trailing padding, such as // off=8 size=8 pad=4.
+
+  - MapTables
+    Displays maps of scalars and strings as Markdown tables with aligned
+    key and value columns in Dump output.
+
+  - GroupByType
+    Clusters the elements of containers holding interfaces by their
+    concrete type, with a per-type count, in Dump output.
+
+  - DimPunctuation
+    Renders structural punctuation such as parens, braces, commas, and
+    colons in a faint style so the data itself stands out.
+
+  - Renderer
+    Replaces the text output of Dump with a custom renderer consuming the
+    tree of nodes returned by Parse, such as the built-in TreeRenderer,
+    SexpRenderer and ProtoTextRenderer.
+
+  - DisableDumpColor
+    Disables colors in Dump style output only.
+
+  - DisableFormatterColor
+    Disables colors in Formatter output, such as that of Printf and Sprint,
+    only.  This is useful when the output is interpolated into log lines.
+
+  - AlsoWrite
+    A writer receiving a copy of the output of each call to the Dump and
+    Print families of functions without colors, such as a log file or a
+    RingBuffer.
+
+  - Glyphs
+    Prefixes sections of Dump output with glyphs, such as a table before
+    maps and an arrow before pointers.  GlyphsNerdFont requires a Nerd Fonts
+    patched font and GlyphsASCII provides a pure ASCII fallback.  Glyphs are
+    disabled by default.
+
+  - Symbols
+    Writes sentinel values in Dump output as symbols, such as ∅ for nil,
+    ✓ and ✗ for true and false, and an ellipsis for values beyond MaxDepth.
+    SymbolsASCII provides a pure ASCII fallback.  Symbols are disabled by
+    default.
+
 # Dump Usage
 
 Simply call spew.Dump with a list of variables you want to dump:
@@ -160,6 +529,58 @@ command as shown.
 	 00000020  31 32                                             |12|
 	}
 
+When the ReflectTypes option is set, the reflect.Type values describing types
+are displayed by the type they describe, its kind, and the import path of its
+package when it is a named type, instead of by the internals of the reflect
+package.
+
+	(*reflect.rtype) main.Foo (kind: struct pkg: example.com/app)
+
+Likewise, when the Regexps option is set, regexps are displayed by their
+source pattern, followed by (longest) when they prefer leftmost-longest
+matches, instead of their compiled program.
+
+	(*regexp.Regexp) "^[a-z]+$"
+
+# Struct Tags
+
+The display of individual struct fields can be controlled with options in
+their spew tag, separated by commas, in the output of Dump, the custom
+formatter and Parse:
+
+	type Account struct {
+		ID       int
+		cache    map[string]int `spew:"-"`
+		Password string         `spew:"redact"`
+		State    State          `spew:"string"`
+		Parent   *Account       `spew:"maxdepth=1"`
+	}
+
+The - option skips the field entirely.  The redact option replaces its value
+with a [REDACTED] placeholder, which gives the length of strings, slices,
+arrays and maps.  The string option displays the value with its Stringer or
+error interface even when DisableMethods is set, and the maxdepth=N option
+limits how many levels below the field are displayed.  The json option
+displays the JSON document held by a string or byte slice indented, colored
+and marked as parsed in Dump output, as is done for json.RawMessage values
+when WellKnownTypes is set, and the value as usual when it is not valid JSON.  The
+hex, bin, oct and dec options described under IntBase are also accepted.
+
+# Type Formatters
+
+The rendering of values of specific types can be fully overridden by
+registering a formatter for the type, either for every ConfigState with
+RegisterFormatter or for a single one with ConfigState.RegisterFormatter:
+
+	spew.RegisterFormatter(reflect.TypeOf((*rsa.PrivateKey)(nil)),
+		func(w io.Writer, v interface{}) {
+			fmt.Fprintf(w, "<private key, %d bits>", v.(*rsa.PrivateKey).N.BitLen())
+		})
+
+Types can instead control their own Dump output by implementing SpewDumper,
+whose DumpSpew method is given a Dumper with access to the current depth,
+indentation and colors of the dump.
+
 # Custom Formatter
 
 Spew provides a custom formatter that implements the fmt.Formatter interface
@@ -18,7 +18,6 @@ package spew
 
 import (
 	"bytes"
-	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -54,9 +53,47 @@ type dumpState struct {
 	w                io.Writer
 	depth            int
 	pointers         map[uintptr]int
+	visited          map[visitKey]bool
 	ignoreNextType   bool
 	ignoreNextIndent bool
 	cs               *ConfigState
+	ids              *pointerIDs
+	sizes            *sizer
+	stats            *DumpStats
+	fieldName        string
+	fieldTag         reflect.StructTag
+	staticType       reflect.Type
+
+	// filter holds the IncludeFields and ExcludeFields options, and path is
+	// the path of struct field names to the value being dumped.
+	filter *fieldFilter
+	path   string
+
+	// redactor holds the redaction options, and keyName is the name of the
+	// map entry whose value is dumped next.
+	redactor *redactor
+	keyName  string
+
+	// maxDepth is the depth beyond which the elements of containers are not
+	// displayed, which is lowered within values of the types given by the
+	// MaxDepthTypes option.
+	maxDepth int
+
+	// forceMethods is set while dumping a field whose spew struct tag has the
+	// string option until the value behind any pointers is reached.
+	forceMethods bool
+
+	// shown holds the pointers whose values have been displayed when the
+	// SharedRefs option is set, and is nil otherwise.
+	shown map[visitKey]bool
+
+	// indents caches the indentation of each depth.
+	indents []string
+
+	// width is the width long strings are wrapped to, or 0 when they are
+	// not wrapped, in which case col is nil.
+	width int
+	col   *columnWriter
 }
 
 // indent performs indentation according to the depth level and cs.Indent
@@ -66,19 +103,63 @@ func (d *dumpState) indent() {
 		d.ignoreNextIndent = false
 		return
 	}
-	d.w.Write(bytes.Repeat([]byte(d.cs.Indent), d.depth))
+	if d.cs.Compact {
+		return
+	}
+	d.w.Write([]byte(d.indentation(d.depth)))
+}
+
+// punct writes structural punctuation such as parens, braces, and commas.
+func (d *dumpState) punct(b []byte) {
+	if d.cs.Compact {
+		b = compactLine(b)
+	}
+	writePunct(d.w, d.cs, b)
 }
 
 // unpackValue returns values inside of non-nil interfaces when possible.
 // This is useful for data types like structs, arrays, slices, and maps which
-// can contain varying types packed inside an interface.
+// can contain varying types packed inside an interface.  The type of
+// non-empty interfaces is kept for the next value dumped when the
+// ShowInterfaceTypes option is set.
 func (d *dumpState) unpackValue(v reflect.Value) reflect.Value {
 	if v.Kind() == reflect.Interface && !v.IsNil() {
+		if d.cs.ShowInterfaceTypes && v.NumMethod() > 0 {
+			d.staticType = v.Type()
+		}
 		v = v.Elem()
 	}
 	return v
 }
 
+// typeHeader writes the type of v, preceded by the interface type it was
+// unpacked from when staticType is not nil, unless it was already handled
+// elsewhere.
+func (d *dumpState) typeHeader(v reflect.Value, staticType reflect.Type) {
+	if !d.ignoreNextType {
+		d.indent()
+		d.writeStaticType(staticType)
+		writeGlyph(d.w, d.cs, v.Kind())
+		withParens(d, func(d *dumpState) {
+			printType(d.w, d.cs, v.Type().String())
+		})
+		d.w.Write(spaceBytes)
+	}
+	d.ignoreNextType = false
+}
+
+// writeStaticType writes the interface type t a value was unpacked from
+// ahead of the type of the value itself, as in (io.Reader)(*bytes.Buffer).
+// Nothing is written when t is nil.
+func (d *dumpState) writeStaticType(t reflect.Type) {
+	if t == nil {
+		return
+	}
+	withParens(d, func(d *dumpState) {
+		printType(d.w, d.cs, t.String())
+	})
+}
+
 type float interface {
 	float32 | float64
 }
@@ -87,6 +168,26 @@ type number interface {
 	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | complex64 | complex128
 }
 
+// line writes b, which ends with a newline.  The newline is replaced with a
+// space when the Compact option is set.
+func (d *dumpState) line(b []byte) {
+	if d.cs.Compact {
+		b = compactLine(b)
+	}
+	d.w.Write(b)
+}
+
+// compactLine returns b with its trailing newline replaced with a space.
+func compactLine(b []byte) []byte {
+	if !bytes.HasSuffix(b, newlineBytes) {
+		return b
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	c[len(c)-1] = ' '
+	return c
+}
+
 // dumpPtr handles formatting of pointers by indirecting them as necessary.
 func (d *dumpState) dumpPtr(v reflect.Value) {
 	// Remove pointers at or below the current depth from map used to detect
@@ -105,6 +206,7 @@ func (d *dumpState) dumpPtr(v reflect.Value) {
 	// references.
 	nilFound := false
 	cycleFound := false
+	var sharedAddr uintptr
 	indirects := 0
 	ve := v
 	for ve.Kind() == reflect.Ptr {
@@ -121,6 +223,15 @@ func (d *dumpState) dumpPtr(v reflect.Value) {
 			break
 		}
 		d.pointers[addr] = d.depth
+		if d.shown != nil {
+			key := visitKey{typ: ve.Type(), addr: addr}
+			if d.shown[key] {
+				sharedAddr = addr
+				indirects--
+				break
+			}
+			d.shown[key] = true
+		}
 
 		ve = ve.Elem()
 		if ve.Kind() == reflect.Interface {
@@ -132,33 +243,41 @@ func (d *dumpState) dumpPtr(v reflect.Value) {
 		}
 	}
 
+	writeGlyph(d.w, d.cs, reflect.Ptr)
 	withParens(d, func(d *dumpState) {
 		// Display type information.
 		d.w.Write(bytes.Repeat(asteriskBytes, indirects))
 		printType(d.w, d.cs, ve.Type().String())
 	})
 
-	// Display pointer information.
-	if !d.cs.DisablePointerAddresses && len(pointerChain) > 0 {
+	// Display pointer information, which anchors the references to shared
+	// pointers.
+	if (!d.cs.DisablePointerAddresses || d.shown != nil) && len(pointerChain) > 0 {
 		withParens(d, func(d *dumpState) {
 			for i, addr := range pointerChain {
 				if i > 0 {
-					d.w.Write(pointerChainBytes)
+					d.punct(pointerChainBytes)
 				}
-				printHexPtr(d.w, addr)
+				d.ids.write(d.w, addr)
 			}
 		})
 	}
 
+	// Display the sizes of the pointer when requested.
+	defer d.printSizes(v)()
+
 	// Display dereferenced value.
 	withParens(d, func(d *dumpState) {
 		switch {
 		case nilFound:
-			d.w.Write(nilAngleBytes)
+			d.w.Write(nilSymbol(d.cs))
 
 		case cycleFound:
 			d.w.Write(circularBytes)
 
+		case sharedAddr != 0:
+			d.sharedRef(sharedAddr)
+
 		default:
 			d.ignoreNextType = true
 			d.dump(ve)
@@ -166,9 +285,9 @@ func (d *dumpState) dumpPtr(v reflect.Value) {
 	})
 }
 
-// dumpSlice handles formatting of arrays and slices.  Byte (uint8 under
-// reflection) arrays and slices are dumped in hexdump -C fashion.
-func (d *dumpState) dumpSlice(v reflect.Value) {
+// byteSlice returns the contents of the byte (uint8 under reflection) array
+// or slice v, including cgo char arrays, and whether v is one.
+func byteSlice(v reflect.Value) ([]uint8, bool) {
 	// Determine whether this type should be hex dumped or not.  Also,
 	// for types which should be hexdumped, try to use the underlying data
 	// first, then fall back to trying to convert them to a uint8 slice.
@@ -232,63 +351,348 @@ func (d *dumpState) dumpSlice(v reflect.Value) {
 			doHexDump = true
 		}
 	}
+	return buf, doHexDump
+}
+
+// dumpSlice handles formatting of arrays and slices.  Byte (uint8 under
+// reflection) arrays and slices are dumped in hexdump -C fashion.
+func (d *dumpState) dumpSlice(v reflect.Value) {
+	win := d.cs.limitElements(v.Len())
+	numEntries := win.len()
+	buf, doHexDump := byteSlice(v)
 
-	// Hexdump the entire slice as needed.
+	// Hexdump the entire slice as needed.  Only the leading bytes are
+	// displayed when it is limited, so the offsets stay contiguous.
 	if doHexDump {
-		indent := strings.Repeat(d.cs.Indent, d.depth)
-		str := indent + hex.Dump(buf)
+		buf = buf[:min(len(buf), win.head)]
+		defer d.moreLine(v.Len() - len(buf))
+		if d.cs.Compact {
+			d.w.Write([]byte(hexBytes(buf)))
+			d.w.Write(spaceBytes)
+			return
+		}
+		indent := d.indentation(d.depth)
+		str := indent + hexdump(buf, d.cs.Hexdump)
 		str = strings.Replace(str, "\n", "\n"+indent, -1)
-		str = strings.TrimRight(str, d.cs.Indent)
+		str = strings.TrimSuffix(str, indent)
 		d.w.Write([]byte(str))
 		return
 	}
 
-	// Recursively call dump for each item.
-	for i := 0; i < numEntries; i++ {
+	// Group the items by their concrete type when requested.
+	if d.cs.GroupByType && v.Type().Elem().Kind() == reflect.Interface {
+		values := make([]reflect.Value, numEntries)
+		for j := range values {
+			values[j] = v.Index(win.index(j))
+		}
+		d.dumpGrouped(values, func(i int) {
+			d.elementIndex(win.index(i))
+			d.dump(d.unpackValue(values[i]))
+		})
+		d.moreLine(win.omitted())
+		return
+	}
+
+	// Recursively call dump for each item, or once for each run of identical
+	// items, with the omitted items marked between the head and tail.
+	for j := 0; j < numEntries; {
+		i := win.index(j)
+		run := d.cs.runLength(v, i, win.end(j))
+		d.elementIndex(i)
 		d.dump(d.unpackValue(v.Index(i)))
-		if i < (numEntries - 1) {
-			d.w.Write(commaNewlineBytes)
+		writeRunCount(d.w, d.cs, run)
+		if j += run; j < numEntries {
+			d.punct(commaNewlineBytes)
 		} else {
-			d.w.Write(newlineBytes)
+			d.line(newlineBytes)
+		}
+		if j == win.head {
+			d.moreLine(win.omitted())
 		}
 	}
 }
 
+// dumpGrouped handles the GroupByType option by dumping the passed interface
+// values clustered by the concrete type they hold.  Each group is preceded by
+// a header with the type and the number of values in the group, and the groups
+// are ordered by the first appearance of their type.  The dumpEntry function
+// is called with the index into values of each entry to dump.
+func (d *dumpState) dumpGrouped(values []reflect.Value, dumpEntry func(i int)) {
+	names, groups := typeGroups(values)
+	written := 0
+	for g, group := range groups {
+		d.indent()
+		d.w.Write(groupHeaderBytes)
+		printType(d.w, d.cs, names[g])
+		d.w.Write(spaceBytes)
+		withParens(d, func(d *dumpState) {
+			printNumber(d.w, d.cs, len(group))
+		})
+		d.line(groupFooterBytes)
+		for _, i := range group {
+			dumpEntry(i)
+			written++
+			if written < len(values) {
+				d.punct(commaNewlineBytes)
+			} else {
+				d.line(newlineBytes)
+			}
+		}
+	}
+}
+
+// typeGroups partitions the indices of the passed interface values by the
+// concrete type each of them holds, in order of first appearance.  The names
+// of the types are returned along with the groups.  Nil interfaces are
+// grouped under <nil>.
+func typeGroups(values []reflect.Value) (names []string, groups [][]int) {
+	positions := make(map[string]int)
+	for i, v := range values {
+		name := string(nilAngleBytes)
+		if !v.IsNil() {
+			name = v.Elem().Type().String()
+		}
+		pos, ok := positions[name]
+		if !ok {
+			pos = len(groups)
+			positions[name] = pos
+			names = append(names, name)
+			groups = append(groups, nil)
+		}
+		groups[pos] = append(groups[pos], i)
+	}
+	return names, groups
+}
+
 // dump is the main workhorse for dumping a value.  It uses the passed reflect
 // value to figure out what kind of object we are dealing with and formats it
 // appropriately.  It is a recursive function, however circular data structures
 // are detected and handled properly.
 func (d *dumpState) dump(v reflect.Value) {
+	if d.cs.ReflectValues {
+		v = heldValue(v)
+	}
+
+	// The name and tag of the struct field holding v only apply to v
+	// itself.
+	fieldName, fieldTag, keyName := d.fieldName, d.fieldTag, d.keyName
+	d.fieldName, d.fieldTag, d.keyName = "", "", ""
+	staticType := d.staticType
+	d.staticType = nil
+
 	// Handle invalid reflect values immediately.
 	kind := v.Kind()
 	if kind == reflect.Invalid {
 		d.w.Write(invalidAngleBytes)
 		return
 	}
+	d.countNode(kind)
+
+	// Apply the options of the spew tag of the struct field holding v.
+	opts := spewTagOptions(fieldTag)
+	name := fieldName
+	if keyName != "" {
+		name = keyName
+	}
+	if opts.redact || d.redactor.redacts(name, v.Type()) {
+		d.dumpRedacted(v, redactedText(v))
+		return
+	}
+	if text, ok := d.cs.customRedaction(d.path, keyName, v); ok {
+		d.dumpRedacted(v, text)
+		return
+	}
+	if d.cs.isSkippedType(v.Type()) {
+		d.forceMethods = false
+		d.dumpSkipped(v)
+		return
+	}
+	if opts.stringer {
+		d.forceMethods = true
+	}
+
+	// Lower the depth limit within values of types or fields with their own
+	// limit.
+	limit := d.cs.typeMaxDepth(d.maxDepth, v.Type(), d.depth)
+	limit = opts.fieldMaxDepth(limit, d.depth)
+	if limit != d.maxDepth {
+		defer func(maxDepth int) { d.maxDepth = maxDepth }(d.maxDepth)
+		d.maxDepth = limit
+	}
+
+	// Render values of types with a registered formatter with it.
+	if write, ok := registeredFormatter(d.cs, v); ok {
+		d.typeHeader(v, staticType)
+		d.forceMethods = false
+		write(d.w)
+		return
+	}
+
+	// Display values of well-known types by what they hold.
+	if d.cs.WellKnownTypes {
+		if inner, valid, ok := sqlNull(v); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			if !valid {
+				withColor(d.w, sqlNullBytes, d.cs.Color.Keyword...)
+				return
+			}
+			// The header has been indented already.
+			d.ignoreNextIndent = true
+			d.ignoreNextType = true
+			d.dump(inner)
+			d.ignoreNextIndent = false
+			return
+		}
+	}
+
+	// Display the JSON documents held by json.RawMessage values and fields
+	// with the json option indented, marked so they aren't mistaken for
+	// structs.
+	if opts.json || d.cs.WellKnownTypes && v.Type() == rawMessageType {
+		if b, ok := jsonBytes(v); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			d.dumpParsedJSON(b)
+			return
+		}
+	}
+
+	// Display strings holding JSON objects and arrays as parsed documents
+	// when asked to, marked so they aren't mistaken for structs.
+	if d.cs.ParseJSONStrings && kind == reflect.String && isJSONDocument(v.String()) {
+		if b, ok := jsonBytes(v); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			d.dumpParsedJSON(b)
+			return
+		}
+	}
+
+	// Display the types of the sync/atomic package as their loaded value.
+	if d.cs.AtomicValues {
+		if loaded, ok := atomicValue(v); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			withParens(d, func(d *dumpState) {
+				withColor(d.w, loadedBytes, d.cs.Color.Length...)
+			})
+			d.w.Write(spaceBytes)
+			// The type of loaded scalars is given by the atomic type.
+			loaded = d.unpackValue(loaded)
+			d.ignoreNextIndent = true
+			d.ignoreNextType = isScalarKind(loaded.Kind())
+			d.dump(loaded)
+			d.ignoreNextIndent = false
+			return
+		}
+	}
+
+	// Display the values yielded by iterator functions when asked to.
+	if d.cs.DrainIterators && kind == reflect.Func && !v.IsNil() && isIterator(v.Type()) {
+		if v.CanInterface() || !UnsafeDisabled {
+			d.forceMethods = false
+			d.dumpIterator(v, staticType)
+			return
+		}
+	}
+
+	// Display the contexts of the context package by what they carry.
+	if d.cs.WellKnownTypes {
+		if ctx, ok := contextValue(v); ok {
+			d.forceMethods = false
+			d.dumpContext(v, staticType, ctx)
+			return
+		}
+		if sv, ok := slogValue(v); ok {
+			d.forceMethods = false
+			d.dumpSlog(v, staticType, sv)
+			return
+		}
+	}
+
+	// Display errors wrapping other errors as a tree of the errors they wrap.
+	if d.cs.UnwrapErrors {
+		if err, ok := errorValue(v); ok {
+			if wrapped, multi := wrappedErrors(err); len(wrapped) > 0 || multi {
+				d.forceMethods = false
+				d.dumpErrorTree(v, staticType, err, wrapped, multi)
+				return
+			}
+		}
+	}
+
+	// Display 16 byte arrays and slices which appear to hold UUIDs in their
+	// canonical form.
+	if d.cs.UUIDs && isUUID(v, name) {
+		if b, ok := byteSlice(v); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			withColor(d.w, []byte(formatUUID(b)), d.cs.Color.Number...)
+			return
+		}
+	}
+
+	// Display the types described by reflect.Type values rather than the
+	// internals describing them.
+	if d.cs.ReflectTypes {
+		if t, ok := reflectType(v); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			d.dumpReflectType(t)
+			return
+		}
+	}
+
+	// Display regexps by their source pattern rather than their compiled
+	// program.
+	if d.cs.Regexps {
+		if expr, longest, ok := regexpPattern(v); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			d.dumpRegexp(expr, longest)
+			return
+		}
+	}
+
+	// Display the upcoming bytes of readers whose contents can be seen
+	// without consuming them.
+	if d.cs.PeekReaders > 0 {
+		if peek, total, buffered, ok := peekReader(v, d.cs.PeekReaders); ok {
+			d.typeHeader(v, staticType)
+			d.forceMethods = false
+			d.dumpPeek(peek, total, buffered)
+			return
+		}
+	}
 
 	// Handle pointers specially.
 	if kind == reflect.Ptr {
 		d.indent()
+		d.writeStaticType(staticType)
 		d.dumpPtr(v)
+		d.forceMethods = false
 		return
 	}
+	forceMethods := d.forceMethods
+	d.forceMethods = false
 
 	// Print type information unless already handled elsewhere.
-	if !d.ignoreNextType {
-		d.indent()
-		withParens(d, func(d *dumpState) {
-			printType(d.w, d.cs, v.Type().String())
-		})
-		d.w.Write(spaceBytes)
-	}
-	d.ignoreNextType = false
+	pointee := d.ignoreNextType
+	d.typeHeader(v, staticType)
 
 	// Display length and capacity if the built-in len and cap functions
 	// work with the value's kind and the len/cap itself is non-zero.
 	valueLen, valueCap := 0, 0
 	switch v.Kind() {
-	case reflect.Array, reflect.Slice, reflect.Chan:
+	case reflect.Array, reflect.Slice:
 		valueLen, valueCap = v.Len(), v.Cap()
+	case reflect.Chan:
+		// The buffer usage is displayed after the address instead when
+		// ChannelDetails is set.
+		if !d.cs.ChannelDetails {
+			valueLen, valueCap = v.Len(), v.Cap()
+		}
 	case reflect.Map, reflect.String:
 		valueLen = v.Len()
 	}
@@ -309,10 +713,25 @@ func (d *dumpState) dump(v reflect.Value) {
 		d.w.Write(spaceBytes)
 	}
 
+	// Display the sizes of composite values when requested.  The sizes of
+	// values reached through a pointer are displayed with the pointer.
+	if d.sizes != nil && !pointee && isSizedKind(kind) {
+		defer d.printSizes(v)()
+		d.w.Write(spaceBytes)
+	}
+
+	// Defer to fmt for types which are configured to be rendered by it.
+	if kind != reflect.Interface && handleFmtFallback(d.cs, d.w, v) {
+		return
+	}
+
 	// Call Stringer/error interfaces if they exist and the handle methods flag
 	// is enabled
-	if !d.cs.DisableMethods {
+	if !d.cs.DisableMethods || forceMethods {
 		if (kind != reflect.Invalid) && (kind != reflect.Interface) {
+			if d.handleSpewDumper(v) {
+				return
+			}
 			if handled := handleMethods(d.cs, d.w, v); handled {
 				return
 			}
@@ -328,10 +747,10 @@ func (d *dumpState) dump(v reflect.Value) {
 		printBool(d.w, d.cs, v.Bool())
 
 	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
-		printNumber(d.w, d.cs, v.Int())
+		printInt(d.w, d.cs, v.Int(), d.cs.intBase(v.Type(), fieldTag))
 
 	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
-		printNumber(d.w, d.cs, v.Uint())
+		printInt(d.w, d.cs, v.Uint(), d.cs.intBase(v.Type(), fieldTag))
 
 	case reflect.Float32:
 		printFloat(d.w, d.cs, v.Float(), 32)
@@ -344,32 +763,54 @@ func (d *dumpState) dump(v reflect.Value) {
 
 	case reflect.Slice:
 		if v.IsNil() {
-			d.w.Write(nilAngleBytes)
+			d.w.Write(nilSymbol(d.cs))
+			break
+		}
+		if d.visited[newVisitKey(v)] {
+			d.w.Write(circularBytes)
 			break
 		}
+		d.visited[newVisitKey(v)] = true
+		defer delete(d.visited, newVisitKey(v))
 		fallthrough
 
 	case reflect.Array:
-		d.w.Write(openBraceNewlineBytes)
+		if d.dumpBytes(v) {
+			break
+		}
+		d.punct(openBraceNewlineBytes)
 		d.depth++
-		if (d.cs.MaxDepth != 0) && (d.depth > d.cs.MaxDepth) {
+		if d.depth > d.maxDepth {
 			d.indent()
-			d.w.Write(maxNewlineBytes)
+			d.line(maxSymbol(d.cs))
+			d.countTruncation()
 		} else {
 			d.dumpSlice(v)
 		}
 		d.depth--
 		d.indent()
-		d.w.Write(closeBraceBytes)
+		d.punct(closeBraceBytes)
 
 	case reflect.String:
-		printString(d.w, d.cs, strconv.Quote(v.String()))
+		s, removed := d.cs.truncateString(v.String())
+		switch {
+		case d.cs.HighlightSQL && isSQL(fieldName, s):
+			printSQL(d.w, d.cs, s)
+		case d.col != nil:
+			d.printWrapped(strconv.Quote(s))
+		default:
+			printString(d.w, d.cs, strconv.Quote(s))
+		}
+		writeTruncation(d.w, d.cs, removed)
+		if removed > 0 {
+			d.countTruncation()
+		}
 
 	case reflect.Interface:
 		// The only time we should get here is for nil interfaces due to
 		// unpackValue calls.
 		if v.IsNil() {
-			d.w.Write(nilAngleBytes)
+			d.w.Write(nilSymbol(d.cs))
 		}
 
 	case reflect.Ptr:
@@ -379,69 +820,120 @@ func (d *dumpState) dump(v reflect.Value) {
 	case reflect.Map:
 		// nil maps should be indicated as different than empty maps
 		if v.IsNil() {
-			d.w.Write(nilAngleBytes)
+			d.w.Write(nilSymbol(d.cs))
+			break
+		}
+
+		// Maps which contain themselves through interfaces are circular
+		// without involving a pointer.
+		if d.visited[newVisitKey(v)] {
+			d.w.Write(circularBytes)
 			break
 		}
+		d.visited[newVisitKey(v)] = true
+		defer delete(d.visited, newVisitKey(v))
 
-		d.w.Write(openBraceNewlineBytes)
+		d.punct(openBraceNewlineBytes)
 		d.depth++
-		if (d.cs.MaxDepth != 0) && (d.depth > d.cs.MaxDepth) {
+		if d.depth > d.maxDepth {
 			d.indent()
-			d.w.Write(maxNewlineBytes)
+			d.line(maxSymbol(d.cs))
+			d.countTruncation()
 		} else {
-			numEntries := v.Len()
 			keys := v.MapKeys()
 			if d.cs.SortKeys {
 				sortValues(keys, d.cs)
 			}
-			for i, key := range keys {
-				d.dump(d.unpackValue(key))
-				d.w.Write(colonSpaceBytes)
-				d.ignoreNextIndent = true
-				d.dump(d.unpackValue(v.MapIndex(key)))
-				if i < (numEntries - 1) {
-					d.w.Write(commaNewlineBytes)
-				} else {
-					d.w.Write(newlineBytes)
+			win := d.cs.limitElements(len(keys))
+			numEntries := win.len()
+			keys = win.keys(keys)
+			if d.cs.MapTables && !d.cs.Compact && isTabular(v, keys) {
+				d.dumpMapTable(v, keys)
+				d.moreLine(win.omitted())
+			} else if d.cs.GroupByType && v.Type().Elem().Kind() == reflect.Interface {
+				values := make([]reflect.Value, numEntries)
+				for i, key := range keys {
+					values[i] = v.MapIndex(key)
+				}
+				d.dumpGrouped(values, func(i int) {
+					d.dump(d.unpackValue(keys[i]))
+					d.punct(colonSpaceBytes)
+					d.ignoreNextIndent = true
+					d.keyName = mapKeyName(keys[i])
+					d.dump(d.unpackValue(values[i]))
+				})
+				d.moreLine(win.omitted())
+			} else {
+				for i, key := range keys {
+					d.dump(d.unpackValue(key))
+					d.punct(colonSpaceBytes)
+					d.ignoreNextIndent = true
+					d.keyName = mapKeyName(key)
+					d.dump(d.unpackValue(v.MapIndex(key)))
+					if i < (numEntries - 1) {
+						d.punct(commaNewlineBytes)
+					} else {
+						d.line(newlineBytes)
+					}
+					if i+1 == win.head {
+						d.moreLine(win.omitted())
+					}
 				}
 			}
 		}
 		d.depth--
 		d.indent()
-		d.w.Write(closeBraceBytes)
+		d.punct(closeBraceBytes)
 
 	case reflect.Struct:
-		d.w.Write(openBraceNewlineBytes)
+		if layout, ok := lookupBinaryLayout(v.Type()); ok {
+			d.dumpBinary(v, layout)
+			break
+		}
+
+		d.punct(openBraceNewlineBytes)
 		d.depth++
-		if (d.cs.MaxDepth != 0) && (d.depth > d.cs.MaxDepth) {
+		if d.depth > d.maxDepth {
 			d.indent()
-			d.w.Write(maxNewlineBytes)
+			d.line(maxSymbol(d.cs))
+			d.countTruncation()
 		} else {
-			vt := v.Type()
-			numFields := v.NumField()
-			for i := 0; i < numFields; i++ {
+			path := d.path
+			fields, nils := d.cs.shownFields(v, d.filter, d.redactor, path)
+			for j, f := range fields {
 				d.indent()
-				vtf := vt.Field(i)
-				d.w.Write([]byte(vtf.Name))
-				d.w.Write(colonSpaceBytes)
+				writeFieldName(d.w, d.cs, f.name)
+				writeFieldTags(d.w, d.cs, f.field.Tag)
+				d.punct(colonSpaceBytes)
 				d.ignoreNextIndent = true
-				d.dump(d.unpackValue(v.Field(i)))
-				if i < (numFields - 1) {
-					d.w.Write(commaNewlineBytes)
-				} else {
-					d.w.Write(newlineBytes)
+				d.fieldName, d.fieldTag = f.field.Name, f.field.Tag
+				d.path = f.path
+				d.dump(d.unpackValue(f.value))
+				if j < (len(fields) - 1) {
+					d.punct(commaBytes)
 				}
+				d.fieldLayout(f)
+				d.line(newlineBytes)
 			}
+			d.path = path
+			d.omittedLine(nils)
 		}
 		d.depth--
 		d.indent()
-		d.w.Write(closeBraceBytes)
+		d.punct(closeBraceBytes)
 
 	case reflect.Uintptr:
-		printHexPtr(d.w, uintptr(v.Uint()))
+		writeAddress(d.w, d.cs, nil, uintptr(v.Uint()))
+
+	case reflect.UnsafePointer:
+		writeAddress(d.w, d.cs, d.ids, v.Pointer())
+
+	case reflect.Func:
+		d.dumpFunc(v)
 
-	case reflect.UnsafePointer, reflect.Chan, reflect.Func:
-		printHexPtr(d.w, v.Pointer())
+	case reflect.Chan:
+		d.ids.write(d.w, v.Pointer())
+		d.chanDetails(v)
 
 	// There were not any other types at the time this code was written, but
 	// fall back to letting the default fmt package handle it in case any new
@@ -458,26 +950,151 @@ func (d *dumpState) dump(v reflect.Value) {
 // fdump is a helper function to consolidate the logic from the various public
 // methods which take varying writers and config states.
 func fdump(cs *ConfigState, w io.Writer, a ...interface{}) {
-	for _, arg := range a {
-		if arg == nil {
-			w.Write(interfaceBytes)
-			w.Write(spaceBytes)
-			w.Write(nilAngleBytes)
-			w.Write(newlineBytes)
+	fdumpIDs(cs, w, nil, a...)
+}
+
+// fdumpIDs dumps the passed arguments like fdump while labeling pointers with
+// the identifiers assigned by ids instead of their addresses when ids is not
+// nil.  Statistics about the dump are returned.
+func fdumpIDs(cs *ConfigState, w io.Writer, ids *pointerIDs, a ...interface{}) DumpStats {
+	values := make([]reflect.Value, len(a))
+	for i, arg := range a {
+		values[i] = reflect.ValueOf(arg)
+	}
+	return fdumpValues(cs, w, ids, values)
+}
+
+// fdumpValues dumps the passed values like fdumpIDs, with the zero Value
+// written as a nil interface.
+func fdumpValues(cs *ConfigState, w io.Writer, ids *pointerIDs, values []reflect.Value) DumpStats {
+	return fdumpLabeledValues(cs, w, ids, values, nil)
+}
+
+// fdumpLabeledValues dumps the passed values like fdumpValues, writing the
+// header of each value with label ahead of it when label is not nil, or a
+// lone header when there are no values.  The headers go through the same
+// writers as the values, so they are prefixed, numbered and copied to the
+// AlsoWrite writer like them.
+func fdumpLabeledValues(cs *ConfigState, w io.Writer, ids *pointerIDs, values []reflect.Value,
+	label func(cs *ConfigState, w io.Writer, i int)) DumpStats {
+	cs = cs.resolve()
+	if cs.DisableDumpColor {
+		cs = cs.plain()
+	}
+	width := wrapWidth(cs, w)
+	stats := DumpStats{ID: newDumpID(), Kinds: make(map[reflect.Kind]int)}
+	if cs.TraceID != nil {
+		stats.TraceID = cs.TraceID()
+	}
+	if ids == nil && (cs.PointerIDs || cs.SharedRefs) {
+		ids = &pointerIDs{}
+	}
+	out := w
+	w = teeWriter(cs, w)
+	cw := &countingWriter{w: w}
+	w = cw
+	var limit *limitWriter
+	if cs.MaxBytes > 0 {
+		limit = &limitWriter{w: w, max: cs.MaxBytes}
+		w = limit
+	}
+	var lw *lineNumberWriter
+	if cs.LineNumbers {
+		lw = &lineNumberWriter{w: w, cs: cs}
+		w = lw
+	}
+	w.Write(outputPrefix(cs))
+	writeDumpHeader(cs, w, &stats)
+	if label != nil && len(values) == 0 {
+		label(cs, w, 0)
+	}
+	for i, v := range values {
+		if limit != nil && limit.full {
+			break
+		}
+		if label != nil {
+			label(cs, w, i)
+		}
+		if cs.Quiet {
+			if v.IsValid() {
+				dumpAnomalies(cs, w, v)
+			}
+			continue
+		}
+		if cs.Renderer != nil {
+			root := parseValue(cs, v)
+			root.ids, root.stats = ids, &stats
+			cs.Renderer.Render(w, cs, root)
 			continue
 		}
+		dumpValue(cs, w, ids, &stats, v, width)
+	}
+	if limit != nil {
+		limit.writeNotice(cs, cw)
+	}
+	if lw != nil {
+		writeLineCount(cs, cw, lw)
+	}
+	stats.Bytes = cw.n
+	writeSummary(cs, cw, &stats)
+	stats.Bytes = cw.n
+	flushSink(out)
+	flushSink(cs.AlsoWrite)
+	return stats
+}
 
-		d := dumpState{w: w, cs: cs}
-		d.pointers = make(map[uintptr]int)
-		d.dump(reflect.ValueOf(arg))
-		d.w.Write(newlineBytes)
+// dumpValue writes the text output for a single argument passed to Dump,
+// which is written as a nil interface when v is the zero Value.  Long strings
+// are wrapped to width unless it is 0.  The values displayed are recorded in
+// stats unless it is nil.
+func dumpValue(cs *ConfigState, w io.Writer, ids *pointerIDs, stats *DumpStats, v reflect.Value, width int) {
+	if !v.IsValid() {
+		w.Write(interfaceBytes)
+		w.Write(spaceBytes)
+		w.Write(nilSymbol(cs))
+		w.Write(newlineBytes)
+		return
+	}
+
+	d := dumpState{w: w, cs: cs, ids: ids, stats: stats, maxDepth: cs.rootMaxDepth(),
+		filter: newFieldFilter(cs), redactor: newRedactor(cs)}
+	if width > 0 {
+		d.width = width
+		d.col = &columnWriter{w: w}
+		d.w = d.col
+	}
+	if cs.ShowSizes {
+		d.sizes = newSizer(cs)
+	}
+	d.pointers = make(map[uintptr]int)
+	d.visited = make(map[visitKey]bool)
+	if cs.SharedRefs {
+		d.shown = make(map[visitKey]bool)
+	}
+	d.dump(v)
+	d.w.Write(newlineBytes)
+	if cs.ShowMethods {
+		writeMethods(d.w, cs, v.Type())
 	}
 }
 
 func withParens(d *dumpState, contentFunc func(d *dumpState)) {
-	d.w.Write(openParenBytes)
+	d.punct(openParenBytes)
 	contentFunc(d)
-	d.w.Write(closeParenBytes)
+	d.punct(closeParenBytes)
+}
+
+// writePunct writes the structural punctuation b to writer, dimmed when the
+// DimPunctuation option is set.  Trailing whitespace such as the newline
+// following an opening brace is written outside of the color sequence.
+func writePunct(writer io.Writer, cs *ConfigState, b []byte) {
+	if !cs.DimPunctuation {
+		writer.Write(b)
+		return
+	}
+	trimmed := bytes.TrimRight(b, " \n")
+	withColor(writer, trimmed, color.Faint)
+	writer.Write(b[len(trimmed):])
 }
 
 func withColor(writer io.Writer, content []byte, colors ...color.Attribute) {
@@ -490,7 +1107,8 @@ func withColor(writer io.Writer, content []byte, colors ...color.Attribute) {
 }
 
 func printFloat(writer io.Writer, cs *ConfigState, num float64, precision int) {
-	withColor(writer, []byte(strconv.FormatFloat(num, 'g', -1, precision)))
+	format, prec := cs.floatVerb()
+	withColor(writer, []byte(strconv.FormatFloat(num, format, prec, precision)))
 }
 
 func printNumber[T number](writer io.Writer, cs *ConfigState, num T) {
@@ -498,28 +1116,32 @@ func printNumber[T number](writer io.Writer, cs *ConfigState, num T) {
 }
 
 func printBool(writer io.Writer, cs *ConfigState, val bool) {
-	withColor(writer, []byte(fmt.Sprintf("%t", val)), cs.Color.Bool...)
+	withColor(writer, boolSymbol(cs, val), cs.Color.Bool...)
 }
 
 func printType(writer io.Writer, cs *ConfigState, val string) {
-	withColor(writer, []byte(val), cs.Color.Type...)
+	withColor(writer, []byte(cs.typeName(val)), cs.Color.Type...)
 }
 
 func printString(writer io.Writer, cs *ConfigState, val string) {
 	withColor(writer, []byte(val), cs.Color.String...)
 }
 
+func printRedacted(writer io.Writer, cs *ConfigState, val string) {
+	withColor(writer, []byte(val), cs.Color.Redacted...)
+}
+
 // Fdump formats and displays the passed arguments to io.Writer w.  It formats
 // exactly the same as Dump.
 func Fdump(w io.Writer, a ...interface{}) {
-	fdump(&Config, w, a...)
+	fdump(global(), w, a...)
 }
 
 // Sdump returns a string with the passed arguments formatted exactly the same
 // as Dump.
 func Sdump(a ...interface{}) string {
 	var buf bytes.Buffer
-	fdump(&Config, &buf, a...)
+	fdump(global(), &buf, a...)
 	return buf.String()
 }
 
@@ -547,5 +1169,5 @@ See Fdump if you would prefer dumping to an arbitrary io.Writer or Sdump to
 get the formatted result as a string.
 */
 func Dump(a ...interface{}) {
-	fdump(&Config, os.Stdout, a...)
+	fdump(global(), os.Stdout, a...)
 }
@@ -64,16 +64,28 @@ package spew_test
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"unsafe"
 
 	spew "github.com/ehowe/rainbow-spew"
+	"github.com/fatih/color"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/samber/lo"
 )
 
+// fmtCrafted is a type with a custom fmt.Formatter implementation used to test
+// the FmtFallbackTypes option.
+type fmtCrafted struct {
+	x int
+}
+
+func (f fmtCrafted) Format(s fmt.State, verb rune) {
+	fmt.Fprintf(s, "crafted<%d %c %v>", f.x, verb, s.Flag('+'))
+}
+
 // dumpTest is used to describe a test to be performed against the Dump method.
 type dumpTest struct {
 	in    interface{}
@@ -1038,4 +1050,127 @@ var _ = Describe("Dump Tests", func() {
 
 	})
 
+	It("dims punctuation", func() {
+		noColor := color.NoColor
+		color.NoColor = false
+		defer func() { color.NoColor = noColor }()
+
+		cfg := spew.ConfigState{DimPunctuation: true}
+		s := cfg.Sdump(struct{ A []int }{[]int{1}})
+		dim := color.New(color.Faint).Sprint
+		expected := dim("(") + "struct { A []int }" + dim(")") + " " + dim("{") + "\n" +
+			"A" + dim(":") + " " + dim("(") + "[]int" + dim(")") + " " + dim("(") +
+			"len: 1 cap: 1" + dim(")") + " " + dim("{") + "\n" +
+			dim("(") + "int" + dim(")") + " 1\n" +
+			dim("}") + "\n" + dim("}") + "\n"
+		Expect(s).To(Equal(expected))
+
+		f := spew.ConfigState{DimPunctuation: true}
+		Expect(f.Sprintf("%+v", struct{ A []int }{[]int{1}})).To(Equal(dim("{") + "A" + dim(":") + dim("[") + "1" + dim("]") + dim("}")))
+	})
+
+	It("detects circular maps and slices without pointers", func() {
+		m := map[string]interface{}{}
+		m["self"] = m
+		s := spew.Sdump(m)
+		expected := "(map[string]interface {}) (len: 1) {\n" +
+			"  (string) (len: 4) \"self\": (map[string]interface {}) (len: 1) <already shown>\n" +
+			"}\n"
+		Expect(s).To(Equal(expected))
+
+		sl := []interface{}{nil}
+		sl[0] = sl
+		s = spew.Sdump(sl)
+		expected = "([]interface {}) (len: 1 cap: 1) {\n" +
+			"  ([]interface {}) (len: 1 cap: 1) <already shown>\n" +
+			"}\n"
+		Expect(s).To(Equal(expected))
+	})
+
+	It("decorates sections with glyphs", func() {
+		v := 1
+		in := struct {
+			M map[string]*int
+		}{map[string]*int{"a": &v}}
+		vAddr := fmt.Sprintf("%p", &v)
+
+		cfg := spew.ConfigState{Glyphs: spew.GlyphsASCII, DisablePointerAddresses: true}
+		expected := "{.} (struct { M map[string]*int }) {\n" +
+			"M: {} (map[string]*int) (len: 1) {\n" +
+			"(string) (len: 1) \"a\": -> (*int)(1)\n" +
+			"}\n" +
+			"}\n"
+		Expect(cfg.Sdump(in)).To(Equal(expected))
+
+		cfg = spew.ConfigState{Glyphs: spew.GlyphsNerdFont}
+		Expect(cfg.Sdump(&v)).To(Equal(" (*int)(" + vAddr + ")(1)\n"))
+	})
+
+	It("groups heterogeneous elements by type", func() {
+		cfg := spew.ConfigState{GroupByType: true, SortKeys: true}
+		s := cfg.Sdump([]interface{}{1, "a", 2, nil})
+		expected := "([]interface {}) (len: 4 cap: 4) {\n" +
+			"-- int (2) --\n" +
+			"(int) 1,\n" +
+			"(int) 2,\n" +
+			"-- string (1) --\n" +
+			"(string) (len: 1) \"a\",\n" +
+			"-- <nil> (1) --\n" +
+			"(interface {}) <nil>\n" +
+			"}\n"
+		Expect(s).To(Equal(expected))
+
+		s = cfg.Sdump(map[string]interface{}{"a": true, "b": 1.5, "c": false})
+		expected = "(map[string]interface {}) (len: 3) {\n" +
+			"-- bool (2) --\n" +
+			"(string) (len: 1) \"a\": (bool) true,\n" +
+			"(string) (len: 1) \"c\": (bool) false,\n" +
+			"-- float64 (1) --\n" +
+			"(string) (len: 1) \"b\": (float64) 1.5\n" +
+			"}\n"
+		Expect(s).To(Equal(expected))
+	})
+
+	It("renders FmtFallbackTypes with fmt", func() {
+		cs := spew.NewTestConfig()
+		cs.FmtFallbackTypes = []reflect.Type{reflect.TypeOf(fmtCrafted{})}
+		Expect(cs.Sdump(fmtCrafted{x: 1})).To(Equal("(spew_test.fmtCrafted) crafted<1 v true>\n"))
+		Expect(cs.Sdump([]fmtCrafted{{x: 2}})).To(Equal("([]spew_test.fmtCrafted) (len: 1 cap: 1) {\n  (spew_test.fmtCrafted) crafted<2 v true>\n}\n"))
+		Expect(spew.NewTestConfig().Sdump(fmtCrafted{x: 1})).To(Equal("(spew_test.fmtCrafted) {\n  x: (int) 1\n}\n"))
+	})
+
+	It("dumps each argument on a single line in compact mode", func() {
+		cs := spew.NewTestConfig()
+		cs.Compact = true
+		v := struct {
+			A []int
+			B map[string]int
+			C []byte
+		}{A: []int{1, 2}, B: map[string]int{"x": 1}, C: []byte{1, 2}}
+		Expect(cs.Sdump(v, 1)).To(Equal(`(struct { A []int; B map[string]int; C []uint8 }) { A: ([]int) (len: 2 cap: 2) { (int) 1, (int) 2 }, B: (map[string]int) (len: 1) { (string) (len: 1) "x": (int) 1 }, C: ([]uint8) (len: 2 cap: 2) { 01 02 } }` + "\n(int) 1\n"))
+	})
+
+	It("highlights SQL keywords within strings", func() {
+		color.NoColor = false
+		defer func() { color.NoColor = true }()
+
+		cs := spew.NewTestConfig()
+		cs.HighlightSQL = true
+		cs.Color.Keyword = []color.Attribute{color.FgBlue}
+		kw := color.New(color.FgBlue).Sprint
+		v := struct {
+			Query string
+			Note  string
+			Raw   string
+		}{
+			Query: "select id from t where name = 'from'\n",
+			Note:  "select the best option",
+			Raw:   "DELETE FROM t",
+		}
+		Expect(cs.Sdump(v)).To(Equal("(struct { Query string; Note string; Raw string }) {\n" +
+			"  Query: (string) (len: 37) \"" + kw("select") + " id " + kw("from") + " t " + kw("where") + " name = 'from'\\n\",\n" +
+			"  Note: (string) (len: 22) \"select the best option\",\n" +
+			"  Raw: (string) (len: 13) \"" + kw("DELETE") + " " + kw("FROM") + " t\"\n" +
+			"}\n"))
+	})
 })
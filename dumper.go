@@ -0,0 +1,110 @@
+package spew
+
+import (
+	"reflect"
+
+	"github.com/fatih/color"
+)
+
+// SpewDumper is implemented by types which control their own rendering in
+// Dump output, analogous to fmt.Formatter for the fmt package.  DumpSpew is
+// called in place of the usual rendering of the value after its type has been
+// displayed, and writes the value through d, which gives access to the state
+// of the dump.  It is subject to the same rules as the error and Stringer
+// interfaces, so it is not called when the DisableMethods option is set and
+// the DisablePointerMethods option applies to methods with a pointer
+// receiver.  Methods with a pointer receiver are only called for values
+// which aren't addressable, such as the fields of structs passed by value,
+// when the unsafe package is available, since it is what makes them
+// addressable.
+type SpewDumper interface {
+	DumpSpew(d *Dumper)
+}
+
+// Dumper gives a SpewDumper access to the state of the dump it is part of.
+// It must not be used once DumpSpew returns.
+type Dumper struct {
+	d *dumpState
+}
+
+// Write writes p to the output of the dump as is.
+func (d *Dumper) Write(p []byte) (int, error) {
+	return d.d.w.Write(p)
+}
+
+// WriteColor writes s to the output of the dump with the color attributes,
+// which are typically taken from the Color option of Config so the colors
+// are disabled along with those of the rest of the dump.
+func (d *Dumper) WriteColor(s string, attrs ...color.Attribute) {
+	withColor(d.d.w, []byte(s), attrs...)
+}
+
+// Config returns the configuration of the dump.  Its colors are cleared when
+// the DisableDumpColor option is set.
+func (d *Dumper) Config() *ConfigState {
+	return d.d.cs
+}
+
+// Depth returns the current level of nesting, which is the number of levels
+// the value being dumped is nested within.
+func (d *Dumper) Depth() int {
+	return d.d.depth
+}
+
+// Indent returns the indentation of the lines at the current depth, which is
+// empty when the Compact option is set.
+func (d *Dumper) Indent() string {
+	if d.d.cs.Compact {
+		return ""
+	}
+	return d.d.indentation(d.d.depth)
+}
+
+// Nested calls fn with the depth increased by one level, for writing the
+// lines of a block such as the fields of a struct.
+func (d *Dumper) Nested(fn func()) {
+	d.d.depth++
+	defer func() { d.d.depth-- }()
+	fn()
+}
+
+// Dump writes v as Dump does at the current position and depth, such as for
+// the value of a field.  The first line is not indented.
+func (d *Dumper) Dump(v interface{}) {
+	d.d.ignoreNextIndent = true
+	if v == nil {
+		d.d.indent()
+		d.d.w.Write(interfaceBytes)
+		d.d.w.Write(spaceBytes)
+		d.d.w.Write(nilSymbol(d.d.cs))
+		return
+	}
+	d.d.dump(reflect.ValueOf(v))
+}
+
+// handleSpewDumper calls the DumpSpew method of v, or of a pointer to v when
+// pointer methods are allowed, and returns whether v implements SpewDumper.
+// Panics in DumpSpew are written by catchPanic in place of the rest of its
+// output.
+func (d *dumpState) handleSpewDumper(v reflect.Value) (handled bool) {
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return false
+		}
+		v = unsafeReflectValue(v)
+	}
+	if !d.cs.DisablePointerMethods && !UnsafeDisabled && !v.CanAddr() {
+		v = unsafeReflectValue(v)
+	}
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	sd, ok := v.Interface().(SpewDumper)
+	if !ok {
+		return false
+	}
+	handled = true
+	defer catchPanic(d.cs, d.w, v, "DumpSpew()")
+	sd.DumpSpew(&Dumper{d: d})
+	return handled
+}
@@ -0,0 +1,56 @@
+package spew_test
+
+import (
+	"io"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type dumperMatrix struct {
+	Rows [][]int
+}
+
+func (m *dumperMatrix) DumpSpew(d *spew.Dumper) {
+	io.WriteString(d, "{\n")
+	d.Nested(func() {
+		for _, row := range m.Rows {
+			io.WriteString(d, d.Indent())
+			d.Dump(row[0])
+			io.WriteString(d, " ...\n")
+		}
+	})
+	io.WriteString(d, d.Indent()+"}")
+}
+
+type dumperPanics struct{}
+
+func (dumperPanics) DumpSpew(d *spew.Dumper) {
+	panic("boom")
+}
+
+var _ = Describe("Dumper Tests", func() {
+	It("lets types control their own Dump output", func() {
+		if spew.UnsafeDisabled {
+			Skip("calling pointer methods of values which aren't addressable requires the unsafe package")
+		}
+		v := struct{ M dumperMatrix }{dumperMatrix{[][]int{{1, 2}, {3, 4}}}}
+		Expect(spew.NewTestConfig().Sdump(v)).To(Equal("(struct { M spew_test.dumperMatrix }) {\n" +
+			"  M: (spew_test.dumperMatrix) {\n" +
+			"    (int) 1 ...\n" +
+			"    (int) 3 ...\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("is not used when methods are disabled", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		Expect(cs.Sdump(dumperPanics{})).To(Equal("(spew_test.dumperPanics) {\n}\n"))
+	})
+
+	It("catches panics", func() {
+		Expect(spew.NewTestConfig().Sdump(dumperPanics{})).To(Equal("(spew_test.dumperPanics) (PANIC: boom)\n"))
+	})
+})
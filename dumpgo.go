@@ -0,0 +1,457 @@
+package spew
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Types whose values Go infers for untyped constants.  Constants of these
+// types do not need a conversion when their container does not declare a
+// concrete type for them.
+var (
+	goBoolType       = reflect.TypeOf(false)
+	goIntType        = reflect.TypeOf(0)
+	goFloat64Type    = reflect.TypeOf(0.0)
+	goComplex128Type = reflect.TypeOf(0i)
+	goStringType     = reflect.TypeOf("")
+)
+
+// goRootVar is the name of the variable the root value is assigned to when
+// circular references need to be restored after it has been constructed.
+const goRootVar = "v"
+
+// goPath is a Go expression which refers to a value relative to the root
+// variable.  An empty expr means the value cannot be referred to.
+type goPath struct {
+	expr string
+
+	// addressable is set when the expression is addressable, which means
+	// its fields and elements may be assigned to.
+	addressable bool
+
+	// assignable is set when the expression may be assigned to.
+	assignable bool
+}
+
+// field returns the path of the struct field name of the value at p.
+func (p goPath) field(name string) goPath {
+	if p.expr == "" {
+		return goPath{}
+	}
+	expr := p.expr
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		// Fields are selected through pointers automatically.
+		expr = expr[2 : len(expr)-1]
+	}
+	return goPath{expr: expr + "." + name, addressable: p.addressable,
+		assignable: p.addressable}
+}
+
+// index returns the path of element i of the array or slice at p.
+func (p goPath) index(i int, slice bool) goPath {
+	if p.expr == "" {
+		return goPath{}
+	}
+	addressable := slice || p.addressable
+	return goPath{expr: p.expr + "[" + strconv.Itoa(i) + "]",
+		addressable: addressable, assignable: addressable}
+}
+
+// mapIndex returns the path of the value for key in the map at p.  Map
+// values may be assigned to but are not addressable.
+func (p goPath) mapIndex(key string) goPath {
+	if p.expr == "" || strings.Contains(key, "\n") {
+		return goPath{}
+	}
+	return goPath{expr: p.expr + "[" + key + "]", assignable: true}
+}
+
+// deref returns the path of the value pointed to by the pointer at p.
+func (p goPath) deref() goPath {
+	if p.expr == "" {
+		return goPath{}
+	}
+	return goPath{expr: "(*" + p.expr + ")", addressable: true, assignable: true}
+}
+
+// assert returns the path of the dynamic value of the type named typ held by
+// the interface at p.
+func (p goPath) assert(typ string) goPath {
+	if p.expr == "" {
+		return goPath{}
+	}
+	return goPath{expr: p.expr + ".(" + typ + ")"}
+}
+
+// goState contains information about the state of a Go source dump
+// operation.
+type goState struct {
+	buf    *bytes.Buffer
+	depth  int
+	active map[visitKey]string
+	fixups []string
+
+	// names qualifies the names of types for the file the source is
+	// written to, or is nil when types are named as reflect does.
+	names *goNames
+}
+
+// newGoState returns a goState for writing the source of a root value.
+func newGoState(names *goNames) *goState {
+	return &goState{
+		buf:    new(bytes.Buffer),
+		depth:  1,
+		active: make(map[visitKey]string),
+		names:  names,
+	}
+}
+
+// typeName returns the name of typ as written in the source.
+func (g *goState) typeName(typ reflect.Type) string {
+	if g.names == nil {
+		return typ.String()
+	}
+	return g.names.qualify(typ)
+}
+
+// indent writes the indentation for the current depth.
+func (g *goState) indent() {
+	g.buf.WriteString(strings.Repeat("\t", g.depth))
+}
+
+// writeTyped writes the constant expression lit of type typ, converting it to
+// typ unless the container declares typ or Go infers it.
+func (g *goState) writeTyped(lit string, typ, static, inferred reflect.Type) {
+	if typ == static || typ == inferred {
+		g.buf.WriteString(lit)
+		return
+	}
+	g.buf.WriteString(g.typeName(typ))
+	g.buf.WriteByte('(')
+	g.buf.WriteString(lit)
+	g.buf.WriteByte(')')
+}
+
+// writeNil writes a nil value of type typ.
+func (g *goState) writeNil(typ, static reflect.Type) {
+	if typ == static {
+		g.buf.WriteString("nil")
+		return
+	}
+	g.buf.WriteString("(" + g.typeName(typ) + ")(nil)")
+}
+
+// writeCycle writes a placeholder for a circular reference at p back to the
+// value at target and records the assignment which restores it once the root
+// value has been constructed.
+func (g *goState) writeCycle(p goPath, target string, typ, static reflect.Type) {
+	g.writeNil(typ, static)
+	if !p.assignable || p.expr == "" || target == "" {
+		g.buf.WriteString(" /* circular */")
+		return
+	}
+	g.fixups = append(g.fixups, p.expr+" = "+target)
+}
+
+// goFloat returns the Go expression for the float f with the passed size in
+// bits along with whether it is a constant.
+func goFloat(f float64, bits int) (string, bool) {
+	switch {
+	case math.IsInf(f, 1):
+		return "math.Inf(1)", false
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)", false
+	case math.IsNaN(f):
+		return "math.NaN()", false
+	}
+	s := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s, true
+}
+
+// emit writes the Go expression for v, which is located at p and declared as
+// static by its container.  static is nil when no type is declared.
+func (g *goState) emit(v reflect.Value, static reflect.Type, p goPath) {
+	// Circular references held by interfaces are restored by assigning to
+	// the interface itself.
+	slot := p
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			g.writeNil(v.Type(), static)
+			return
+		}
+		p = p.assert(g.typeName(v.Elem().Type()))
+		v = v.Elem()
+	}
+
+	typ := v.Type()
+	switch v.Kind() {
+	case reflect.Bool:
+		g.writeTyped(strconv.FormatBool(v.Bool()), typ, static, goBoolType)
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		g.writeTyped(strconv.FormatInt(v.Int(), 10), typ, static, goIntType)
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		g.writeTyped(strconv.FormatUint(v.Uint(), 10), typ, static, nil)
+
+	case reflect.Uintptr:
+		g.writeTyped("0x"+strconv.FormatUint(v.Uint(), 16), typ, static, nil)
+
+	case reflect.Float32, reflect.Float64:
+		lit, constant := goFloat(v.Float(), typ.Bits())
+		if !constant {
+			// math functions return float64 rather than untyped constants.
+			static = goFloat64Type
+			g.names.use("math")
+		}
+		g.writeTyped(lit, typ, static, goFloat64Type)
+
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		r, rc := goFloat(real(c), typ.Bits()/2)
+		i, ic := goFloat(imag(c), typ.Bits()/2)
+		if !rc || !ic {
+			static = goComplex128Type
+			g.names.use("math")
+		}
+		g.writeTyped("complex("+r+", "+i+")", typ, static, goComplex128Type)
+
+	case reflect.String:
+		g.writeTyped(strconv.Quote(v.String()), typ, static, goStringType)
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		// There is no way to reconstruct these in source.
+		g.writeNil(typ, static)
+		if !v.IsNil() {
+			g.buf.WriteString(" /* non-nil */")
+		}
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			g.writeNil(typ, static)
+			return
+		}
+		key := visitKey{typ: typ, addr: v.Pointer()}
+		if target, ok := g.active[key]; ok {
+			g.writeCycle(slot, target, typ, static)
+			return
+		}
+		g.active[key] = p.expr
+		defer delete(g.active, key)
+
+		elem := v.Elem()
+		switch elem.Kind() {
+		case reflect.Array, reflect.Struct:
+			g.buf.WriteByte('&')
+			g.emit(elem, elem.Type(), p.deref())
+			return
+		case reflect.Slice, reflect.Map:
+			if !elem.IsNil() {
+				g.buf.WriteByte('&')
+				g.emit(elem, elem.Type(), p.deref())
+				return
+			}
+		}
+
+		// Values which are not composite literals can't have their address
+		// taken directly, so they are wrapped in a function.
+		et := g.typeName(elem.Type())
+		g.buf.WriteString("func() *" + et + " { var v " + et + " = ")
+		g.emit(elem, elem.Type(), p.deref())
+		g.buf.WriteString("; return &v }()")
+
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			g.writeNil(typ, static)
+			return
+		}
+		key := newVisitKey(v)
+		if target, ok := g.active[key]; ok {
+			g.writeCycle(slot, target, typ, static)
+			return
+		}
+		g.active[key] = p.expr
+		defer delete(g.active, key)
+
+		if v.Kind() == reflect.Map {
+			g.emitMap(v, p)
+		} else {
+			g.emitList(v, p)
+		}
+
+	case reflect.Array:
+		g.emitList(v, p)
+
+	case reflect.Struct:
+		g.buf.WriteString(g.typeName(typ))
+		g.buf.WriteByte('{')
+		wrote := false
+		for i := 0; i < v.NumField(); i++ {
+			fv := v.Field(i)
+			if fv.IsZero() {
+				continue
+			}
+			sf := typ.Field(i)
+			g.names.field(typ, sf)
+			if !wrote {
+				g.buf.WriteByte('\n')
+				g.depth++
+				wrote = true
+			}
+			g.indent()
+			g.buf.WriteString(sf.Name)
+			g.buf.WriteString(": ")
+			g.emit(fv, sf.Type, p.field(sf.Name))
+			g.buf.WriteString(",\n")
+		}
+		if wrote {
+			g.depth--
+			g.indent()
+		}
+		g.buf.WriteByte('}')
+	}
+}
+
+// emitList writes the composite literal for the array or slice v located at
+// p.  Scalar elements are written on a single line.
+func (g *goState) emitList(v reflect.Value, p goPath) {
+	typ := v.Type()
+	slice := v.Kind() == reflect.Slice
+	g.buf.WriteString(g.typeName(typ))
+	g.buf.WriteByte('{')
+	if v.Len() == 0 {
+		g.buf.WriteByte('}')
+		return
+	}
+
+	ek := typ.Elem().Kind()
+	if isScalarKind(ek) || ek == reflect.String {
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				g.buf.WriteString(", ")
+			}
+			g.emit(v.Index(i), typ.Elem(), p.index(i, slice))
+		}
+		g.buf.WriteByte('}')
+		return
+	}
+
+	g.buf.WriteByte('\n')
+	g.depth++
+	for i := 0; i < v.Len(); i++ {
+		g.indent()
+		g.emit(v.Index(i), typ.Elem(), p.index(i, slice))
+		g.buf.WriteString(",\n")
+	}
+	g.depth--
+	g.indent()
+	g.buf.WriteByte('}')
+}
+
+// emitMap writes the composite literal for the map v located at p.  Entries
+// are ordered by the source of their keys so the output is reproducible.
+func (g *goState) emitMap(v reflect.Value, p goPath) {
+	typ := v.Type()
+	g.buf.WriteString(g.typeName(typ))
+	g.buf.WriteByte('{')
+	if v.Len() == 0 {
+		g.buf.WriteByte('}')
+		return
+	}
+
+	g.depth++
+	type entry struct {
+		key string
+		val reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	buf := g.buf
+	for _, k := range v.MapKeys() {
+		g.buf = new(bytes.Buffer)
+		g.emit(k, typ.Key(), goPath{})
+		entries = append(entries, entry{key: g.buf.String(), val: v.MapIndex(k)})
+	}
+	g.buf = buf
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	g.buf.WriteByte('\n')
+	for _, e := range entries {
+		g.indent()
+		g.buf.WriteString(e.key)
+		g.buf.WriteString(": ")
+		g.emit(e.val, typ.Elem(), p.mapIndex(e.key))
+		g.buf.WriteString(",\n")
+	}
+	g.depth--
+	g.indent()
+	g.buf.WriteByte('}')
+}
+
+/*
+SdumpGo returns Go source code for an expression which reconstructs v.  This
+is useful for turning values captured at runtime into test fixtures.  For
+example:
+
+	spew.SdumpGo(&T{Name: "a", Tags: []string{"x"}})
+
+returns:
+
+	&main.T{
+		Name: "a",
+		Tags: []string{"x"},
+	}
+
+Struct fields holding zero values are omitted, map entries are ordered by the
+source of their keys, and types are qualified with the name of their package.
+Circular references are restored by assigning to the root value after it has
+been constructed, so the expression is wrapped in a function when they occur:
+
+	func() *main.Node {
+		v := &main.Node{
+			Name: "a",
+			Next: nil,
+		}
+		v.Next = v
+		return v
+	}()
+
+Values which can't be written in source, such as non-nil channels and
+functions, are written as nil followed by a comment.  Values reachable through
+several pointers which do not form a cycle are written once for each pointer.
+*/
+func SdumpGo(v interface{}) string {
+	return newGoState(nil).source(v)
+}
+
+// source returns the Go expression which reconstructs v.
+func (g *goState) source(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+	rv := reflect.ValueOf(v)
+	g.emit(rv, nil, goPath{expr: goRootVar, addressable: true, assignable: true})
+	if len(g.fixups) == 0 {
+		// The source was indented for the function body which is not needed.
+		return strings.ReplaceAll(g.buf.String(), "\n\t", "\n")
+	}
+
+	typ := g.typeName(rv.Type())
+	var buf bytes.Buffer
+	buf.WriteString("func() " + typ + " {\n\t" + goRootVar + " := ")
+	buf.Write(g.buf.Bytes())
+	buf.WriteByte('\n')
+	for _, fixup := range g.fixups {
+		buf.WriteString("\t" + fixup + "\n")
+	}
+	buf.WriteString("\treturn " + goRootVar + "\n}()")
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+package spew_test
+
+import (
+	"math"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type goNode struct {
+	Name  string
+	Next  *goNode
+	Attrs map[string]interface{}
+	score float32
+}
+
+var _ = Describe("DumpGo Tests", func() {
+	It("writes composite literals", func() {
+		v := &goNode{Name: "a", Attrs: map[string]interface{}{"z": []int{1, 2}, "a": uint8(3)}, score: 1}
+		Expect(spew.SdumpGo(v)).To(Equal(`&spew_test.goNode{
+	Name: "a",
+	Attrs: map[string]interface {}{
+		"a": uint8(3),
+		"z": []int{1, 2},
+	},
+	score: 1.0,
+}`))
+	})
+
+	It("writes scalars with conversions where required", func() {
+		Expect(spew.SdumpGo(nil)).To(Equal("nil"))
+		Expect(spew.SdumpGo(5)).To(Equal("5"))
+		Expect(spew.SdumpGo(int8(5))).To(Equal("int8(5)"))
+		Expect(spew.SdumpGo(2.0)).To(Equal("2.0"))
+		Expect(spew.SdumpGo(float32(math.Inf(1)))).To(Equal("float32(math.Inf(1))"))
+		Expect(spew.SdumpGo(complex64(1 + 2i))).To(Equal("complex64(complex(1.0, 2.0))"))
+		Expect(spew.SdumpGo([]error{nil})).To(Equal("[]error{\n\tnil,\n}"))
+		Expect(spew.SdumpGo((*int)(nil))).To(Equal("(*int)(nil)"))
+
+		x := 5
+		Expect(spew.SdumpGo(&x)).To(Equal("func() *int { var v int = 5; return &v }()"))
+	})
+
+	It("restores circular references", func() {
+		n := &goNode{Name: "a"}
+		n.Next = &goNode{Name: "b", Next: n}
+		Expect(spew.SdumpGo(n)).To(Equal(`func() *spew_test.goNode {
+	v := &spew_test.goNode{
+		Name: "a",
+		Next: &spew_test.goNode{
+			Name: "b",
+			Next: nil,
+		},
+	}
+	v.Next.Next = v
+	return v
+}()`))
+
+		m := map[string]interface{}{}
+		m["self"] = m
+		Expect(spew.SdumpGo(m)).To(Equal(`func() map[string]interface {} {
+	v := map[string]interface {}{
+		"self": (map[string]interface {})(nil),
+	}
+	v["self"] = v
+	return v
+}()`))
+	})
+})
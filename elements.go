@@ -0,0 +1,88 @@
+package spew
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// elementWindow describes the elements of an array, slice or map which are
+// displayed, which are the first head and the last tail of its n elements.
+type elementWindow struct {
+	head, tail, n int
+}
+
+// limitElements returns the window of the n elements of an array, slice or
+// map which are displayed according to the MaxElements and TailElements
+// options.
+func (c *ConfigState) limitElements(n int) elementWindow {
+	tail := max(c.TailElements, 0)
+	if c.MaxElements <= 0 || n <= c.MaxElements+tail {
+		return elementWindow{head: n, n: n}
+	}
+	return elementWindow{head: c.MaxElements, tail: tail, n: n}
+}
+
+// len returns the number of elements displayed.
+func (w elementWindow) len() int {
+	return w.head + w.tail
+}
+
+// omitted returns the number of elements omitted between the head and tail.
+func (w elementWindow) omitted() int {
+	return w.n - w.head - w.tail
+}
+
+// index returns the index of the element displayed at position j.
+func (w elementWindow) index(j int) int {
+	if j < w.head {
+		return j
+	}
+	return w.n - w.tail + j - w.head
+}
+
+// end returns the index which ends the consecutive run of displayed elements
+// which the element displayed at position j belongs to.
+func (w elementWindow) end(j int) int {
+	if j < w.head {
+		return w.head
+	}
+	return w.n
+}
+
+// keys returns the map keys displayed out of the n keys.
+func (w elementWindow) keys(keys []reflect.Value) []reflect.Value {
+	if w.tail == 0 {
+		return keys[:w.head]
+	}
+	return append(keys[:w.head:w.head], keys[w.n-w.tail:]...)
+}
+
+// writeMore writes the marker for the number of elements of a collection
+// omitted due to the MaxElements option to Writer w.
+func writeMore(w io.Writer, cs *ConfigState, omitted int) {
+	withColor(w, []byte("... (+"+strconv.Itoa(omitted)+" more)"), cs.Color.Length...)
+}
+
+// moreLine writes the line marking the elements of a collection omitted due to
+// the MaxElements option.  Nothing is written when no elements were omitted.
+func (d *dumpState) moreLine(omitted int) {
+	if omitted == 0 {
+		return
+	}
+	d.countTruncation()
+	d.indent()
+	writeMore(d.w, d.cs, omitted)
+	d.line(newlineBytes)
+}
+
+// more writes the marker for the elements of a collection omitted due to the
+// MaxElements option, separated from the preceding elements by a space.
+// Nothing is written when no elements were omitted.
+func (f *formatState) more(omitted int) {
+	if omitted == 0 {
+		return
+	}
+	f.fs.Write(spaceBytes)
+	writeMore(f.fs, f.cs, omitted)
+}
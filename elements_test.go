@@ -0,0 +1,72 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MaxElements Tests", func() {
+	It("limits the elements of slices in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxElements = 2
+		cs.DisableCapacities = true
+		Expect(cs.Sdump([]int{1, 2, 3, 4, 5}, []int{1, 2})).To(Equal("([]int) (len: 5) {\n" +
+			"  (int) 1,\n" +
+			"  (int) 2\n" +
+			"  ... (+3 more)\n" +
+			"}\n" +
+			"([]int) (len: 2) {\n" +
+			"  (int) 1,\n" +
+			"  (int) 2\n" +
+			"}\n"))
+	})
+
+	It("limits the entries of maps after sorting", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxElements = 1
+		cs.SortKeys = true
+		Expect(cs.Sdump(map[string]int{"b": 2, "a": 1, "c": 3})).To(Equal("(map[string]int) (len: 3) {\n" +
+			"  (string) (len: 1) \"a\": (int) 1\n" +
+			"  ... (+2 more)\n" +
+			"}\n"))
+	})
+
+	It("limits hexdumps of byte slices", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxElements = 4
+		cs.DisableCapacities = true
+		Expect(cs.Sdump([]byte("abcdefgh"))).To(Equal("([]uint8) (len: 8) {\n" +
+			"  00000000  61 62 63 64                                       |abcd|\n" +
+			"  ... (+4 more)\n" +
+			"}\n"))
+	})
+
+	It("limits the elements of collections in Formatter output", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxElements = 2
+		cs.SortKeys = true
+		Expect(fmt.Sprintf("%v %v", cs.NewFormatter([]int{1, 2, 3}), cs.NewFormatter(map[int]int{1: 1, 2: 2, 3: 3}))).To(
+			Equal("[1 2 ... (+1 more)] map[1:1 2:2 ... (+1 more)]"))
+	})
+
+	It("displays the last elements after the marker when requested", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxElements = 2
+		cs.TailElements = 1
+		cs.DisableCapacities = true
+		Expect(cs.Sdump([]int{1, 2, 3, 4, 5})).To(Equal("([]int) (len: 5) {\n" +
+			"  (int) 1,\n" +
+			"  (int) 2,\n" +
+			"  ... (+2 more)\n" +
+			"  (int) 5\n" +
+			"}\n"))
+
+		cs.SortKeys = true
+		Expect(fmt.Sprintf("%v %v", cs.NewFormatter([]int{1, 2, 3, 4}), cs.NewFormatter(map[int]int{1: 1, 2: 2, 3: 3, 4: 4}))).To(
+			Equal("[1 2 ... (+1 more) 4] map[1:1 2:2 ... (+1 more) 4:4]"))
+		Expect(fmt.Sprintf("%v", cs.NewFormatter([]int{1, 2, 3}))).To(Equal("[1 2 3]"))
+	})
+})
@@ -0,0 +1,108 @@
+package spew
+
+import "reflect"
+
+// shownField describes a field displayed by Dump or a Formatter.  Fields
+// promoted from embedded structs by the FlattenEmbedded option are named by
+// the field itself unless that name is shared by another displayed field, in
+// which case it is qualified by the names of the embedded fields holding it,
+// as in Base.ID.  The path is always that of the field in the nested structs,
+// and the owner is the type of the struct directly holding the field.
+type shownField struct {
+	name  string
+	path  string
+	owner reflect.Type
+	field reflect.StructField
+	value reflect.Value
+}
+
+// shownFields returns the fields of the struct v at path which are displayed
+// according to visibleFields, with the fields of embedded structs promoted to
+// v when FlattenEmbedded is set, along with the number of fields omitted for
+// being nil.  Embedded structs which are redacted by rd or RedactFunc are not
+// flattened so they stay hidden.
+func (c *ConfigState) shownFields(v reflect.Value, ff *fieldFilter, rd *redactor, path string) ([]shownField, int) {
+	var ptrs []uintptr
+	if v.CanAddr() {
+		ptrs = append(ptrs, v.UnsafeAddr())
+	}
+	fields, nils := c.appendShownFields(nil, v, ff, rd, path, "", ptrs)
+	if !c.FlattenEmbedded {
+		return fields, nils
+	}
+	counts := make(map[string]int, len(fields))
+	for _, f := range fields {
+		counts[f.field.Name]++
+	}
+	for i, f := range fields {
+		if counts[f.field.Name] == 1 {
+			fields[i].name = f.field.Name
+		}
+	}
+	return fields, nils
+}
+
+// appendShownFields appends the fields of the struct v at path to fields for
+// shownFields, with their names qualified by prefix.  The addresses of the
+// structs being flattened are given by ptrs so that embedded pointers which
+// refer back to one of them are not flattened again.
+func (c *ConfigState) appendShownFields(fields []shownField, v reflect.Value, ff *fieldFilter, rd *redactor, path, prefix string, ptrs []uintptr) ([]shownField, int) {
+	indices, nils := c.visibleFields(v, ff, path)
+	vt := v.Type()
+	for _, i := range indices {
+		vtf := vt.Field(i)
+		f := shownField{
+			name:  prefix + vtf.Name,
+			path:  fieldPath(path, vtf.Name),
+			owner: vt,
+			field: vtf,
+			value: v.Field(i),
+		}
+		if ev, ok := c.flattenable(f, rd, ptrs); ok {
+			if f.value.Kind() == reflect.Ptr {
+				ptrs = append(ptrs, f.value.Pointer())
+			}
+			var n int
+			fields, n = c.appendShownFields(fields, ev, ff, rd, f.path, f.name+".", ptrs)
+			nils += n
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields, nils
+}
+
+// flattenable returns the struct held by the field f, and whether its fields
+// are promoted by the FlattenEmbedded option.  This is the case for embedded
+// structs and non-nil pointers to them which are displayed as structs, so
+// redacted, skipped and binary layout structs are not flattened.
+func (c *ConfigState) flattenable(f shownField, rd *redactor, ptrs []uintptr) (reflect.Value, bool) {
+	if !c.FlattenEmbedded || !f.field.Anonymous {
+		return reflect.Value{}, false
+	}
+	v := f.value
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		for _, p := range ptrs {
+			if p == v.Pointer() {
+				return reflect.Value{}, false
+			}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || c.isSkippedType(v.Type()) {
+		return reflect.Value{}, false
+	}
+	if _, ok := lookupBinaryLayout(v.Type()); ok {
+		return reflect.Value{}, false
+	}
+	if spewTagOptions(f.field.Tag).redact || rd.redacts(f.field.Name, f.field.Type) {
+		return reflect.Value{}, false
+	}
+	if _, ok := c.customRedaction(f.path, "", f.value); ok {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
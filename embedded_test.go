@@ -0,0 +1,59 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type EmbeddedMeta struct {
+	ID   int
+	Name string
+}
+
+type EmbeddedAudit struct {
+	ID int
+}
+
+type embeddedResource struct {
+	EmbeddedMeta
+	*EmbeddedAudit
+	Size int
+}
+
+type embeddedLoop struct {
+	*embeddedLoop
+	N int
+}
+
+var _ = Describe("Flatten Embedded Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.FlattenEmbedded = true
+		return cs
+	}
+
+	It("promotes the fields of embedded structs", func() {
+		v := embeddedResource{EmbeddedMeta: EmbeddedMeta{1, "a"}, Size: 3}
+		Expect(newConfig().Sdump(v)).To(Equal("(spew_test.embeddedResource) {\n" +
+			"  ID: (int) 1,\n" +
+			"  Name: (string) (len: 1) \"a\",\n" +
+			"  EmbeddedAudit: (*spew_test.EmbeddedAudit)(<nil>),\n" +
+			"  Size: (int) 3\n" +
+			"}\n"))
+	})
+
+	It("qualifies promoted fields with shared names", func() {
+		v := embeddedResource{EmbeddedMeta{1, "a"}, &EmbeddedAudit{2}, 3}
+		Expect(fmt.Sprintf("%+v", newConfig().NewFormatter(v))).To(Equal(
+			"{EmbeddedMeta.ID:1 Name:a EmbeddedAudit.ID:2 Size:3}"))
+	})
+
+	It("does not flatten embedded pointers referring back to the struct", func() {
+		v := &embeddedLoop{N: 1}
+		v.embeddedLoop = v
+		Expect(fmt.Sprintf("%v", newConfig().NewFormatter(v))).To(Equal("<*>{<*><shown> 1}"))
+	})
+})
@@ -0,0 +1,274 @@
+package spew
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// envPrefix is the prefix of the environment variables read by LoadEnv.
+const envPrefix = "SPEW_"
+
+// setting is a configuration option which can be set from text, such as the
+// value of an environment variable.
+type setting struct {
+	name string
+	set  func(c *ConfigState, val string) error
+}
+
+// boolSetting returns a setting which parses its value into the bool field
+// returned by field.
+func boolSetting(name string, field func(c *ConfigState) *bool) setting {
+	return setting{name, func(c *ConfigState, val string) error {
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		*field(c) = b
+		return nil
+	}}
+}
+
+// intSetting returns a setting which parses its value into the int field
+// returned by field.
+func intSetting(name string, field func(c *ConfigState) *int) setting {
+	return setting{name, func(c *ConfigState, val string) error {
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}}
+}
+
+// patternsSetting returns a setting which parses its value as a comma
+// separated list of regular expressions into the field returned by field.
+func patternsSetting(name string, field func(c *ConfigState) *[]string) setting {
+	return setting{name, func(c *ConfigState, val string) error {
+		patterns := strings.FieldsFunc(val, func(r rune) bool { return r == ',' })
+		for _, pattern := range patterns {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return err
+			}
+		}
+		*field(c) = patterns
+		return nil
+	}}
+}
+
+// unescapeSetting returns val with the escapes allowed in a Go string literal
+// interpreted, so tabs can be given as \t, or val itself when it is not valid
+// as the contents of a string literal.
+func unescapeSetting(val string) string {
+	if s, err := strconv.Unquote(`"` + val + `"`); err == nil {
+		return s
+	}
+	return val
+}
+
+// settings houses the options which can be set by LoadEnv and LoadConfig,
+// named in snake_case.
+var settings = []setting{
+	{"indent", func(c *ConfigState, val string) error {
+		c.Indent = unescapeSetting(val)
+		return nil
+	}},
+	{"indent_levels", func(c *ConfigState, val string) error {
+		levels := strings.Split(val, ",")
+		for i, level := range levels {
+			levels[i] = unescapeSetting(level)
+		}
+		c.IndentLevels = levels
+		return nil
+	}},
+	intSetting("max_depth", func(c *ConfigState) *int { return &c.MaxDepth }),
+	{"max_width", func(c *ConfigState, val string) error {
+		if val == "terminal" {
+			c.MaxWidth = MaxWidthTerminal
+			return nil
+		}
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return err
+		}
+		c.MaxWidth = n
+		return nil
+	}},
+	intSetting("max_bytes", func(c *ConfigState) *int { return &c.MaxBytes }),
+	intSetting("max_elements", func(c *ConfigState) *int { return &c.MaxElements }),
+	intSetting("tail_elements", func(c *ConfigState) *int { return &c.TailElements }),
+	boolSetting("collapse_runs", func(c *ConfigState) *bool { return &c.CollapseRuns }),
+	boolSetting("element_indices", func(c *ConfigState) *bool { return &c.ElementIndices }),
+	boolSetting("channel_details", func(c *ConfigState) *bool { return &c.ChannelDetails }),
+	boolSetting("func_names", func(c *ConfigState) *bool { return &c.FuncNames }),
+	boolSetting("human_times", func(c *ConfigState) *bool { return &c.HumanTimes }),
+	boolSetting("relative_times", func(c *ConfigState) *bool { return &c.RelativeTimes }),
+	boolSetting("well_known_types", func(c *ConfigState) *bool { return &c.WellKnownTypes }),
+	boolSetting("uuids", func(c *ConfigState) *bool { return &c.UUIDs }),
+	boolSetting("reflect_types", func(c *ConfigState) *bool { return &c.ReflectTypes }),
+	boolSetting("regexps", func(c *ConfigState) *bool { return &c.Regexps }),
+	boolSetting("parse_json_strings", func(c *ConfigState) *bool { return &c.ParseJSONStrings }),
+	boolSetting("atomic_values", func(c *ConfigState) *bool { return &c.AtomicValues }),
+	boolSetting("drain_iterators", func(c *ConfigState) *bool { return &c.DrainIterators }),
+	boolSetting("unwrap_errors", func(c *ConfigState) *bool { return &c.UnwrapErrors }),
+	boolSetting("pad_pointers", func(c *ConfigState) *bool { return &c.PadPointers }),
+	boolSetting("pointer_symbols", func(c *ConfigState) *bool { return &c.PointerSymbols }),
+	intSetting("max_string_length", func(c *ConfigState) *int { return &c.MaxStringLength }),
+	intSetting("peek_readers", func(c *ConfigState) *int { return &c.PeekReaders }),
+	{"theme", func(c *ConfigState, val string) error {
+		cc, ok := LookupTheme(val)
+		if !ok {
+			return fmt.Errorf("unknown theme, want one of %s", strings.Join(ThemeNames(), ", "))
+		}
+		c.Color = cc
+		return nil
+	}},
+	boolSetting("exported_only", func(c *ConfigState) *bool { return &c.ExportedOnly }),
+	patternsSetting("include_fields", func(c *ConfigState) *[]string { return &c.IncludeFields }),
+	patternsSetting("exclude_fields", func(c *ConfigState) *[]string { return &c.ExcludeFields }),
+	boolSetting("omit_zero", func(c *ConfigState) *bool { return &c.OmitZero }),
+	boolSetting("omit_nil", func(c *ConfigState) *bool { return &c.OmitNil }),
+	boolSetting("flatten_embedded", func(c *ConfigState) *bool { return &c.FlattenEmbedded }),
+	{"show_tags", func(c *ConfigState, val string) error {
+		c.ShowTags = strings.FieldsFunc(val, func(r rune) bool { return r == ',' || r == ' ' })
+		return nil
+	}},
+	boolSetting("redact", func(c *ConfigState) *bool { return &c.Redact }),
+	patternsSetting("redact_fields", func(c *ConfigState) *[]string { return &c.RedactFields }),
+	boolSetting("disable_methods", func(c *ConfigState) *bool { return &c.DisableMethods }),
+	boolSetting("disable_pointer_methods", func(c *ConfigState) *bool { return &c.DisablePointerMethods }),
+	boolSetting("disable_pointer_addresses", func(c *ConfigState) *bool { return &c.DisablePointerAddresses }),
+	boolSetting("pointer_ids", func(c *ConfigState) *bool { return &c.PointerIDs }),
+	boolSetting("shared_refs", func(c *ConfigState) *bool { return &c.SharedRefs }),
+	boolSetting("disable_capacities", func(c *ConfigState) *bool { return &c.DisableCapacities }),
+	boolSetting("continue_on_method", func(c *ConfigState) *bool { return &c.ContinueOnMethod }),
+	boolSetting("use_go_stringer", func(c *ConfigState) *bool { return &c.UseGoStringer }),
+	boolSetting("text_marshalers", func(c *ConfigState) *bool { return &c.TextMarshalers }),
+	boolSetting("json_marshalers", func(c *ConfigState) *bool { return &c.JSONMarshalers }),
+	boolSetting("verbose_panics", func(c *ConfigState) *bool { return &c.VerbosePanics }),
+	intSetting("panic_stack_frames", func(c *ConfigState) *int { return &c.PanicStackFrames }),
+	boolSetting("sort_keys", func(c *ConfigState) *bool { return &c.SortKeys }),
+	boolSetting("deterministic", func(c *ConfigState) *bool { return &c.Deterministic }),
+	boolSetting("spew_keys", func(c *ConfigState) *bool { return &c.SpewKeys }),
+	boolSetting("compact", func(c *ConfigState) *bool { return &c.Compact }),
+	boolSetting("show_sizes", func(c *ConfigState) *bool { return &c.ShowSizes }),
+	boolSetting("show_methods", func(c *ConfigState) *bool { return &c.ShowMethods }),
+	boolSetting("show_layout", func(c *ConfigState) *bool { return &c.ShowLayout }),
+	boolSetting("show_summary", func(c *ConfigState) *bool { return &c.ShowSummary }),
+	boolSetting("show_interface_types", func(c *ConfigState) *bool { return &c.ShowInterfaceTypes }),
+	boolSetting("short_generic_names", func(c *ConfigState) *bool { return &c.ShortGenericNames }),
+	boolSetting("reflect_values", func(c *ConfigState) *bool { return &c.ReflectValues }),
+	boolSetting("show_caller", func(c *ConfigState) *bool { return &c.ShowCaller }),
+	boolSetting("caller_funcs", func(c *ConfigState) *bool { return &c.CallerFuncs }),
+	{"timestamp_format", func(c *ConfigState, val string) error {
+		c.TimestampFormat = val
+		return nil
+	}},
+	boolSetting("disable_dump_color", func(c *ConfigState) *bool { return &c.DisableDumpColor }),
+	boolSetting("disable_formatter_color", func(c *ConfigState) *bool { return &c.DisableFormatterColor }),
+}
+
+// envName returns the name of the environment variable for the setting name.
+func envName(name string) string {
+	return envPrefix + strings.ToUpper(name)
+}
+
+/*
+LoadEnv sets the options of c from environment variables, so the output of
+deployed binaries can be adjusted without code changes.  The following
+variables are read, and options whose variable is unset are left unchanged:
+
+	SPEW_INDENT                     Indent, with escapes such as \t allowed
+	SPEW_INDENT_LEVELS              IndentLevels, as a comma separated list
+	SPEW_MAX_DEPTH                  MaxDepth
+	SPEW_MAX_WIDTH                  MaxWidth, or terminal for MaxWidthTerminal
+	SPEW_MAX_BYTES                  MaxBytes
+	SPEW_MAX_ELEMENTS               MaxElements
+	SPEW_TAIL_ELEMENTS              TailElements
+	SPEW_COLLAPSE_RUNS              CollapseRuns
+	SPEW_ELEMENT_INDICES            ElementIndices
+	SPEW_CHANNEL_DETAILS            ChannelDetails
+	SPEW_FUNC_NAMES                 FuncNames
+	SPEW_HUMAN_TIMES                HumanTimes
+	SPEW_RELATIVE_TIMES             RelativeTimes
+	SPEW_WELL_KNOWN_TYPES           WellKnownTypes
+	SPEW_UUIDS                      UUIDs
+	SPEW_REFLECT_TYPES              ReflectTypes
+	SPEW_REGEXPS                    Regexps
+	SPEW_PARSE_JSON_STRINGS         ParseJSONStrings
+	SPEW_ATOMIC_VALUES              AtomicValues
+	SPEW_DRAIN_ITERATORS            DrainIterators
+	SPEW_UNWRAP_ERRORS              UnwrapErrors
+	SPEW_PAD_POINTERS               PadPointers
+	SPEW_POINTER_SYMBOLS            PointerSymbols
+	SPEW_MAX_STRING_LENGTH          MaxStringLength
+	SPEW_PEEK_READERS               PeekReaders
+	SPEW_THEME                      the colors of a theme; see LookupTheme
+	SPEW_EXPORTED_ONLY              ExportedOnly
+	SPEW_INCLUDE_FIELDS             IncludeFields, as a comma separated list
+	SPEW_EXCLUDE_FIELDS             ExcludeFields, as a comma separated list
+	SPEW_OMIT_ZERO                  OmitZero
+	SPEW_OMIT_NIL                   OmitNil
+	SPEW_FLATTEN_EMBEDDED           FlattenEmbedded
+	SPEW_SHOW_TAGS                  ShowTags, as a comma separated list
+	SPEW_REDACT                     Redact
+	SPEW_REDACT_FIELDS              RedactFields, as a comma separated list
+	SPEW_DISABLE_METHODS            DisableMethods
+	SPEW_DISABLE_POINTER_METHODS    DisablePointerMethods
+	SPEW_DISABLE_POINTER_ADDRESSES  DisablePointerAddresses
+	SPEW_POINTER_IDS                PointerIDs
+	SPEW_SHARED_REFS                SharedRefs
+	SPEW_DISABLE_CAPACITIES         DisableCapacities
+	SPEW_CONTINUE_ON_METHOD         ContinueOnMethod
+	SPEW_USE_GO_STRINGER            UseGoStringer
+	SPEW_TEXT_MARSHALERS            TextMarshalers
+	SPEW_JSON_MARSHALERS            JSONMarshalers
+	SPEW_VERBOSE_PANICS             VerbosePanics
+	SPEW_PANIC_STACK_FRAMES         PanicStackFrames
+	SPEW_SORT_KEYS                  SortKeys
+	SPEW_DETERMINISTIC              Deterministic
+	SPEW_SPEW_KEYS                  SpewKeys
+	SPEW_COMPACT                    Compact
+	SPEW_SHOW_SIZES                 ShowSizes
+	SPEW_SHOW_METHODS               ShowMethods
+	SPEW_SHOW_LAYOUT                ShowLayout
+	SPEW_SHOW_SUMMARY               ShowSummary
+	SPEW_SHOW_INTERFACE_TYPES       ShowInterfaceTypes
+	SPEW_SHORT_GENERIC_NAMES        ShortGenericNames
+	SPEW_REFLECT_VALUES             ReflectValues
+	SPEW_SHOW_CALLER                ShowCaller
+	SPEW_CALLER_FUNCS               CallerFuncs
+	SPEW_TIMESTAMP_FORMAT           TimestampFormat
+	SPEW_DISABLE_DUMP_COLOR         DisableDumpColor
+	SPEW_DISABLE_FORMATTER_COLOR    DisableFormatterColor
+
+Boolean variables accept the values understood by strconv.ParseBool.  An
+error describing each variable with an invalid value is returned, and those
+variables are otherwise ignored.
+
+The global Config is loaded from the environment when the package is
+initialized.
+*/
+func (c *ConfigState) LoadEnv() error {
+	var errs []error
+	for _, s := range settings {
+		val, ok := os.LookupEnv(envName(s.name))
+		if !ok {
+			continue
+		}
+		if err := s.set(c, val); err != nil {
+			errs = append(errs, fmt.Errorf("spew: invalid %s %q: %w", envName(s.name), val, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func init() {
+	// Invalid variables are ignored since there's no caller to report them
+	// to.
+	_ = Config.LoadEnv()
+}
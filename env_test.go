@@ -0,0 +1,60 @@
+package spew_test
+
+import (
+	"os"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// setenv sets the environment variables in vars for the current spec.
+func setenv(vars map[string]string) {
+	for name, val := range vars {
+		old, ok := os.LookupEnv(name)
+		Expect(os.Setenv(name, val)).To(Succeed())
+		DeferCleanup(func() {
+			if ok {
+				os.Setenv(name, old)
+			} else {
+				os.Unsetenv(name)
+			}
+		})
+	}
+}
+
+var _ = Describe("Env Tests", func() {
+	It("sets options from environment variables", func() {
+		setenv(map[string]string{
+			"SPEW_INDENT":          `\t`,
+			"SPEW_MAX_DEPTH":       "3",
+			"SPEW_MAX_WIDTH":       "terminal",
+			"SPEW_THEME":           "none",
+			"SPEW_DISABLE_METHODS": "true",
+			"SPEW_SORT_KEYS":       "1",
+		})
+		cs := spew.NewDefaultConfig()
+		Expect(cs.LoadEnv()).To(Succeed())
+		Expect(cs.Indent).To(Equal("\t"))
+		Expect(cs.MaxDepth).To(Equal(3))
+		Expect(cs.MaxWidth).To(Equal(spew.MaxWidthTerminal))
+		Expect(cs.Color).To(Equal(spew.ColorConfiguration{}))
+		Expect(cs.DisableMethods).To(BeTrue())
+		Expect(cs.SortKeys).To(BeTrue())
+		Expect(cs.Compact).To(BeFalse())
+	})
+
+	It("reports invalid values", func() {
+		setenv(map[string]string{
+			"SPEW_MAX_DEPTH": "deep",
+			"SPEW_THEME":     "neon",
+			"SPEW_COMPACT":   "true",
+		})
+		cs := spew.NewDefaultConfig()
+		err := cs.LoadEnv()
+		Expect(err).To(MatchError(ContainSubstring(`spew: invalid SPEW_MAX_DEPTH "deep"`)))
+		Expect(err).To(MatchError(ContainSubstring(`spew: invalid SPEW_THEME "neon": unknown theme`)))
+		Expect(cs.MaxDepth).To(Equal(0))
+		Expect(cs.Compact).To(BeTrue())
+	})
+})
@@ -0,0 +1,94 @@
+package spew
+
+import "reflect"
+
+// errorValue returns the error held by v, and false when v does not
+// implement error or is a nil pointer.
+func errorValue(v reflect.Value) (error, bool) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, false
+	}
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return nil, false
+		}
+		v = unsafeReflectValue(v)
+	}
+	err, ok := v.Interface().(error)
+	return err, ok
+}
+
+// wrappedErrors returns the errors wrapped by err, and whether err is a
+// multi-error implementing Unwrap() []error, such as the errors returned by
+// errors.Join.  Panics in Unwrap are treated as err wrapping nothing.
+func wrappedErrors(err error) (errs []error, multi bool) {
+	defer func() {
+		if recover() != nil {
+			errs, multi = nil, false
+		}
+	}()
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		return e.Unwrap(), true
+	case interface{ Unwrap() error }:
+		if wrapped := e.Unwrap(); wrapped != nil {
+			return []error{wrapped}, false
+		}
+	}
+	return nil, false
+}
+
+// dumpErrorTree writes the error v holding err, which wraps the passed
+// errors, followed by the tree of the errors it wraps for the UnwrapErrors
+// option.  The message of multi-errors is omitted since it is made up of the
+// messages of the errors they wrap.  Errors which wrap themselves, directly or
+// through the errors they wrap, are written as already shown when repeated.
+func (d *dumpState) dumpErrorTree(v reflect.Value, staticType reflect.Type, err error, wrapped []error, multi bool) {
+	d.indent()
+	d.writeStaticType(staticType)
+	writeGlyph(d.w, d.cs, v.Kind())
+	withParens(d, func(d *dumpState) {
+		printType(d.w, d.cs, v.Type().String())
+	})
+	if !multi {
+		withParens(d, func(d *dumpState) {
+			defer catchPanic(d.cs, d.w, v, "Error()")
+			d.w.Write([]byte(err.Error()))
+		})
+	}
+	d.w.Write(spaceBytes)
+	if v.Kind() == reflect.Ptr {
+		key := visitKey{typ: v.Type(), addr: v.Pointer()}
+		if d.visited[key] {
+			d.w.Write(circularBytes)
+			return
+		}
+		d.visited[key] = true
+		defer delete(d.visited, key)
+	}
+	d.punct(openBraceNewlineBytes)
+	d.depth++
+	if d.depth > d.maxDepth {
+		d.indent()
+		d.line(maxSymbol(d.cs))
+		d.countTruncation()
+	} else {
+		for i, e := range wrapped {
+			if t := reflect.TypeOf(e); t != nil && t.Kind() != reflect.Ptr && t.Comparable() && e == err {
+				// Values can't be told apart by their address, so only
+				// those wrapping themselves directly are caught.
+				d.indent()
+				d.w.Write(circularBytes)
+			} else {
+				d.dump(reflect.ValueOf(e))
+			}
+			if i < len(wrapped)-1 {
+				d.punct(commaBytes)
+			}
+			d.line(newlineBytes)
+		}
+	}
+	d.depth--
+	d.indent()
+	d.punct(closeBraceBytes)
+}
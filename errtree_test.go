@@ -0,0 +1,86 @@
+package spew_test
+
+import (
+	"errors"
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// errtreeLoop is an error wrapping next, which may lead back to itself.
+type errtreeLoop struct {
+	next error
+}
+
+func (e *errtreeLoop) Error() string { return "loop" }
+func (e *errtreeLoop) Unwrap() error { return e.next }
+
+// errtreeSelf is an error value wrapping itself.
+type errtreeSelf struct{}
+
+func (e errtreeSelf) Error() string { return "self" }
+func (e errtreeSelf) Unwrap() error { return e }
+
+var _ = Describe("Error Tree Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.UnwrapErrors = true
+		cs.DisablePointerAddresses = true
+		return cs
+	}
+
+	It("displays joined errors as a tree", func() {
+		cs := newConfig()
+		err := errors.Join(errors.New("a"), fmt.Errorf("ctx: %w", errors.New("b")))
+		Expect(cs.Sdump(err)).To(Equal("(*errors.joinError) {\n" +
+			"  (*errors.errorString)(a),\n" +
+			"  (*fmt.wrapError)(ctx: b) {\n" +
+			"    (*errors.errorString)(b)\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("displays errors nested in values", func() {
+		cs := newConfig()
+		v := struct{ Err error }{fmt.Errorf("outer: %w", errors.New("inner"))}
+		Expect(cs.Sdump(v)).To(Equal("(struct { Err error }) {\n" +
+			"  Err: (*fmt.wrapError)(outer: inner) {\n" +
+			"    (*errors.errorString)(inner)\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("stops at the depth limit", func() {
+		cs := newConfig()
+		cs.MaxDepth = 1
+		err := errors.Join(errors.Join(errors.New("a")))
+		Expect(cs.Sdump(err)).To(Equal("(*errors.joinError) {\n" +
+			"  (*errors.joinError) {\n" +
+			"    <max depth reached>\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("stops at errors wrapping themselves", func() {
+		cs := newConfig()
+		self := &errtreeLoop{}
+		self.next = self
+		Expect(cs.Sdump(self)).To(Equal("(*spew_test.errtreeLoop)(loop) {\n" +
+			"  (*spew_test.errtreeLoop)(loop) <already shown>\n" +
+			"}\n"))
+
+		a := &errtreeLoop{}
+		a.next = fmt.Errorf("via: %w", a)
+		Expect(cs.Sdump(a)).To(Equal("(*spew_test.errtreeLoop)(loop) {\n" +
+			"  (*fmt.wrapError)(via: loop) {\n" +
+			"    (*spew_test.errtreeLoop)(loop) <already shown>\n" +
+			"  }\n" +
+			"}\n"))
+
+		Expect(cs.Sdump(errtreeSelf{})).To(Equal("(spew_test.errtreeSelf)(self) {\n" +
+			"  <already shown>\n" +
+			"}\n"))
+	})
+})
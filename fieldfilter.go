@@ -0,0 +1,154 @@
+package spew
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// fieldFilter narrows the struct fields which are displayed to those matched
+// by the IncludeFields and ExcludeFields options.  A nil fieldFilter keeps
+// every field.
+type fieldFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+
+	// narrow is set when IncludeFields is, so fields not matched by
+	// include are hidden even when none of its patterns are valid.
+	narrow bool
+
+	// leads caches whether a struct field of a type at a path has a
+	// descendant field matched by include.
+	leads map[filterKey]bool
+}
+
+// filterKey is the key of the leads cache of a fieldFilter.
+type filterKey struct {
+	path string
+	typ  reflect.Type
+}
+
+// compileFieldPatterns compiles patterns so each must match a whole field
+// name or path.  Patterns which are not valid regular expressions are
+// skipped.
+func compileFieldPatterns(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		if re, err := regexp.Compile("^(?:" + pattern + ")$"); err == nil {
+			res = append(res, re)
+		}
+	}
+	return res
+}
+
+// newFieldFilter returns the fieldFilter for the IncludeFields and
+// ExcludeFields options of cs, or nil when neither is set.
+func newFieldFilter(cs *ConfigState) *fieldFilter {
+	if len(cs.IncludeFields) == 0 && len(cs.ExcludeFields) == 0 {
+		return nil
+	}
+	return &fieldFilter{
+		include: compileFieldPatterns(cs.IncludeFields),
+		exclude: compileFieldPatterns(cs.ExcludeFields),
+		narrow:  len(cs.IncludeFields) > 0,
+		leads:   make(map[filterKey]bool),
+	}
+}
+
+// fieldPath returns the path of the struct field name of the struct at path.
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// matchPath returns whether one of res matches the path, or the path or name
+// of one of the fields it passes through.
+func matchPath(res []*regexp.Regexp, path string) bool {
+	for _, re := range res {
+		prefix := path
+		for {
+			name := prefix[strings.LastIndexByte(prefix, '.')+1:]
+			if re.MatchString(prefix) || re.MatchString(name) {
+				return true
+			}
+			i := strings.LastIndexByte(prefix, '.')
+			if i < 0 {
+				break
+			}
+			prefix = prefix[:i]
+		}
+	}
+	return false
+}
+
+// keep returns whether the struct field at path with the type t is displayed.
+// Fields which are not matched by an include pattern are kept when a field
+// within them could be, so the structure around matched fields remains.
+func (ff *fieldFilter) keep(path string, t reflect.Type) bool {
+	if ff == nil {
+		return true
+	}
+	if matchPath(ff.exclude, path) {
+		return false
+	}
+	if !ff.narrow || matchPath(ff.include, path) {
+		return true
+	}
+	return ff.leadsTo(path, t, nil)
+}
+
+// leadsTo returns whether a struct field within a value of type t at path is
+// matched by an include pattern.  Interfaces may hold any value, so they are
+// assumed to.  The struct types in stack are not searched again, which ends
+// the search of recursive types.
+func (ff *fieldFilter) leadsTo(path string, t reflect.Type, stack []reflect.Type) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			t = t.Elem()
+			continue
+		case reflect.Interface:
+			return true
+		}
+		break
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for _, s := range stack {
+		if s == t {
+			return false
+		}
+	}
+
+	key := filterKey{path, t}
+	if leads, ok := ff.leads[key]; ok {
+		return leads
+	}
+	leads := false
+	stack = append(stack, t)
+	for i := 0; i < t.NumField() && !leads; i++ {
+		p := fieldPath(path, t.Field(i).Name)
+		if matchPath(ff.exclude, p) {
+			continue
+		}
+		leads = matchPath(ff.include, p) || ff.leadsTo(p, t.Field(i).Type, stack)
+	}
+	ff.leads[key] = leads
+	return leads
+}
+
+// structPath returns the path of struct field names to the node n.
+func structPath(n *node) string {
+	var names []string
+	for ; n.parent != nil; n = n.parent {
+		if n.parent.kind == reflect.Struct {
+			names = append(names, n.name)
+		}
+	}
+	slices.Reverse(names)
+	return strings.Join(names, ".")
+}
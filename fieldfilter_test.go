@@ -0,0 +1,93 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type filterStatus struct {
+	Phase string
+	Ready bool
+}
+
+type filterSpec struct {
+	Replicas int
+	Status   filterStatus
+}
+
+type filterObject struct {
+	Name   string
+	Spec   filterSpec
+	Status filterStatus
+	Labels map[string]string
+}
+
+var _ = Describe("Field Filter Tests", func() {
+	v := filterObject{
+		Name:   "api",
+		Spec:   filterSpec{Replicas: 3, Status: filterStatus{Phase: "Pending"}},
+		Status: filterStatus{Phase: "Running", Ready: true},
+	}
+
+	It("shows only the fields leading to included fields", func() {
+		cs := spew.NewTestConfig()
+		cs.IncludeFields = []string{".*Status.*"}
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.filterObject) {\n" +
+			"  Spec: (spew_test.filterSpec) {\n" +
+			"    Status: (spew_test.filterStatus) {\n" +
+			"      Phase: (string) (len: 7) \"Pending\",\n" +
+			"      Ready: (bool) false\n" +
+			"    }\n" +
+			"  },\n" +
+			"  Status: (spew_test.filterStatus) {\n" +
+			"    Phase: (string) (len: 7) \"Running\",\n" +
+			"    Ready: (bool) true\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("matches patterns against full field paths", func() {
+		cs := spew.NewTestConfig()
+		cs.IncludeFields = []string{`Spec\.Status\.Phase`}
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(v))).To(Equal(
+			"{Spec:{Status:{Phase:Pending}}}"))
+	})
+
+	It("hides excluded fields", func() {
+		cs := spew.NewTestConfig()
+		cs.ExcludeFields = []string{"Spec", "Labels", `Status\.Ready`}
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(v))).To(Equal(
+			"{Name:api Status:{Phase:Running}}"))
+	})
+
+	It("applies exclusions before inclusions", func() {
+		cs := spew.NewTestConfig()
+		cs.IncludeFields = []string{"Phase"}
+		cs.ExcludeFields = []string{"Spec"}
+		root := cs.Parse(v)
+		Expect(root.Children).To(HaveLen(1))
+		Expect(root.Children[0].Name).To(Equal("Status"))
+		Expect(root.Children[0].Children).To(HaveLen(1))
+		Expect(root.Children[0].Children[0].Name).To(Equal("Phase"))
+	})
+
+	It("ignores invalid patterns", func() {
+		cs := spew.NewTestConfig()
+		cs.IncludeFields = []string{"("}
+		Expect(fmt.Sprintf("%v", cs.NewFormatter(v))).To(Equal("{}"))
+	})
+
+	It("reads the patterns from the environment", func() {
+		setenv(map[string]string{
+			"SPEW_INCLUDE_FIELDS": "Name,Status",
+			"SPEW_EXCLUDE_FIELDS": "(",
+		})
+		cs := spew.NewTestConfig()
+		Expect(cs.LoadEnv()).To(MatchError(ContainSubstring("SPEW_EXCLUDE_FIELDS")))
+		Expect(cs.IncludeFields).To(Equal([]string{"Name", "Status"}))
+		Expect(cs.ExcludeFields).To(BeNil())
+	})
+})
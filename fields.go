@@ -0,0 +1,58 @@
+package spew
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isNilValue returns whether v is a nil pointer, interface, map, slice,
+// channel or function.
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// visibleFields returns the indices of the fields of the struct v at path
+// which are displayed according to their spew struct tags, the field filter
+// ff and the ExportedOnly, OmitZero and OmitNil options, along with the
+// number of fields omitted for being nil.
+func (c *ConfigState) visibleFields(v reflect.Value, ff *fieldFilter, path string) (fields []int, nils int) {
+	fields = make([]int, 0, v.NumField())
+	vt := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		switch f := v.Field(i); {
+		case c.ExportedOnly && !vt.Field(i).IsExported():
+			continue
+		case spewTagOptions(vt.Field(i).Tag).skip:
+			continue
+		case !ff.keep(fieldPath(path, vt.Field(i).Name), vt.Field(i).Type):
+			continue
+		case c.OmitNil && isNilValue(f):
+			nils++
+			continue
+		case c.OmitZero && f.IsZero():
+			continue
+		}
+		fields = append(fields, i)
+	}
+	return fields, nils
+}
+
+// omittedLine writes the line giving the number of fields of a struct
+// omitted for being nil.  Nothing is written when no fields were omitted.
+func (d *dumpState) omittedLine(nils int) {
+	if nils == 0 {
+		return
+	}
+	noun := "fields"
+	if nils == 1 {
+		noun = "field"
+	}
+	d.indent()
+	withColor(d.w, []byte(fmt.Sprintf("(%d nil %s omitted)", nils, noun)), d.cs.Color.Length...)
+	d.line(newlineBytes)
+}
@@ -0,0 +1,73 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fieldsTester struct {
+	Name   string
+	Count  int
+	Labels map[string]string
+	Spec   struct{ Replicas int }
+}
+
+var _ = Describe("Field Filtering Tests", func() {
+	It("omits zero fields in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.OmitZero = true
+		Expect(cs.Sdump(fieldsTester{Count: 2}, fieldsTester{})).To(Equal("(spew_test.fieldsTester) {\n" +
+			"  Count: (int) 2\n" +
+			"}\n" +
+			"(spew_test.fieldsTester) {\n" +
+			"}\n"))
+	})
+
+	It("omits zero fields in Formatter output and parsed trees", func() {
+		cs := spew.NewTestConfig()
+		cs.OmitZero = true
+		v := fieldsTester{Name: "a", Spec: struct{ Replicas int }{3}}
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(v))).To(Equal("{Name:a Spec:{Replicas:3}}"))
+		root := cs.Parse(v)
+		Expect(root.Children).To(HaveLen(2))
+		Expect(root.Children[1].Name).To(Equal("Spec"))
+		Expect(root.Children[1].Index).To(Equal(1))
+	})
+
+	It("omits nil fields with a count in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.OmitNil = true
+		type nils struct {
+			P   *int
+			E   error
+			M   map[string]int
+			N   int
+			Fns []func()
+		}
+		Expect(cs.Sdump(nils{}, struct{ P *int }{})).To(Equal("(spew_test.nils) {\n" +
+			"  N: (int) 0\n" +
+			"  (4 nil fields omitted)\n" +
+			"}\n" +
+			"(struct { P *int }) {\n" +
+			"  (1 nil field omitted)\n" +
+			"}\n"))
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(nils{N: 1}))).To(Equal("{N:1}"))
+	})
+
+	It("omits unexported fields", func() {
+		cs := spew.NewTestConfig()
+		cs.ExportedOnly = true
+		type mixed struct {
+			Public  int
+			private int
+		}
+		Expect(cs.Sdump(mixed{1, 2})).To(Equal("(spew_test.mixed) {\n" +
+			"  Public: (int) 1\n" +
+			"}\n"))
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(mixed{1, 2}))).To(Equal("{Public:1}"))
+		Expect(cs.Parse(mixed{1, 2}).Children).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,330 @@
+package spew
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// goNames qualifies the names of types for a Go file in the package pkg and
+// records the imports they require.  The first type which can't be referred
+// to from the file is recorded in err.  The import path of the package is
+// that of the first type declared in a package named pkg, and the names of
+// the packages of the types referred to are kept in names, since the
+// arguments of generic types only give their import path.  The imports whose
+// name is guessed from their path are kept in guessed.
+type goNames struct {
+	pkg     string
+	pkgPath string
+	imports map[string]string
+	aliases map[string]string
+	names   map[string]string
+	guessed map[string]bool
+	err     error
+}
+
+// newGoNames returns a goNames for a file in the package pkg.
+func newGoNames(pkg string) *goNames {
+	n := &goNames{pkg: pkg, names: make(map[string]string)}
+	n.reset()
+	return n
+}
+
+// reset forgets the imports and the error recorded by n, keeping the names
+// of the packages already learned so a second pass over the same value can
+// qualify the arguments of generic types declared before their packages are
+// referred to.
+func (n *goNames) reset() {
+	n.imports = make(map[string]string)
+	n.aliases = make(map[string]string)
+	n.guessed = make(map[string]bool)
+	n.err = nil
+}
+
+// fail records the first error preventing the file from compiling.
+func (n *goNames) fail(format string, a ...interface{}) {
+	if n.err == nil {
+		n.err = fmt.Errorf("spew: "+format, a...)
+	}
+}
+
+// use records that the file imports the standard library package path, which
+// is referred to by its name.  It does nothing when n is nil.
+func (n *goNames) use(path string) {
+	if n != nil {
+		n.imports[path] = path
+		n.aliases[path] = path
+	}
+}
+
+// pkgName returns the name of the package declaring the named type typ.
+func pkgName(typ reflect.Type) string {
+	return strings.TrimSuffix(typ.String(), "."+typ.Name())
+}
+
+// local returns whether the named type typ is declared in the package of the
+// file.
+func (n *goNames) local(typ reflect.Type) bool {
+	if n.pkgPath == "" && typ.PkgPath() != "" && pkgName(typ) == n.pkg {
+		n.pkgPath = typ.PkgPath()
+	}
+	return typ.PkgPath() != "" && typ.PkgPath() == n.pkgPath
+}
+
+// qualifyPath returns the qualifier the file refers to the package path by,
+// which is empty for the package of the file, and records its import.  The
+// name of the package is name when known and guessed from path otherwise.
+func (n *goNames) qualifyPath(path, name string) string {
+	if path == n.pkgPath {
+		return ""
+	}
+	if path == "main" {
+		n.fail("types of package main can't be referred to from package %s", n.pkg)
+	}
+	if name == "" {
+		name = n.names[path]
+	}
+	if name == "" {
+		name = guessPkgName(path)
+		n.guessed[path] = true
+	}
+	return n.importName(path, name) + "."
+}
+
+// guessPkgName returns the likely name of the package path, which is its
+// last element without a major version suffix, made a valid identifier.
+func guessPkgName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+	if !token.IsIdentifier(name) {
+		name = "pkg" + name
+	}
+	return name
+}
+
+// typeName returns the name of the named type typ within its package, with
+// the qualified identifiers of the arguments of generic types, which
+// reflect writes with their import path, qualified as in the file.
+func (n *goNames) typeName(typ reflect.Type) string {
+	name := typ.Name()
+	open := strings.IndexByte(name, '[')
+	if open < 0 {
+		return name
+	}
+	var b strings.Builder
+	b.WriteString(name[:open])
+	args := name[open:]
+	for len(args) > 0 {
+		end := strings.IndexAny(args, "[](){},;*& ")
+		if end < 0 {
+			end = len(args)
+		}
+		if end == 0 {
+			b.WriteByte(args[0])
+			args = args[1:]
+			continue
+		}
+		word := args[:end]
+		args = args[end:]
+		if dot := strings.LastIndexByte(word, '.'); dot > 0 {
+			b.WriteString(n.qualifyPath(word[:dot], ""))
+			word = word[dot+1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// field records an error when the struct field sf of typ can't be set from
+// the file.  It does nothing when n is nil.
+func (n *goNames) field(typ reflect.Type, sf reflect.StructField) {
+	if n == nil || sf.IsExported() {
+		return
+	}
+	if typ.Name() == "" || typ.PkgPath() != sf.PkgPath || !n.local(typ) {
+		n.fail("unexported field %s of %v can't be set from package %s", sf.Name, typ, n.pkg)
+	}
+}
+
+// importName returns the name the file refers to the package path by, which
+// is its name unless another imported package has the same name.
+func (n *goNames) importName(path, name string) string {
+	if alias, ok := n.imports[path]; ok {
+		return alias
+	}
+	alias := name
+	for i := 2; n.aliases[alias] != "" || alias == n.pkg; i++ {
+		alias = name + strconv.Itoa(i)
+	}
+	n.imports[path] = alias
+	n.aliases[alias] = path
+	return alias
+}
+
+// qualify returns the name of typ as written in the file.
+func (n *goNames) qualify(typ reflect.Type) string {
+	if typ.Name() != "" {
+		if typ.PkgPath() == "" {
+			return typ.Name()
+		}
+		n.names[typ.PkgPath()] = pkgName(typ)
+		if n.local(typ) {
+			return n.typeName(typ)
+		}
+		if !token.IsExported(typ.Name()) {
+			n.fail("unexported type %v can't be referred to from package %s", typ, n.pkg)
+		}
+		return n.qualifyPath(typ.PkgPath(), pkgName(typ)) + n.typeName(typ)
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return "*" + n.qualify(typ.Elem())
+	case reflect.Slice:
+		return "[]" + n.qualify(typ.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(typ.Len()) + "]" + n.qualify(typ.Elem())
+	case reflect.Map:
+		return "map[" + n.qualify(typ.Key()) + "]" + n.qualify(typ.Elem())
+	case reflect.Chan:
+		elem := n.qualify(typ.Elem())
+		switch typ.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + elem
+		case reflect.SendDir:
+			return "chan<- " + elem
+		}
+		if typ.Elem().Kind() == reflect.Chan && typ.Elem().ChanDir() == reflect.RecvDir {
+			return "chan (" + elem + ")"
+		}
+		return "chan " + elem
+	case reflect.Func:
+		in := make([]string, typ.NumIn())
+		for i := range in {
+			if typ.IsVariadic() && i == len(in)-1 {
+				in[i] = "..." + n.qualify(typ.In(i).Elem())
+				continue
+			}
+			in[i] = n.qualify(typ.In(i))
+		}
+		out := make([]string, typ.NumOut())
+		for i := range out {
+			out[i] = n.qualify(typ.Out(i))
+		}
+		s := "func(" + strings.Join(in, ", ") + ")"
+		switch len(out) {
+		case 0:
+		case 1:
+			s += " " + out[0]
+		default:
+			s += " (" + strings.Join(out, ", ") + ")"
+		}
+		return s
+	case reflect.Struct:
+		fields := make([]string, typ.NumField())
+		for i := range fields {
+			sf := typ.Field(i)
+			f := n.qualify(sf.Type)
+			if !sf.Anonymous {
+				f = sf.Name + " " + f
+			}
+			if sf.Tag != "" {
+				f += " " + strconv.Quote(string(sf.Tag))
+			}
+			fields[i] = f
+		}
+		if len(fields) == 0 {
+			return "struct {}"
+		}
+		return "struct { " + strings.Join(fields, "; ") + " }"
+	case reflect.Interface:
+		if typ.NumMethod() == 0 {
+			return "interface {}"
+		}
+		n.fail("unnamed interface type %v is not supported", typ)
+	}
+	return typ.String()
+}
+
+/*
+ToGoFixture returns a complete Go source file in the package pkgName which
+declares the variable varName initialized to v, as written by SdumpGo.  The
+file imports the packages of the types it refers to, so values captured at
+runtime can be turned into unit test fixtures in one step.  For example:
+
+	src, err := spew.ToGoFixture(order, "orders_test", "fixtureOrder")
+
+results in a file such as:
+
+	package orders_test
+
+	import "example.com/shop/orders"
+
+	var fixtureOrder = &orders.Order{
+		ID: 42,
+	}
+
+Types declared in a package named pkgName are referred to without a
+qualifier, including as the arguments of generic types.  The package is told
+apart by the import path of the first such type, so the types of other
+packages with the same name are imported.  An error is returned when the
+names are not valid identifiers or when v holds values which can't be set
+from the package, such as unexported fields of types declared elsewhere or
+types of package main.  Like SdumpGo, the source holds the actual values
+whatever the Redact option, so fixtures must not be captured from values
+holding credentials.
+*/
+func ToGoFixture(v interface{}, pkgName, varName string) ([]byte, error) {
+	if !token.IsIdentifier(pkgName) {
+		return nil, fmt.Errorf("spew: invalid package name %q", pkgName)
+	}
+	if !token.IsIdentifier(varName) {
+		return nil, fmt.Errorf("spew: invalid variable name %q", varName)
+	}
+
+	// The first pass learns the packages of the types held by v, which are
+	// used to qualify the arguments of generic types in the second.
+	names := newGoNames(pkgName)
+	newGoState(names).source(v)
+	names.reset()
+	expr := newGoState(names).source(v)
+	if names.err != nil {
+		return nil, names.err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	if len(names.imports) > 0 {
+		paths := make([]string, 0, len(names.imports))
+		for path := range names.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		buf.WriteString("import (\n")
+		for _, path := range paths {
+			alias := names.imports[path]
+			if alias == path || !names.guessed[path] && strings.HasSuffix(path, "/"+alias) {
+				fmt.Fprintf(&buf, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&buf, "\t%s %q\n", alias, path)
+			}
+		}
+		buf.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&buf, "var %s = %s\n", varName, expr)
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,115 @@
+package spew_test
+
+import (
+	"math"
+	"net/url"
+	"time"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fixtureLink struct {
+	URL   *url.URL
+	Score float64
+}
+
+type fixtureBox[T any] struct {
+	V T
+}
+
+var _ = Describe("ToGoFixture Tests", func() {
+	It("writes a file declaring the value", func() {
+		src, err := spew.ToGoFixture(&goNode{Name: "a"}, "spew_test", "fixtureNode")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(`package spew_test
+
+var fixtureNode = &goNode{
+	Name: "a",
+}
+`))
+	})
+
+	It("imports the packages of the types it refers to", func() {
+		v := []fixtureLink{{URL: &url.URL{Scheme: "https", Host: "example.com"}, Score: math.NaN()}}
+		src, err := spew.ToGoFixture(v, "spew_test", "links")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(`package spew_test
+
+import (
+	"math"
+	"net/url"
+)
+
+var links = []fixtureLink{
+	fixtureLink{
+		URL: &url.URL{
+			Scheme: "https",
+			Host:   "example.com",
+		},
+		Score: math.NaN(),
+	},
+}
+`))
+	})
+
+	It("qualifies the types of other packages", func() {
+		src, err := spew.ToGoFixture(map[string]url.Values{"q": {"a": {"b"}}}, "fixtures", "Queries")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(`package fixtures
+
+import (
+	"net/url"
+)
+
+var Queries = map[string]url.Values{
+	"q": url.Values{
+		"a": []string{"b"},
+	},
+}
+`))
+	})
+
+	It("rejects values which can't be set from the package", func() {
+		_, err := spew.ToGoFixture(&goNode{score: 1}, "fixtures", "node")
+		Expect(err).To(MatchError(ContainSubstring("unexported type spew_test.goNode")))
+
+		_, err = spew.ToGoFixture(time.Unix(0, 0), "fixtures", "t")
+		Expect(err).To(MatchError(ContainSubstring("unexported field")))
+	})
+
+	It("rejects invalid names", func() {
+		_, err := spew.ToGoFixture(1, "my-pkg", "v")
+		Expect(err).To(MatchError(`spew: invalid package name "my-pkg"`))
+		_, err = spew.ToGoFixture(1, "fixtures", "1v")
+		Expect(err).To(MatchError(`spew: invalid variable name "1v"`))
+	})
+
+	It("qualifies the arguments of generic types", func() {
+		v := fixtureBox[fixtureBox[url.Values]]{V: fixtureBox[url.Values]{V: url.Values{"a": {"b"}}}}
+		src, err := spew.ToGoFixture(v, "spew_test", "box")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(`package spew_test
+
+import (
+	"net/url"
+)
+
+var box = fixtureBox[fixtureBox[url.Values]]{
+	V: fixtureBox[url.Values]{
+		V: url.Values{
+			"a": []string{"b"},
+		},
+	},
+}
+`))
+
+		src, err = spew.ToGoFixture([]fixtureBox[*fixtureLink]{}, "spew_test", "boxes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(`package spew_test
+
+var boxes = []fixtureBox[*fixtureLink]{}
+`))
+	})
+})
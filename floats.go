@@ -0,0 +1,34 @@
+package spew
+
+// FloatFormat specifies the notation floats are displayed in.
+type FloatFormat int
+
+const (
+	// FloatShortest displays floats in the shortest of decimal and
+	// scientific notation, as the %g verb of fmt does.  This is the default.
+	FloatShortest FloatFormat = iota
+
+	// FloatFixed displays floats in decimal notation without an exponent,
+	// such as 123456.789.
+	FloatFixed
+
+	// FloatScientific displays floats in scientific notation, such as
+	// 1.23456789e+05.
+	FloatScientific
+)
+
+// floatVerb returns the strconv format byte and precision floats are
+// displayed with according to the FloatFormat and FloatPrecision options.
+func (c *ConfigState) floatVerb() (format byte, prec int) {
+	prec = c.FloatPrecision
+	if prec <= 0 {
+		prec = -1
+	}
+	switch c.FloatFormat {
+	case FloatFixed:
+		return 'f', prec
+	case FloatScientific:
+		return 'e', prec
+	}
+	return 'g', prec
+}
@@ -0,0 +1,34 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FloatFormat Tests", func() {
+	v := []float64{123456.789, 0.1, 1e21}
+
+	It("displays the shortest representation by default", func() {
+		Expect(spew.NewTestConfig().Sprint(v)).To(Equal("[123456.789 0.1 1e+21]"))
+	})
+
+	It("displays floats in the configured notation", func() {
+		cfg := spew.NewTestConfig()
+		cfg.FloatFormat = spew.FloatFixed
+		Expect(cfg.Sprint(v)).To(Equal("[123456.789 0.1 1000000000000000000000]"))
+
+		cfg.FloatFormat = spew.FloatScientific
+		Expect(cfg.Sprint(v)).To(Equal("[1.23456789e+05 1e-01 1e+21]"))
+	})
+
+	It("displays floats with the configured precision", func() {
+		cfg := spew.NewTestConfig()
+		cfg.FloatPrecision = 2
+		Expect(cfg.Sprint(v)).To(Equal("[1.2e+05 0.1 1e+21]"))
+
+		cfg.FloatFormat = spew.FloatFixed
+		Expect(cfg.Sprint(v)).To(Equal("[123456.79 0.10 1000000000000000000000.00]"))
+		Expect(cfg.Sdump(float32(1.5))).To(Equal("(float32) 1.50\n"))
+	})
+})
@@ -36,8 +36,29 @@ type formatState struct {
 	fs             fmt.State
 	depth          int
 	pointers       map[uintptr]int
+	visited        map[visitKey]bool
 	ignoreNextType bool
 	cs             *ConfigState
+
+	// maxDepth is the depth beyond which the elements of containers are not
+	// displayed, and forceMethods is set while formatting a field with the
+	// string option.  See dumpState.
+	maxDepth     int
+	forceMethods bool
+
+	// filter and path are the field filter and the path of struct field
+	// names to the value being formatted.  See dumpState.
+	filter *fieldFilter
+	path   string
+
+	// redactor and keyName are the redaction options and the name of the
+	// map entry whose value is formatted next.  See dumpState.
+	redactor *redactor
+	keyName  string
+
+	// ids labels pointers with the identifiers it assigns instead of their
+	// addresses when it is not nil.
+	ids *pointerIDs
 }
 
 // buildDefaultFormat recreates the original format string without precision
@@ -86,6 +107,11 @@ func (f *formatState) constructOrigFormat(verb rune) (format string) {
 	return format
 }
 
+// punct writes structural punctuation such as parens, brackets, and colons.
+func (f *formatState) punct(b []byte) {
+	writePunct(f.fs, f.cs, b)
+}
+
 // unpackValue returns values inside of non-nil interfaces when possible and
 // ensures that types for values which have been unpacked from an interface
 // are displayed when the show types flag is also set.
@@ -155,29 +181,29 @@ func (f *formatState) formatPtr(v reflect.Value) {
 
 	// Display type or indirection level depending on flags.
 	if showTypes && !f.ignoreNextType {
-		f.fs.Write(openParenBytes)
+		f.punct(openParenBytes)
 		f.fs.Write(bytes.Repeat(asteriskBytes, indirects))
-		f.fs.Write([]byte(ve.Type().String()))
-		f.fs.Write(closeParenBytes)
+		f.fs.Write([]byte(f.cs.typeName(ve.Type().String())))
+		f.punct(closeParenBytes)
 	} else {
 		if nilFound || cycleFound {
 			indirects += strings.Count(ve.Type().String(), "*")
 		}
-		f.fs.Write(openAngleBytes)
+		f.punct(openAngleBytes)
 		f.fs.Write([]byte(strings.Repeat("*", indirects)))
-		f.fs.Write(closeAngleBytes)
+		f.punct(closeAngleBytes)
 	}
 
 	// Display pointer information depending on flags.
 	if f.fs.Flag('+') && (len(pointerChain) > 0) {
-		f.fs.Write(openParenBytes)
+		f.punct(openParenBytes)
 		for i, addr := range pointerChain {
 			if i > 0 {
-				f.fs.Write(pointerChainBytes)
+				f.punct(pointerChainBytes)
 			}
-			printHexPtr(f.fs, addr)
+			f.ids.write(f.fs, addr)
 		}
-		f.fs.Write(closeParenBytes)
+		f.punct(closeParenBytes)
 	}
 
 	// Display dereferenced value.
@@ -199,30 +225,113 @@ func (f *formatState) formatPtr(v reflect.Value) {
 // dealing with and formats it appropriately.  It is a recursive function,
 // however circular data structures are detected and handled properly.
 func (f *formatState) format(v reflect.Value) {
+	if f.cs.ReflectValues {
+		v = heldValue(v)
+	}
+
 	// Handle invalid reflect values immediately.
 	kind := v.Kind()
 	if kind == reflect.Invalid {
 		f.fs.Write(invalidAngleBytes)
 		return
 	}
+	keyName := f.keyName
+	f.keyName = ""
+	if f.redactor.redacts(keyName, v.Type()) {
+		f.forceMethods = false
+		printRedacted(f.fs, f.cs, redactedText(v))
+		return
+	}
+	if text, ok := f.cs.customRedaction(f.path, keyName, v); ok {
+		f.forceMethods = false
+		printRedacted(f.fs, f.cs, text)
+		return
+	}
+	if f.cs.isSkippedType(v.Type()) {
+		f.forceMethods = false
+		f.formatSkipped(v)
+		return
+	}
+
+	// Lower the depth limit within values of types with their own limit.
+	if limit := f.cs.typeMaxDepth(f.maxDepth, v.Type(), f.depth); limit != f.maxDepth {
+		defer func(maxDepth int) { f.maxDepth = maxDepth }(f.maxDepth)
+		f.maxDepth = limit
+	}
+
+	// Render values of types with a registered formatter with it.
+	if write, ok := registeredFormatter(f.cs, v); ok {
+		if !f.ignoreNextType && f.fs.Flag('#') {
+			f.punct(openParenBytes)
+			f.fs.Write([]byte(f.cs.typeName(v.Type().String())))
+			f.punct(closeParenBytes)
+		}
+		f.ignoreNextType = false
+		f.forceMethods = false
+		write(f.fs)
+		return
+	}
+
+	// Display values of well-known types by what they hold.
+	if f.cs.WellKnownTypes {
+		if inner, valid, ok := sqlNull(v); ok {
+			if !f.ignoreNextType && f.fs.Flag('#') {
+				f.punct(openParenBytes)
+				f.fs.Write([]byte(f.cs.typeName(v.Type().String())))
+				f.punct(closeParenBytes)
+			}
+			f.forceMethods = false
+			if !valid {
+				f.ignoreNextType = false
+				withColor(f.fs, sqlNullBytes, f.cs.Color.Keyword...)
+				return
+			}
+			f.ignoreNextType = true
+			f.format(inner)
+			return
+		}
+	}
+
+	// Display the types of the sync/atomic package as their loaded value.
+	if f.cs.AtomicValues {
+		if loaded, ok := atomicValue(v); ok {
+			if !f.ignoreNextType && f.fs.Flag('#') {
+				f.punct(openParenBytes)
+				f.fs.Write([]byte(f.cs.typeName(v.Type().String())))
+				f.punct(closeParenBytes)
+			}
+			f.forceMethods = false
+			loaded = f.unpackValue(loaded)
+			f.ignoreNextType = isScalarKind(loaded.Kind())
+			f.format(loaded)
+			return
+		}
+	}
 
 	// Handle pointers specially.
 	if kind == reflect.Ptr {
 		f.formatPtr(v)
 		return
 	}
+	forceMethods := f.forceMethods
+	f.forceMethods = false
 
 	// Print type information unless already handled elsewhere.
 	if !f.ignoreNextType && f.fs.Flag('#') {
-		f.fs.Write(openParenBytes)
-		f.fs.Write([]byte(v.Type().String()))
-		f.fs.Write(closeParenBytes)
+		f.punct(openParenBytes)
+		f.fs.Write([]byte(f.cs.typeName(v.Type().String())))
+		f.punct(closeParenBytes)
 	}
 	f.ignoreNextType = false
 
+	// Defer to fmt for types which are configured to be rendered by it.
+	if kind != reflect.Interface && handleFmtFallback(f.cs, f.fs, v) {
+		return
+	}
+
 	// Call Stringer/error interfaces if they exist and the handle methods
 	// flag is enabled.
-	if !f.cs.DisableMethods {
+	if !f.cs.DisableMethods || forceMethods {
 		if (kind != reflect.Invalid) && (kind != reflect.Interface) {
 			if handled := handleMethods(f.cs, f.fs, v); handled {
 				return
@@ -236,13 +345,14 @@ func (f *formatState) format(v reflect.Value) {
 		// been handled above.
 
 	case reflect.Bool:
-		printBool(f.fs, f.cs, v.Bool())
+		// The Symbols option only applies to Dump.
+		withColor(f.fs, []byte(strconv.FormatBool(v.Bool())), f.cs.Color.Bool...)
 
 	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
-		printNumber(f.fs, f.cs, v.Int())
+		printInt(f.fs, f.cs, v.Int(), f.cs.intBase(v.Type(), ""))
 
 	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
-		printNumber(f.fs, f.cs, v.Uint())
+		printInt(f.fs, f.cs, v.Uint(), f.cs.intBase(v.Type(), ""))
 
 	case reflect.Float32:
 		printFloat(f.fs, f.cs, v.Float(), 32)
@@ -258,28 +368,42 @@ func (f *formatState) format(v reflect.Value) {
 			f.fs.Write(nilAngleBytes)
 			break
 		}
+		if f.visited[newVisitKey(v)] {
+			f.fs.Write(circularShortBytes)
+			break
+		}
+		f.visited[newVisitKey(v)] = true
+		defer delete(f.visited, newVisitKey(v))
 		fallthrough
 
 	case reflect.Array:
-		f.fs.Write(openBracketBytes)
+		f.punct(openBracketBytes)
 		f.depth++
-		if (f.cs.MaxDepth != 0) && (f.depth > f.cs.MaxDepth) {
+		if f.depth > f.maxDepth {
 			f.fs.Write(maxShortBytes)
 		} else {
-			numEntries := v.Len()
-			for i := 0; i < numEntries; i++ {
-				if i > 0 {
+			win := f.cs.limitElements(v.Len())
+			for j := 0; j < win.len(); {
+				if j > 0 {
 					f.fs.Write(spaceBytes)
 				}
+				i := win.index(j)
+				run := f.cs.runLength(v, i, win.end(j))
 				f.ignoreNextType = true
 				f.format(f.unpackValue(v.Index(i)))
+				writeRunCount(f.fs, f.cs, run)
+				if j += run; j == win.head {
+					f.more(win.omitted())
+				}
 			}
 		}
 		f.depth--
-		f.fs.Write(closeBracketBytes)
+		f.punct(closeBracketBytes)
 
 	case reflect.String:
-		f.fs.Write([]byte(v.String()))
+		s, removed := f.cs.truncateString(v.String())
+		f.fs.Write([]byte(s))
+		writeTruncation(f.fs, f.cs, removed)
 
 	case reflect.Interface:
 		// The only time we should get here is for nil interfaces due to
@@ -299,57 +423,77 @@ func (f *formatState) format(v reflect.Value) {
 			break
 		}
 
-		f.fs.Write(openMapBytes)
+		// Maps which contain themselves through interfaces are circular
+		// without involving a pointer.
+		if f.visited[newVisitKey(v)] {
+			f.fs.Write(circularShortBytes)
+			break
+		}
+		f.visited[newVisitKey(v)] = true
+		defer delete(f.visited, newVisitKey(v))
+
+		f.punct(openMapBytes)
 		f.depth++
-		if (f.cs.MaxDepth != 0) && (f.depth > f.cs.MaxDepth) {
+		if f.depth > f.maxDepth {
 			f.fs.Write(maxShortBytes)
 		} else {
 			keys := v.MapKeys()
 			if f.cs.SortKeys {
 				sortValues(keys, f.cs)
 			}
-			for i, key := range keys {
+			win := f.cs.limitElements(len(keys))
+			for i, key := range win.keys(keys) {
 				if i > 0 {
 					f.fs.Write(spaceBytes)
 				}
 				f.ignoreNextType = true
 				f.format(f.unpackValue(key))
-				f.fs.Write(colonBytes)
+				f.punct(colonBytes)
 				f.ignoreNextType = true
+				f.keyName = mapKeyName(key)
 				f.format(f.unpackValue(v.MapIndex(key)))
+				if i+1 == win.head {
+					f.more(win.omitted())
+				}
 			}
 		}
 		f.depth--
-		f.fs.Write(closeMapBytes)
+		f.punct(closeMapBytes)
 
 	case reflect.Struct:
-		numFields := v.NumField()
-		f.fs.Write(openBraceBytes)
+		f.punct(openBraceBytes)
 		f.depth++
-		if (f.cs.MaxDepth != 0) && (f.depth > f.cs.MaxDepth) {
+		if f.depth > f.maxDepth {
 			f.fs.Write(maxShortBytes)
 		} else {
-			vt := v.Type()
-			for i := 0; i < numFields; i++ {
-				if i > 0 {
+			path := f.path
+			fields, _ := f.cs.shownFields(v, f.filter, f.redactor, path)
+			for j, sf := range fields {
+				if j > 0 {
 					f.fs.Write(spaceBytes)
 				}
-				vtf := vt.Field(i)
 				if f.fs.Flag('+') || f.fs.Flag('#') {
-					f.fs.Write([]byte(vtf.Name))
-					f.fs.Write(colonBytes)
+					f.fs.Write([]byte(sf.name))
+					f.punct(colonBytes)
 				}
-				f.format(f.unpackValue(v.Field(i)))
+				opts := spewTagOptions(sf.field.Tag)
+				opts.redact = opts.redact || f.redactor.redacts(sf.field.Name, sf.field.Type)
+				f.path = sf.path
+				f.formatField(f.unpackValue(sf.value), opts)
 			}
+			f.path = path
 		}
 		f.depth--
-		f.fs.Write(closeBraceBytes)
+		f.punct(closeBraceBytes)
 
 	case reflect.Uintptr:
-		printHexPtr(f.fs, uintptr(v.Uint()))
+		writeAddress(f.fs, f.cs, nil, uintptr(v.Uint()))
+
+	case reflect.UnsafePointer:
+		writeAddress(f.fs, f.cs, f.ids, v.Pointer())
 
-	case reflect.UnsafePointer, reflect.Chan, reflect.Func:
-		printHexPtr(f.fs, v.Pointer())
+	case reflect.Chan, reflect.Func:
+		f.ids.write(f.fs, v.Pointer())
 
 	// There were not any other types at the time this code was written, but
 	// fall back to letting the default fmt package handle it if any get added.
@@ -383,14 +527,23 @@ func (f *formatState) Format(fs fmt.State, verb rune) {
 		return
 	}
 
+	if f.cs.PointerIDs {
+		f.ids = &pointerIDs{}
+	}
 	f.format(reflect.ValueOf(f.value))
 }
 
 // newFormatter is a helper function to consolidate the logic from the various
 // public methods which take varying config states.
 func newFormatter(cs *ConfigState, v interface{}) fmt.Formatter {
-	fs := &formatState{value: v, cs: cs}
+	cs = cs.resolve()
+	if cs.DisableFormatterColor {
+		cs = cs.plain()
+	}
+	fs := &formatState{value: v, cs: cs, maxDepth: cs.rootMaxDepth(),
+		filter: newFieldFilter(cs), redactor: newRedactor(cs)}
 	fs.pointers = make(map[uintptr]int)
+	fs.visited = make(map[visitKey]bool)
 	return fs
 }
 
@@ -412,5 +565,5 @@ use of the custom formatter by calling one of the convenience functions such as
 Printf, Println, or Fprintf.
 */
 func NewFormatter(v interface{}) fmt.Formatter {
-	return newFormatter(&Config, v)
+	return newFormatter(global(), v)
 }
@@ -69,6 +69,7 @@ package spew_test
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"unsafe"
@@ -1546,4 +1547,20 @@ var _ = Describe("Format Tests", func() {
 		expected = "map[error: 1:1 error: 2:2 error: 3:3]"
 		Expect(s).To(Equal(expected))
 	})
+
+	It("detects circular maps and slices without pointers", func() {
+		m := map[string]interface{}{}
+		m["self"] = m
+		Expect(spew.Sprint(m)).To(Equal("map[self:<shown>]"))
+
+		sl := []interface{}{nil}
+		sl[0] = sl
+		Expect(spew.Sprint(sl)).To(Equal("[<shown>]"))
+	})
+
+	It("renders FmtFallbackTypes with fmt", func() {
+		cs := spew.NewTestConfig()
+		cs.FmtFallbackTypes = []reflect.Type{reflect.TypeOf(fmtCrafted{})}
+		Expect(cs.Sprintf("%v", []fmtCrafted{{x: 1}})).To(Equal("[crafted<1 v true>]"))
+	})
 })
@@ -0,0 +1,96 @@
+package spew
+
+import (
+	"io"
+	"reflect"
+	"sync"
+)
+
+// typeFormatters houses the formatters registered with RegisterFormatter.
+var typeFormatters = struct {
+	sync.RWMutex
+	m map[reflect.Type]func(w io.Writer, v interface{})
+}{m: make(map[reflect.Type]func(w io.Writer, v interface{}))}
+
+/*
+RegisterFormatter registers fn to render the values of the type typ in place of
+their usual output for every ConfigState, so the rendering of specific types
+can be fully overridden without forking the dump logic.  For example:
+
+	spew.RegisterFormatter(reflect.TypeOf((*rsa.PrivateKey)(nil)),
+		func(w io.Writer, v interface{}) {
+			fmt.Fprintf(w, "<private key, %d bits>", v.(*rsa.PrivateKey).N.BitLen())
+		})
+
+The type is matched exactly, so a formatter registered for a pointer type is
+used for the pointers themselves, including nil ones, while one registered for
+the type they point to is used for the values behind them.  The type of the
+value is still displayed ahead of the formatter's output by Dump and by the %#v
+verb of Formatter.  Formatters registered with ConfigState.RegisterFormatter
+take precedence.  Registering a nil fn removes the formatter for typ.
+*/
+func RegisterFormatter(typ reflect.Type, fn func(w io.Writer, v interface{})) {
+	typeFormatters.Lock()
+	defer typeFormatters.Unlock()
+	if fn == nil {
+		delete(typeFormatters.m, typ)
+		return
+	}
+	typeFormatters.m[typ] = fn
+}
+
+// RegisterFormatter registers fn to render the values of the type typ in place
+// of their usual output for c only.  It takes precedence over the formatters
+// registered with the package level RegisterFormatter, which has the details.
+// It must not be called concurrently with the use of c.
+func (c *ConfigState) RegisterFormatter(typ reflect.Type, fn func(w io.Writer, v interface{})) {
+	if fn == nil {
+		delete(c.formatters, typ)
+		return
+	}
+	if c.formatters == nil {
+		c.formatters = make(map[reflect.Type]func(w io.Writer, v interface{}))
+	}
+	c.formatters[typ] = fn
+}
+
+// lookupFormatter returns the formatter registered for typ with c, or with
+// RegisterFormatter when c has none, falling back to the formatters for times
+// and well-known types when the HumanTimes and WellKnownTypes options are set.
+func (c *ConfigState) lookupFormatter(typ reflect.Type) (func(w io.Writer, v interface{}), bool) {
+	if fn, ok := c.formatters[typ]; ok {
+		return fn, true
+	}
+	typeFormatters.RLock()
+	fn, ok := typeFormatters.m[typ]
+	typeFormatters.RUnlock()
+	if !ok && c.HumanTimes {
+		fn, ok = c.timeFormatter(typ)
+	}
+	if !ok && c.WellKnownTypes {
+		fn, ok = c.wellKnownFormatter(typ)
+	}
+	return fn, ok
+}
+
+// registeredFormatter returns a function writing v with the formatter
+// registered for its type, and false when there is none.  Values which can't
+// be converted to an interface are not handled when the unsafe package is not
+// available, except those of the well-known types read by reflection.  Panics
+// in the formatter are written by catchPanic.
+func registeredFormatter(cs *ConfigState, v reflect.Value) (func(w io.Writer), bool) {
+	fn, ok := cs.lookupFormatter(v.Type())
+	if !ok {
+		return nil, false
+	}
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return cs.wellKnownValue(v)
+		}
+		v = unsafeReflectValue(v)
+	}
+	return func(w io.Writer) {
+		defer catchPanic(cs, w, v, "formatter")
+		fn(w, v.Interface())
+	}, true
+}
@@ -0,0 +1,70 @@
+package spew_test
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type formattersKey struct {
+	Bits   int
+	secret []byte
+}
+
+type formattersGlobal struct {
+	N int
+}
+
+var _ = Describe("Formatter Registry Tests", func() {
+	keyFormatter := func(w io.Writer, v interface{}) {
+		fmt.Fprintf(w, "<private key, %d bits>", v.(*formattersKey).Bits)
+	}
+
+	It("renders registered pointer types with their formatter", func() {
+		cs := spew.NewTestConfig()
+		cs.RegisterFormatter(reflect.TypeOf((*formattersKey)(nil)), keyFormatter)
+		v := struct{ Key *formattersKey }{&formattersKey{Bits: 2048}}
+		Expect(cs.Sdump(v)).To(Equal("(struct { Key *spew_test.formattersKey }) {\n" +
+			"  Key: (*spew_test.formattersKey) <private key, 2048 bits>\n" +
+			"}\n"))
+		Expect(cs.Sprintf("%v", v)).To(Equal("{<private key, 2048 bits>}"))
+		Expect(cs.Sprintf("%#v", v.Key)).To(Equal("(*spew_test.formattersKey)<private key, 2048 bits>"))
+	})
+
+	It("does not affect other configurations or clones made before", func() {
+		cs := spew.NewTestConfig()
+		clone := cs.Clone()
+		cs.RegisterFormatter(reflect.TypeOf(formattersKey{}), func(w io.Writer, v interface{}) {
+			io.WriteString(w, "<key>")
+		})
+		Expect(cs.Sdump(formattersKey{})).To(Equal("(spew_test.formattersKey) <key>\n"))
+		Expect(clone.Sdump(formattersKey{})).NotTo(ContainSubstring("<key>"))
+		Expect(cs.Clone().Sdump(formattersKey{})).To(ContainSubstring("<key>"))
+	})
+
+	It("uses the formatters registered globally", func() {
+		typ := reflect.TypeOf(formattersGlobal{})
+		spew.RegisterFormatter(typ, func(w io.Writer, v interface{}) {
+			fmt.Fprintf(w, "global %d", v.(formattersGlobal).N)
+		})
+		defer spew.RegisterFormatter(typ, nil)
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(formattersGlobal{1})).To(Equal("(spew_test.formattersGlobal) global 1\n"))
+		cs.RegisterFormatter(typ, func(w io.Writer, v interface{}) {
+			io.WriteString(w, "local")
+		})
+		Expect(cs.Sdump(formattersGlobal{1})).To(Equal("(spew_test.formattersGlobal) local\n"))
+	})
+
+	It("catches panics in formatters", func() {
+		cs := spew.NewTestConfig()
+		cs.RegisterFormatter(reflect.TypeOf(formattersGlobal{}), func(w io.Writer, v interface{}) {
+			panic("boom")
+		})
+		Expect(cs.Sdump(formattersGlobal{})).To(Equal("(spew_test.formattersGlobal) (PANIC: boom)\n"))
+	})
+})
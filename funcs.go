@@ -0,0 +1,36 @@
+package spew
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+)
+
+// funcName returns the name of the function v and the file and line of its
+// entry point, as in github.com/acme/pkg.Handler at handlers.go:42, and false
+// when it can't be resolved.
+func funcName(v reflect.Value) (string, bool) {
+	pc := v.Pointer()
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "", false
+	}
+	file, line := fn.FileLine(fn.Entry())
+	if file == "" {
+		return fn.Name(), true
+	}
+	return fn.Name() + " at " + filepath.Base(file) + ":" + strconv.Itoa(line), true
+}
+
+// dumpFunc writes the function v, which is displayed by name when FuncNames
+// is set and by address otherwise.
+func (d *dumpState) dumpFunc(v reflect.Value) {
+	if d.cs.FuncNames && !v.IsNil() {
+		if name, ok := funcName(v); ok {
+			d.w.Write([]byte(name))
+			return
+		}
+	}
+	d.ids.write(d.w, v.Pointer())
+}
@@ -0,0 +1,35 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func funcNamesHandler(n int) error {
+	return nil
+}
+
+var _ = Describe("Func Names Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.FuncNames = true
+		return cs
+	}
+
+	It("displays functions by name and declaration", func() {
+		Expect(newConfig().Sdump(funcNamesHandler)).To(MatchRegexp(
+			`^\(func\(int\) error\) github\.com/ehowe/rainbow-spew_test\.funcNamesHandler at funcs_test\.go:\d+\n$`))
+	})
+
+	It("displays closures by their generated name", func() {
+		f := func() {}
+		Expect(newConfig().Sdump(f)).To(MatchRegexp(
+			`^\(func\(\)\) github\.com/ehowe/rainbow-spew_test\.init\.func[\d.]+ at funcs_test\.go:\d+\n$`))
+	})
+
+	It("leaves nil functions unchanged", func() {
+		var f func()
+		Expect(newConfig().Sdump(f)).To(Equal("(func()) <nil>\n"))
+	})
+})
@@ -0,0 +1,34 @@
+package spew
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// qualifiedNameRE matches the names of types qualified by the import path of
+// their package, which the names of instantiated generic types use for their
+// type arguments, as in pkg.Box[github.com/very/long/path.Thing].
+var qualifiedNameRE = regexp.MustCompile(`[\w.~-]+(?:/[\w.~-]+)+`)
+
+// shortGenericName returns the type name with the package paths inside its
+// type arguments elided to their last element, as in
+// pkg.Box[path.Thing].
+func shortGenericName(name string) string {
+	return qualifiedNameRE.ReplaceAllStringFunc(name, func(qualified string) string {
+		dot := strings.LastIndexByte(qualified, '.')
+		if dot < strings.LastIndexByte(qualified, '/') {
+			return qualified
+		}
+		return path.Base(qualified[:dot]) + qualified[dot:]
+	})
+}
+
+// typeName returns the type name to display according to the
+// ShortGenericNames option.
+func (c *ConfigState) typeName(name string) string {
+	if !c.ShortGenericNames || !strings.Contains(name, "/") {
+		return name
+	}
+	return shortGenericName(name)
+}
@@ -0,0 +1,41 @@
+package spew_test
+
+import (
+	"encoding/json"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type genericsBox[T any] struct {
+	V T
+}
+
+type genericsPair[K comparable, V any] struct {
+	Key K
+	Val V
+}
+
+type genericsThing struct{}
+
+var _ = Describe("Generic Names Tests", func() {
+	It("elides package paths inside type arguments", func() {
+		cs := spew.NewTestConfig()
+		cs.ShortGenericNames = true
+		v := map[string]genericsBox[genericsThing]{"a": {}}
+		Expect(cs.Sdump(v)).To(Equal("(map[string]spew_test.genericsBox[rainbow-spew_test.genericsThing]) (len: 1) {\n" +
+			"  (string) (len: 1) \"a\": (spew_test.genericsBox[rainbow-spew_test.genericsThing]) {\n" +
+			"    V: (spew_test.genericsThing) {\n" +
+			"    }\n" +
+			"  }\n" +
+			"}\n"))
+		Expect(cs.Sprintf("%#v", genericsPair[json.Number, *genericsThing]{})).To(Equal(
+			"(spew_test.genericsPair[json.Number,*rainbow-spew_test.genericsThing]){Key:(json.Number) Val:(*spew_test.genericsThing)<nil>}"))
+	})
+
+	It("displays full names by default", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(genericsBox[json.Number]{})).To(HavePrefix("(spew_test.genericsBox[encoding/json.Number]) {\n"))
+	})
+})
@@ -0,0 +1,53 @@
+package spew
+
+import "sync/atomic"
+
+// globalConfig houses the configuration installed by SetConfig.
+var globalConfig atomic.Pointer[ConfigState]
+
+// global returns the configuration used by the top-level functions, which is
+// the one installed by SetConfig, or Config when there is none.
+func global() *ConfigState {
+	if cs := globalConfig.Load(); cs != nil {
+		return cs
+	}
+	return &Config
+}
+
+// CurrentConfig returns a copy of the configuration used by the top-level
+// functions, which may be modified freely.
+func CurrentConfig() *ConfigState {
+	return global().Clone()
+}
+
+// SetConfig atomically replaces the configuration used by the top-level
+// functions with a copy of cs, so it can be changed while other goroutines
+// are dumping values without a data race, unlike modifying Config.  Changes
+// to Config have no effect once SetConfig has been called, until it is
+// called with nil to return to using Config.
+func SetConfig(cs *ConfigState) {
+	if cs == nil {
+		globalConfig.Store(nil)
+		return
+	}
+	globalConfig.Store(cs.Clone())
+}
+
+// UpdateConfig atomically replaces the configuration used by the top-level
+// functions with a copy modified by update, such as to raise MaxDepth while
+// debugging.  update may be called more than once when other goroutines
+// update the configuration concurrently, so it should not have side effects.
+func UpdateConfig(update func(c *ConfigState)) {
+	for {
+		old := globalConfig.Load()
+		cs := old
+		if cs == nil {
+			cs = &Config
+		}
+		cs = cs.Clone()
+		update(cs)
+		if globalConfig.CompareAndSwap(old, cs) {
+			return
+		}
+	}
+}
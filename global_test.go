@@ -0,0 +1,55 @@
+package spew_test
+
+import (
+	"sync"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Global Config Tests", func() {
+	BeforeEach(func() {
+		DeferCleanup(func() { spew.SetConfig(nil) })
+	})
+
+	It("uses the configuration installed by SetConfig", func() {
+		spew.SetConfig(spew.NewTestConfig())
+		Expect(spew.Sdump([]int{1})).To(Equal("([]int) (len: 1 cap: 1) {\n  (int) 1\n}\n"))
+		Expect(spew.CurrentConfig().Indent).To(Equal("  "))
+
+		spew.SetConfig(nil)
+		Expect(spew.CurrentConfig().Indent).To(Equal(spew.Config.Indent))
+	})
+
+	It("updates a copy of the configuration", func() {
+		spew.SetConfig(spew.NewTestConfig())
+		spew.UpdateConfig(func(c *spew.ConfigState) { c.DisableCapacities = true })
+		Expect(spew.Sdump([]int{1})).To(Equal("([]int) (len: 1) {\n  (int) 1\n}\n"))
+	})
+
+	It("doesn't share the configuration passed to SetConfig", func() {
+		cs := spew.NewTestConfig()
+		spew.SetConfig(cs)
+		cs.Indent = "\t"
+		Expect(spew.CurrentConfig().Indent).To(Equal("  "))
+	})
+
+	It("changes the configuration while dumping", func() {
+		spew.SetConfig(spew.NewTestConfig())
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				spew.Sdump(map[string]int{"a": 1})
+			}()
+			go func() {
+				defer wg.Done()
+				spew.UpdateConfig(func(c *spew.ConfigState) { c.MaxDepth++ })
+			}()
+		}
+		wg.Wait()
+		Expect(spew.CurrentConfig().MaxDepth).To(Equal(4))
+	})
+})
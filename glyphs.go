@@ -0,0 +1,66 @@
+package spew
+
+import (
+	"io"
+	"reflect"
+)
+
+// GlyphMode specifies which glyphs, if any, are used to decorate the sections
+// of Dump output.
+type GlyphMode int
+
+const (
+	// GlyphsNone disables glyph decorations.  This is the default.
+	GlyphsNone GlyphMode = iota
+
+	// GlyphsNerdFont decorates sections with icons from the Nerd Fonts
+	// patched font set, such as a table before maps and an arrow before
+	// pointers.  The glyphs only render properly with a patched font.
+	GlyphsNerdFont
+
+	// GlyphsASCII decorates sections with pure ASCII equivalents of the
+	// Nerd Fonts glyphs for terminals without a patched font.
+	GlyphsASCII
+)
+
+// nerdFontGlyphs houses the Nerd Fonts (Font Awesome range) glyphs for each
+// decorated kind.
+var nerdFontGlyphs = map[reflect.Kind]string{
+	reflect.Map:    "",
+	reflect.Slice:  "",
+	reflect.Array:  "",
+	reflect.Struct: "",
+	reflect.Ptr:    "",
+	reflect.Chan:   "",
+	reflect.Func:   "",
+}
+
+// asciiGlyphs houses the ASCII fallbacks for nerdFontGlyphs.
+var asciiGlyphs = map[reflect.Kind]string{
+	reflect.Map:    "{}",
+	reflect.Slice:  "[]",
+	reflect.Array:  "[]",
+	reflect.Struct: "{.}",
+	reflect.Ptr:    "->",
+	reflect.Chan:   "<-",
+	reflect.Func:   "fn",
+}
+
+// writeGlyph outputs the glyph for kind followed by a space to Writer w
+// according to the Glyphs option.  Nothing is written for kinds which are not
+// decorated.
+func writeGlyph(w io.Writer, cs *ConfigState, kind reflect.Kind) {
+	var glyphs map[reflect.Kind]string
+	switch cs.Glyphs {
+	case GlyphsNerdFont:
+		glyphs = nerdFontGlyphs
+	case GlyphsASCII:
+		glyphs = asciiGlyphs
+	default:
+		return
+	}
+	if glyph, ok := glyphs[kind]; ok {
+		w.Write([]byte(glyph))
+		w.Write(spaceBytes)
+	}
+}
@@ -0,0 +1,74 @@
+package spew
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HexdumpLayout configures the layout of the hexdumps Dump displays byte
+// arrays and slices as.  The zero value is the layout of hexdump -C, with 16
+// bytes per line in groups of 8 along with offsets and an ASCII gutter.
+type HexdumpLayout struct {
+	// BytesPerLine is the number of bytes displayed on each line.  The
+	// default, 0, means 16.
+	BytesPerLine int
+
+	// GroupSize is the number of bytes in each group of a line, with groups
+	// separated by an extra space.  The default, 0, means 8.  Grouping is
+	// disabled when it is at least BytesPerLine.
+	GroupSize int
+
+	// HideASCII specifies whether to omit the gutter displaying the
+	// printable ASCII characters of each line.
+	HideASCII bool
+
+	// HideOffsets specifies whether to omit the offset of the first byte of
+	// each line.
+	HideOffsets bool
+}
+
+// hexdump returns the hexdump of buf in layout l.  Each line of the hexdump,
+// including the last, ends with a newline.
+func hexdump(buf []byte, l HexdumpLayout) string {
+	perLine, group := l.BytesPerLine, l.GroupSize
+	if perLine <= 0 {
+		perLine = 16
+	}
+	if group <= 0 {
+		group = 8
+	}
+
+	var b strings.Builder
+	for off := 0; off < len(buf); off += perLine {
+		line := buf[off:min(off+perLine, len(buf))]
+		if !l.HideOffsets {
+			fmt.Fprintf(&b, "%08x  ", off)
+		}
+		var hexCols strings.Builder
+		for i := 0; i < perLine; i++ {
+			if i < len(line) {
+				fmt.Fprintf(&hexCols, "%02x ", line[i])
+			} else {
+				hexCols.WriteString("   ")
+			}
+			if (i+1)%group == 0 && i+1 < perLine {
+				hexCols.WriteByte(' ')
+			}
+		}
+		if l.HideASCII {
+			b.WriteString(strings.TrimRight(hexCols.String(), " "))
+			b.WriteByte('\n')
+			continue
+		}
+		b.WriteString(hexCols.String())
+		b.WriteString(" |")
+		for _, c := range line {
+			if c < 32 || c > 126 {
+				c = '.'
+			}
+			b.WriteByte(c)
+		}
+		b.WriteString("|\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package spew_test
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hexdump Tests", func() {
+	It("matches hexdump -C by default", func() {
+		cfg := spew.ConfigState{Indent: "", DisableCapacities: true}
+		for n := 1; n <= 40; n++ {
+			buf := make([]byte, n)
+			for i := range buf {
+				buf[i] = byte(i*7 + 30)
+			}
+			want := fmt.Sprintf("([]uint8) (len: %d) {\n%s}\n", n, hex.Dump(buf))
+			Expect(cfg.Sdump(buf)).To(Equal(want), "length %d", n)
+		}
+	})
+
+	It("uses the configured layout", func() {
+		cfg := spew.NewTestConfig()
+		cfg.DisableCapacities = true
+		cfg.Hexdump = spew.HexdumpLayout{BytesPerLine: 4, GroupSize: 2}
+		Expect(cfg.Sdump([]byte("abcdef"))).To(Equal(`([]uint8) (len: 6) {
+  00000000  61 62  63 64  |abcd|
+  00000004  65 66         |ef|
+}
+`))
+
+		cfg.Hexdump = spew.HexdumpLayout{BytesPerLine: 4, HideASCII: true, HideOffsets: true}
+		Expect(cfg.Sdump([]byte("abcdef"))).To(Equal(`([]uint8) (len: 6) {
+  61 62 63 64
+  65 66
+}
+`))
+	})
+})
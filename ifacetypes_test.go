@@ -0,0 +1,53 @@
+package spew_test
+
+import (
+	"errors"
+	"io"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type ifaceTypesCode int
+
+func (c ifaceTypesCode) Error() string { return "code" }
+
+type ifaceTypesReader struct {
+	N int
+}
+
+func (r *ifaceTypesReader) Read(p []byte) (int, error) { return 0, io.EOF }
+
+var _ = Describe("Interface Types Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.ShowInterfaceTypes = true
+		cs.DisablePointerAddresses = true
+		cs.DisableMethods = true
+		return cs
+	}
+
+	It("displays the static and dynamic types of interface fields", func() {
+		v := struct {
+			R   io.Reader
+			Err error
+			Any interface{}
+			Nil error
+		}{R: &ifaceTypesReader{2}, Err: ifaceTypesCode(3), Any: 1}
+		Expect(newConfig().Sdump(v)).To(Equal("(struct { R io.Reader; Err error; Any interface {}; Nil error }) {\n" +
+			"  R: (io.Reader)(*spew_test.ifaceTypesReader)({\n" +
+			"    N: (int) 2\n" +
+			"  }),\n" +
+			"  Err: (error)(spew_test.ifaceTypesCode) 3,\n" +
+			"  Any: (int) 1,\n" +
+			"  Nil: (error) <nil>\n" +
+			"}\n"))
+	})
+
+	It("displays the static types of slice elements", func() {
+		v := []error{errors.New("x")}
+		Expect(newConfig().Sdump(v)).To(HavePrefix("([]error) (len: 1 cap: 1) {\n" +
+			"  (error)(*errors.errorString)({\n"))
+	})
+})
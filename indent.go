@@ -0,0 +1,58 @@
+package spew
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tabWidth is the number of columns between the tab stops assumed when
+// measuring the width of output.
+const tabWidth = 8
+
+// isUnsafeIndentRune returns whether r would corrupt the layout of the output
+// when written as part of an indent string, which is the case for control
+// characters other than tabs, such as newlines and the escape character.
+func isUnsafeIndentRune(r rune) bool {
+	return unicode.IsControl(r) && r != '\t'
+}
+
+// sanitizeIndent returns the indent string s with its unsafe characters
+// escaped as they would be in a Go string literal.
+func sanitizeIndent(s string) string {
+	if !strings.ContainsFunc(s, isUnsafeIndentRune) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if !isUnsafeIndentRune(r) {
+			b.WriteRune(r)
+			continue
+		}
+		q := strconv.QuoteRune(r)
+		b.WriteString(q[1 : len(q)-1])
+	}
+	return b.String()
+}
+
+// indentLevel returns the indent string for the zero-based indentation level
+// i, which is taken from IndentLevels when it is set and from Indent
+// otherwise.
+func (c *ConfigState) indentLevel(i int) string {
+	if n := len(c.IndentLevels); n > 0 {
+		return sanitizeIndent(c.IndentLevels[min(i, n-1)])
+	}
+	return sanitizeIndent(c.Indent)
+}
+
+// indentation returns the indentation for depth, which is the indent strings
+// of each level up to depth.  The indentation of each depth is cached.
+func (d *dumpState) indentation(depth int) string {
+	if len(d.indents) == 0 {
+		d.indents = []string{""}
+	}
+	for n := len(d.indents); n <= depth; n++ {
+		d.indents = append(d.indents, d.indents[n-1]+d.cs.indentLevel(n-1))
+	}
+	return d.indents[depth]
+}
@@ -0,0 +1,64 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type indentTester struct {
+	A struct{ B []int }
+}
+
+var _ = Describe("Indent Tests", func() {
+	v := indentTester{}
+	v.A.B = []int{1}
+
+	It("uses a string for each indentation level", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableCapacities = true
+		cs.IndentLevels = []string{"\t", "│  "}
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.indentTester) {\n" +
+			"\tA: (struct { B []int }) {\n" +
+			"\t│  B: ([]int) (len: 1) {\n" +
+			"\t│  │  (int) 1\n" +
+			"\t│  }\n" +
+			"\t}\n" +
+			"}\n"))
+	})
+
+	It("escapes control characters in indent strings", func() {
+		cs := spew.NewTestConfig()
+		cs.Indent = "\n\x1b"
+		Expect(cs.Sdump(struct{ A int }{})).To(Equal("(struct { A int }) {\n" +
+			"\\n\\x1bA: (int) 0\n" +
+			"}\n"))
+	})
+
+	It("indents hexdumps with indent strings", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableCapacities = true
+		cs.IndentLevels = []string{"| "}
+		Expect(cs.Sdump([]byte("ab"))).To(Equal("([]uint8) (len: 2) {\n" +
+			"| 00000000  61 62                                             |ab|\n" +
+			"}\n"))
+	})
+
+	It("measures tabs to the next tab stop when wrapping", func() {
+		cs := spew.NewTestConfig()
+		cs.Indent = "\t"
+		cs.MaxWidth = 24
+		Expect(cs.Sdump(struct{ S string }{"abcdefghijklmnopqrstuvwxyz"})).To(Equal("(struct { S string }) {\n" +
+			"\tS: (string) (len: 26) \n" +
+			"\t\t\"abcdefghijklmnopqrs\n" +
+			"\t\ttuvwxyz\"\n" +
+			"}\n"))
+	})
+
+	It("reads indent levels from the environment", func() {
+		setenv(map[string]string{"SPEW_INDENT_LEVELS": `\t,| `})
+		cs := spew.NewTestConfig()
+		Expect(cs.LoadEnv()).To(Succeed())
+		Expect(cs.IndentLevels).To(Equal([]string{"\t", "| "}))
+	})
+})
@@ -0,0 +1,15 @@
+package spew
+
+// elementIndex handles the ElementIndices option by writing the index i of an
+// array or slice element, as in [17]: , before the element is dumped.
+func (d *dumpState) elementIndex(i int) {
+	if !d.cs.ElementIndices {
+		return
+	}
+	d.indent()
+	d.punct(openBracketBytes)
+	printNumber(d.w, d.cs, i)
+	d.punct(closeBracketBytes)
+	d.punct(colonSpaceBytes)
+	d.ignoreNextIndent = true
+}
@@ -0,0 +1,54 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Element Indices Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.ElementIndices = true
+		return cs
+	}
+
+	It("prefixes slice elements with their index", func() {
+		v := []string{"a", "b"}
+		Expect(newConfig().Sdump(v)).To(Equal("([]string) (len: 2 cap: 2) {\n" +
+			"  [0]: (string) (len: 1) \"a\",\n" +
+			"  [1]: (string) (len: 1) \"b\"\n" +
+			"}\n"))
+	})
+
+	It("gives the original indices of elements shown after the marker", func() {
+		cs := newConfig()
+		cs.MaxElements = 1
+		cs.TailElements = 1
+		v := [4]int{1, 2, 3, 4}
+		Expect(cs.Sdump(v)).To(Equal("([4]int) (len: 4 cap: 4) {\n" +
+			"  [0]: (int) 1,\n" +
+			"  ... (+2 more)\n" +
+			"  [3]: (int) 4\n" +
+			"}\n"))
+	})
+
+	It("gives the index of the first element of collapsed runs", func() {
+		cs := newConfig()
+		cs.CollapseRuns = true
+		v := []int{7, 0, 0, 0}
+		Expect(cs.Sdump(v)).To(Equal("([]int) (len: 4 cap: 4) {\n" +
+			"  [0]: (int) 7,\n" +
+			"  [1]: (int) 0 ×3\n" +
+			"}\n"))
+	})
+
+	It("indents nested elements", func() {
+		v := [][]int{{5}}
+		Expect(newConfig().Sdump(v)).To(Equal("([][]int) (len: 1 cap: 1) {\n" +
+			"  [0]: ([]int) (len: 1 cap: 1) {\n" +
+			"    [0]: (int) 5\n" +
+			"  }\n" +
+			"}\n"))
+	})
+})
@@ -0,0 +1,107 @@
+package spew
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// IntBase specifies the base integers are displayed in.
+type IntBase int
+
+const (
+	// IntBaseDecimal displays integers in base 10.  This is the default.
+	IntBaseDecimal IntBase = iota
+
+	// IntBaseHex displays integers in base 16, prefixed with 0x when the
+	// IntBasePrefix option is set.
+	IntBaseHex
+
+	// IntBaseBinary displays integers in base 2, prefixed with 0b when the
+	// IntBasePrefix option is set.
+	IntBaseBinary
+
+	// IntBaseOctal displays integers in base 8, prefixed with 0o when the
+	// IntBasePrefix option is set.
+	IntBaseOctal
+)
+
+// intBaseOptions maps the options of the spew struct tag to the base they
+// select for the integer held by the field.
+var intBaseOptions = map[string]IntBase{
+	"dec": IntBaseDecimal,
+	"hex": IntBaseHex,
+	"bin": IntBaseBinary,
+	"oct": IntBaseOctal,
+}
+
+// intBase returns the base an integer of type typ held by a struct field with
+// the passed tag is displayed in.  The options of the spew tag take precedence
+// over the IntBaseTypes option, which takes precedence over IntBase.
+func (c *ConfigState) intBase(typ reflect.Type, tag reflect.StructTag) IntBase {
+	for _, opt := range tagOptions(tag, "spew") {
+		if base, ok := intBaseOptions[opt]; ok {
+			return base
+		}
+	}
+	if base, ok := c.IntBaseTypes[typ]; ok {
+		return base
+	}
+	return c.IntBase
+}
+
+// printInt outputs the integer num in the passed base to Writer w.
+func printInt[T int64 | uint64](writer io.Writer, cs *ConfigState, num T, base IntBase) {
+	var verb string
+	switch base {
+	case IntBaseHex:
+		verb = "%x"
+		if cs.IntBasePrefix {
+			verb = "%#x"
+		}
+	case IntBaseBinary:
+		verb = "%b"
+		if cs.IntBasePrefix {
+			verb = "%#b"
+		}
+	case IntBaseOctal:
+		verb = "%o"
+		if cs.IntBasePrefix {
+			verb = "%O"
+		}
+	default:
+		if cs.ThousandsSeparator == "" {
+			printNumber(writer, cs, num)
+			return
+		}
+		s := groupThousands(fmt.Sprintf("%d", num), cs.ThousandsSeparator)
+		withColor(writer, []byte(s), cs.Color.Number...)
+		return
+	}
+	withColor(writer, []byte(fmt.Sprintf(verb, num)), cs.Color.Number...)
+}
+
+// groupThousands returns the decimal integer s with sep inserted between each
+// group of three digits, as in 1_234_567.
+func groupThousands(s, sep string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= 3 {
+		return sign + s
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	first := len(s) % 3
+	if first == 0 {
+		first = 3
+	}
+	b.WriteString(s[:first])
+	for i := first; i < len(s); i += 3 {
+		b.WriteString(sep)
+		b.WriteString(s[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package spew_test
+
+import (
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type intBaseFlags uint16
+
+type intBaseTester struct {
+	Mode  uint32 `spew:"oct"`
+	Flags intBaseFlags
+	Count int `spew:"dec"`
+	Delta int8
+}
+
+var _ = Describe("IntBase Tests", func() {
+	v := intBaseTester{Mode: 0755, Flags: 5, Count: 12, Delta: -31}
+
+	It("displays integers in decimal unless tagged otherwise", func() {
+		Expect(spew.NewTestConfig().Sdump(v)).To(Equal(`(spew_test.intBaseTester) {
+  Mode: (uint32) 755,
+  Flags: (spew_test.intBaseFlags) 5,
+  Count: (int) 12,
+  Delta: (int8) -31
+}
+`))
+	})
+
+	It("displays integers in the configured bases", func() {
+		cfg := spew.NewTestConfig()
+		cfg.IntBase = spew.IntBaseHex
+		cfg.IntBaseTypes = map[reflect.Type]spew.IntBase{
+			reflect.TypeOf(intBaseFlags(0)): spew.IntBaseBinary,
+		}
+		Expect(cfg.Sdump(v)).To(Equal(`(spew_test.intBaseTester) {
+  Mode: (uint32) 755,
+  Flags: (spew_test.intBaseFlags) 101,
+  Count: (int) 12,
+  Delta: (int8) -1f
+}
+`))
+
+		cfg.IntBasePrefix = true
+		Expect(cfg.Sdump(v)).To(Equal(`(spew_test.intBaseTester) {
+  Mode: (uint32) 0o755,
+  Flags: (spew_test.intBaseFlags) 0b101,
+  Count: (int) 12,
+  Delta: (int8) -0x1f
+}
+`))
+	})
+
+	It("displays integers in the configured bases with Formatter", func() {
+		cfg := spew.NewTestConfig()
+		cfg.IntBase = spew.IntBaseHex
+		cfg.IntBasePrefix = true
+		Expect(cfg.Sprint([]int{10, 255})).To(Equal("[0xa 0xff]"))
+	})
+
+	It("groups the digits of decimal integers in thousands", func() {
+		cfg := spew.NewTestConfig()
+		cfg.ThousandsSeparator = "_"
+		Expect(cfg.Sprint([]int64{1234567, -123456, 999, -1000, 0})).To(Equal("[1_234_567 -123_456 999 -1_000 0]"))
+
+		cfg.ThousandsSeparator = ","
+		Expect(cfg.Sdump(uint64(18446744073709551615))).To(Equal("(uint64) 18,446,744,073,709,551,615\n"))
+		Expect(cfg.Sdump(make([]int, 1000))).To(HavePrefix("([]int) (len: 1000 cap: 1000) {\n  (int) 0,"))
+
+		cfg.IntBase = spew.IntBaseHex
+		Expect(cfg.Sprint(123456)).To(Equal("1e240"))
+	})
+})
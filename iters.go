@@ -0,0 +1,96 @@
+package spew
+
+import "reflect"
+
+// defaultIteratorElements is the number of values drained from iterators for
+// the DrainIterators option when MaxElements doesn't limit them, which keeps
+// infinite iterators from hanging the dump.
+const defaultIteratorElements = 100
+
+var (
+	consumedBytes     = []byte("consumed")
+	moreIteratedBytes = []byte("... (more)")
+)
+
+// isIterator returns whether t is the type of an iterator function, such as
+// iter.Seq[V] and iter.Seq2[K, V], which takes a yield function of one or two
+// values returning a bool and returns nothing.
+func isIterator(t reflect.Type) bool {
+	if t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 0 || t.IsVariadic() {
+		return false
+	}
+	y := t.In(0)
+	return y.Kind() == reflect.Func && (y.NumIn() == 1 || y.NumIn() == 2) &&
+		!y.IsVariadic() && y.NumOut() == 1 && y.Out(0).Kind() == reflect.Bool
+}
+
+// drainIterator calls the iterator function v and returns the values it
+// yields, one or two per call, up to limit calls, along with whether it yielded
+// more.  The values yielded before the iterator panics are returned.
+func drainIterator(v reflect.Value, limit int) (values [][]reflect.Value, more bool) {
+	if !v.CanInterface() {
+		v = unsafeReflectValue(v)
+	}
+	yt := v.Type().In(0)
+	proceed := reflect.ValueOf(true).Convert(yt.Out(0))
+	stop := reflect.ValueOf(false).Convert(yt.Out(0))
+	yield := reflect.MakeFunc(yt, func(args []reflect.Value) []reflect.Value {
+		if len(values) == limit {
+			more = true
+			return []reflect.Value{stop}
+		}
+		values = append(values, append([]reflect.Value(nil), args...))
+		return []reflect.Value{proceed}
+	})
+
+	defer func() { recover() }()
+	v.Call([]reflect.Value{yield})
+	return values, more
+}
+
+// dumpIterator writes the values yielded by the iterator function v, marked
+// as consumed since draining the iterator may have side effects.  The pairs
+// yielded by iter.Seq2 iterators are written like the entries of maps.
+func (d *dumpState) dumpIterator(v reflect.Value, staticType reflect.Type) {
+	d.typeHeader(v, staticType)
+	withParens(d, func(d *dumpState) {
+		withColor(d.w, consumedBytes, d.cs.Color.Length...)
+	})
+	d.w.Write(spaceBytes)
+	d.punct(openBraceNewlineBytes)
+	d.depth++
+	if d.depth > d.maxDepth {
+		d.indent()
+		d.line(maxSymbol(d.cs))
+		d.countTruncation()
+	} else {
+		limit := d.cs.MaxElements
+		if limit <= 0 {
+			limit = defaultIteratorElements
+		}
+		values, more := drainIterator(v, limit)
+		for i, args := range values {
+			if len(args) == 2 {
+				d.dump(d.unpackValue(args[0]))
+				d.punct(colonSpaceBytes)
+				d.ignoreNextIndent = true
+				d.keyName = mapKeyName(args[0])
+			}
+			d.dump(d.unpackValue(args[len(args)-1]))
+			if i < len(values)-1 || more {
+				d.punct(commaNewlineBytes)
+			} else {
+				d.line(newlineBytes)
+			}
+		}
+		if more {
+			d.countTruncation()
+			d.indent()
+			withColor(d.w, moreIteratedBytes, d.cs.Color.Length...)
+			d.line(newlineBytes)
+		}
+	}
+	d.depth--
+	d.indent()
+	d.punct(closeBraceBytes)
+}
@@ -0,0 +1,77 @@
+package spew_test
+
+import (
+	"iter"
+	"slices"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type itersPage struct {
+	Items iter.Seq[string]
+	Pairs iter.Seq2[int, string]
+}
+
+// itersCount returns an infinite iterator of the natural numbers.
+func itersCount() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for n := 0; yield(n); n++ {
+		}
+	}
+}
+
+var _ = Describe("Iterator Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.DrainIterators = true
+		return cs
+	}
+
+	It("displays the values yielded by iterators", func() {
+		cs := newConfig()
+		names := []string{"a", "b"}
+		p := itersPage{Items: slices.Values(names), Pairs: slices.All(names)}
+		Expect(cs.Sdump(p)).To(Equal("(spew_test.itersPage) {\n" +
+			"  Items: (iter.Seq[string]) (consumed) {\n" +
+			"    (string) (len: 1) \"a\",\n" +
+			"    (string) (len: 1) \"b\"\n" +
+			"  },\n" +
+			"  Pairs: (iter.Seq2[int,string]) (consumed) {\n" +
+			"    (int) 0: (string) (len: 1) \"a\",\n" +
+			"    (int) 1: (string) (len: 1) \"b\"\n" +
+			"  }\n" +
+			"}\n"))
+	})
+
+	It("drains iterators up to MaxElements", func() {
+		cs := newConfig()
+		cs.MaxElements = 2
+		Expect(cs.Sdump(itersCount())).To(Equal("(iter.Seq[int]) (consumed) {\n" +
+			"  (int) 0,\n" +
+			"  (int) 1,\n" +
+			"  ... (more)\n" +
+			"}\n"))
+
+		cs.MaxElements = 0
+		Expect(cs.Sdump(itersCount())).To(ContainSubstring("  (int) 99,\n  ... (more)\n}\n"))
+	})
+
+	It("displays empty and panicking iterators", func() {
+		cs := newConfig()
+		Expect(cs.Sdump(slices.Values([]int{}))).To(Equal("(iter.Seq[int]) (consumed) {\n}\n"))
+		var panics iter.Seq[int] = func(yield func(int) bool) {
+			yield(1)
+			panic("boom")
+		}
+		Expect(cs.Sdump(panics)).To(Equal("(iter.Seq[int]) (consumed) {\n" +
+			"  (int) 1\n" +
+			"}\n"))
+	})
+
+	It("displays iterators as functions without the option", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(itersCount())).NotTo(ContainSubstring("consumed"))
+	})
+})
@@ -0,0 +1,111 @@
+package spew
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// rawMessageType is the reflect type of json.RawMessage.
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+	jsonNullBytes   = []byte("null")
+	parsedJSONBytes = []byte("parsed json")
+)
+
+// isJSONDocument returns whether s appears to hold a JSON object or array,
+// which is all the ParseJSONStrings option parses, so strings such as "1"
+// and "true" are left alone.
+func isJSONDocument(s string) bool {
+	s = strings.TrimSpace(s)
+	return len(s) >= 2 && (s[0] == '{' && s[len(s)-1] == '}' || s[0] == '[' && s[len(s)-1] == ']')
+}
+
+// jsonBytes returns the contents of the string, byte array or byte slice v
+// when it holds a single valid JSON value, and false otherwise.
+func jsonBytes(v reflect.Value) ([]byte, bool) {
+	var b []byte
+	switch v.Kind() {
+	case reflect.String:
+		b = []byte(v.String())
+	case reflect.Array, reflect.Slice:
+		var ok bool
+		if b, ok = byteSlice(v); !ok {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	return b, json.Valid(b)
+}
+
+// dumpParsedJSON writes the valid JSON document b as dumpJSON does, following
+// a (parsed json) marker.
+func (d *dumpState) dumpParsedJSON(b []byte) {
+	withParens(d, func(d *dumpState) {
+		withColor(d.w, parsedJSONBytes, d.cs.Color.Length...)
+	})
+	d.w.Write(spaceBytes)
+	d.dumpJSON(b)
+}
+
+// dumpJSON writes the valid JSON document b indented at the current depth,
+// with its strings, numbers, booleans and nulls colored like the values of
+// the corresponding kinds.  The order of object members is preserved.
+func (d *dumpState) dumpJSON(b []byte) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	d.jsonValue(dec)
+}
+
+// jsonValue writes the next value read from dec, which holds valid JSON.
+func (d *dumpState) jsonValue(dec *json.Decoder) {
+	tok, err := dec.Token()
+	if err != nil {
+		return
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		open, closing := openBraceBytes, closeBraceBytes
+		if t == '[' {
+			open, closing = openBracketBytes, closeBracketBytes
+		}
+		d.punct(open)
+		if !dec.More() {
+			dec.Token()
+			d.punct(closing)
+			return
+		}
+		d.line(newlineBytes)
+		d.depth++
+		for first := true; dec.More(); first = false {
+			if !first {
+				d.punct(commaBytes)
+				d.line(newlineBytes)
+			}
+			d.indent()
+			if t == '{' {
+				key, _ := dec.Token()
+				printString(d.w, d.cs, strconv.Quote(key.(string)))
+				d.punct(colonSpaceBytes)
+			}
+			d.jsonValue(dec)
+		}
+		d.depth--
+		dec.Token()
+		d.line(newlineBytes)
+		d.indent()
+		d.punct(closing)
+	case string:
+		printString(d.w, d.cs, strconv.Quote(t))
+	case json.Number:
+		withColor(d.w, []byte(t), d.cs.Color.Number...)
+	case bool:
+		printBool(d.w, d.cs, t)
+	case nil:
+		withColor(d.w, jsonNullBytes, d.cs.Color.Keyword...)
+	}
+}
@@ -0,0 +1,72 @@
+package spew_test
+
+import (
+	"encoding/json"
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type jsonEvent struct {
+	Payload json.RawMessage
+	Body    string `spew:"json"`
+}
+
+var _ = Describe("JSON Tests", func() {
+	// json.RawMessage is an alias of another type in some releases.
+	rawMessage := reflect.TypeOf(json.RawMessage(nil)).String()
+
+	It("displays raw messages and json fields indented", func() {
+		cs := spew.NewTestConfig()
+		cs.WellKnownTypes = true
+		e := jsonEvent{
+			Payload: json.RawMessage(`{"b":[true,null],"a":1.5,"e":{}}`),
+			Body:    `["x"]`,
+		}
+		Expect(cs.Sdump(e)).To(Equal("(spew_test.jsonEvent) {\n" +
+			"  Payload: (" + rawMessage + ") (parsed json) {\n" +
+			"    \"b\": [\n" +
+			"      true,\n" +
+			"      null\n" +
+			"    ],\n" +
+			"    \"a\": 1.5,\n" +
+			"    \"e\": {}\n" +
+			"  },\n" +
+			"  Body: (string) (parsed json) [\n" +
+			"    \"x\"\n" +
+			"  ]\n" +
+			"}\n"))
+	})
+
+	It("displays invalid JSON as usual", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		Expect(cs.Sdump(jsonEvent{Body: "{"})).To(Equal("(spew_test.jsonEvent) {\n" +
+			"  Payload: (" + rawMessage + ") <nil>,\n" +
+			"  Body: (string) (len: 1) \"{\"\n" +
+			"}\n"))
+	})
+
+	It("leaves raw messages alone without WellKnownTypes", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		Expect(cs.Sdump(json.RawMessage(`1`))).To(Equal("(" + rawMessage + ") (len: 1 cap: 1) {\n" +
+			"  00000000  31                                                |1|\n" +
+			"}\n"))
+	})
+
+	It("parses strings holding JSON documents", func() {
+		cs := spew.NewTestConfig()
+		cs.ParseJSONStrings = true
+		cs.SortKeys = true
+		Expect(cs.Sdump(map[string]string{"a": ` {"n": 1} `, "b": "1", "c": "{x}"})).To(Equal("(map[string]string) (len: 3) {\n" +
+			"  (string) (len: 1) \"a\": (string) (parsed json) {\n" +
+			"    \"n\": 1\n" +
+			"  },\n" +
+			"  (string) (len: 1) \"b\": (string) (len: 1) \"1\",\n" +
+			"  (string) (len: 1) \"c\": (string) (len: 3) \"{x}\"\n" +
+			"}\n"))
+	})
+})
@@ -0,0 +1,87 @@
+package spew
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+)
+
+// writeLabel writes the header line for the value numbered i of n labeled
+// label, which includes the number of the value when there are several.
+func writeLabel(cs *ConfigState, w io.Writer, label string, i, n int) {
+	text := "----- " + label
+	if n > 1 {
+		text += fmt.Sprintf(" [%d/%d]", i+1, n)
+	}
+	withColor(w, []byte(text+" -----"), cs.Color.Label...)
+	w.Write(newlineBytes)
+}
+
+// fdumpLabeled dumps each of the passed arguments to w after a header line
+// with label.  A lone header is written when there are no arguments.
+func fdumpLabeled(cs *ConfigState, w io.Writer, label string, a ...interface{}) {
+	values := make([]reflect.Value, len(a))
+	for i, arg := range a {
+		values[i] = reflect.ValueOf(arg)
+	}
+	fdumpLabeledValues(cs, w, nil, values, func(cs *ConfigState, w io.Writer, i int) {
+		writeLabel(cs, w, label, i, len(a))
+	})
+}
+
+/*
+Fdumpl displays the passed arguments to w exactly the same as Fdump after a
+header line with label, written in the Label color, which replaces the pattern
+of printing a marker line before each dump.  For example:
+
+	spew.Dumpl("here", v)
+
+displays:
+
+	----- here -----
+	(int) 1
+
+When several arguments are passed, each of them is dumped after its own
+header, numbered as in ----- here [2/3] -----.  The headers are part of the
+output of the dump, so options such as LineNumbers and AlsoWrite apply to them
+as well.
+*/
+func (c *ConfigState) Fdumpl(w io.Writer, label string, a ...interface{}) {
+	fdumpLabeled(c, w, label, a...)
+}
+
+// Dumpl displays the passed arguments to standard out after a header line
+// with label.  See Fdumpl for details.
+func (c *ConfigState) Dumpl(label string, a ...interface{}) {
+	fdumpLabeled(c, os.Stdout, label, a...)
+}
+
+// Sdumpl returns a string with the passed arguments displayed exactly the
+// same as Dumpl.
+func (c *ConfigState) Sdumpl(label string, a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpLabeled(c, &buf, label, a...)
+	return buf.String()
+}
+
+// Fdumpl displays the passed arguments to w after a header line with label.
+// See ConfigState.Fdumpl for details.
+func Fdumpl(w io.Writer, label string, a ...interface{}) {
+	fdumpLabeled(global(), w, label, a...)
+}
+
+// Dumpl displays the passed arguments to standard out after a header line
+// with label.  See ConfigState.Fdumpl for details.
+func Dumpl(label string, a ...interface{}) {
+	fdumpLabeled(global(), os.Stdout, label, a...)
+}
+
+// Sdumpl returns a string with the passed arguments displayed exactly the
+// same as Dumpl.
+func Sdumpl(label string, a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpLabeled(global(), &buf, label, a...)
+	return buf.String()
+}
@@ -0,0 +1,53 @@
+package spew_test
+
+import (
+	"bytes"
+
+	spew "github.com/ehowe/rainbow-spew"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Label Tests", func() {
+	It("writes a label before a value", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdumpl("here", 1)).To(Equal("----- here -----\n(int) 1\n"))
+		Expect(cs.Sdumpl("here")).To(Equal("----- here -----\n"))
+	})
+
+	It("numbers the headers of several values", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdumpl("args", 1, "a")).To(Equal("----- args [1/2] -----\n" +
+			"(int) 1\n" +
+			"----- args [2/2] -----\n" +
+			"(string) (len: 1) \"a\"\n"))
+	})
+
+	It("colors the label", func() {
+		noColor := color.NoColor
+		color.NoColor = false
+		defer func() { color.NoColor = noColor }()
+
+		cs := spew.NewTestConfig()
+		cs.Color.Label = []color.Attribute{color.Bold}
+		bold := color.New(color.Bold).Sprint
+		Expect(cs.Sdumpl("here", 1)).To(Equal(bold("----- here -----") + "\n(int) 1\n"))
+
+		cs.DisableDumpColor = true
+		Expect(cs.Sdumpl("here", 1)).To(Equal("----- here -----\n(int) 1\n"))
+	})
+
+	It("writes the labels through the output of the dump", func() {
+		var also bytes.Buffer
+		cs := spew.NewTestConfig()
+		cs.LineNumbers = true
+		cs.AlsoWrite = &also
+		want := "   1  ----- args [1/2] -----\n" +
+			"   2  (int) 1\n" +
+			"   3  ----- args [2/2] -----\n" +
+			"   4  (string) (len: 1) \"a\"\n"
+		Expect(cs.Sdumpl("args", 1, "a")).To(Equal(want))
+		Expect(also.String()).To(Equal(want))
+	})
+})
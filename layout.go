@@ -0,0 +1,31 @@
+package spew
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// fieldPadding returns the number of bytes of padding following the field i
+// of the struct type t, up to the next field or the end of the struct.
+func fieldPadding(t reflect.Type, i int) uintptr {
+	f := t.Field(i)
+	next := t.Size()
+	if i+1 < t.NumField() {
+		next = t.Field(i + 1).Offset
+	}
+	return next - f.Offset - f.Type.Size()
+}
+
+// fieldLayout handles the ShowLayout option by writing a comment giving the
+// offset and size of the field f within the struct holding it, as in
+// // off=8 size=8, along with the padding following it when there is any.
+func (d *dumpState) fieldLayout(f shownField) {
+	if !d.cs.ShowLayout || d.cs.Compact {
+		return
+	}
+	text := fmt.Sprintf(" // off=%d size=%d", f.field.Offset, f.field.Type.Size())
+	if pad := fieldPadding(f.owner, f.field.Index[len(f.field.Index)-1]); pad > 0 {
+		text += fmt.Sprintf(" pad=%d", pad)
+	}
+	withColor(d.w, []byte(text), d.cs.Color.Length...)
+}
@@ -0,0 +1,36 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type layoutRecord struct {
+	Flag  bool
+	Count int64
+	Small int32
+}
+
+var _ = Describe("Layout Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.ShowLayout = true
+		return cs
+	}
+
+	It("annotates struct fields with their offset, size and padding", func() {
+		v := layoutRecord{true, 2, 3}
+		Expect(newConfig().Sdump(v)).To(Equal("(spew_test.layoutRecord) {\n" +
+			"  Flag: (bool) true, // off=0 size=1 pad=7\n" +
+			"  Count: (int64) 2, // off=8 size=8\n" +
+			"  Small: (int32) 3 // off=16 size=4 pad=4\n" +
+			"}\n"))
+	})
+
+	It("does not annotate compact output", func() {
+		cs := newConfig()
+		cs.Compact = true
+		Expect(cs.Sdump(struct{ A int8 }{1})).To(Equal("(struct { A int8 }) { A: (int8) 1 }\n"))
+	})
+})
@@ -0,0 +1,53 @@
+package spew
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// limitWriter passes at most max bytes to the underlying writer and discards
+// the rest, which implements the MaxBytes option.  Writes which would exceed
+// the limit are cut after their last newline that fits, or dropped when no
+// newline fits, so escape sequences and UTF-8 sequences are never split.
+type limitWriter struct {
+	w       io.Writer
+	max     int
+	n       int
+	full    bool
+	midLine bool
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if l.full || len(p) == 0 {
+		return len(p), nil
+	}
+	q := p
+	if l.n+len(q) > l.max {
+		l.full = true
+		q = q[:bytes.LastIndexByte(q[:l.max-l.n], '\n')+1]
+		if len(q) == 0 {
+			return len(p), nil
+		}
+	}
+	n, err := l.w.Write(q)
+	l.n += n
+	l.midLine = q[len(q)-1] != '\n'
+	if err != nil {
+		return n, err
+	}
+	return len(p), nil
+}
+
+// writeNotice writes the notice that output was truncated to w when the limit
+// was reached.
+func (l *limitWriter) writeNotice(cs *ConfigState, w io.Writer) {
+	if !l.full {
+		return
+	}
+	if l.midLine {
+		w.Write(newlineBytes)
+	}
+	withColor(w, []byte(fmt.Sprintf("... (output truncated at %d bytes)", l.max)), cs.Color.Length...)
+	w.Write(newlineBytes)
+}
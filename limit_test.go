@@ -0,0 +1,30 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MaxBytes Tests", func() {
+	It("truncates output at line boundaries", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxBytes = 30
+		cs.DisableCapacities = true
+		Expect(cs.Sdump([]int{1, 2, 3, 4, 5}, "next")).To(Equal("([]int) (len: 5) {\n" +
+			"  (int) 1,\n" +
+			"... (output truncated at 30 bytes)\n"))
+	})
+
+	It("ends truncated lines before the notice", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxBytes = 10
+		Expect(cs.Sdump("a long string value")).To(Equal("(string) (\n... (output truncated at 10 bytes)\n"))
+	})
+
+	It("leaves output alone under the limit", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxBytes = 100
+		Expect(cs.Sdump(1)).To(Equal("(int) 1\n"))
+	})
+})
@@ -0,0 +1,65 @@
+package spew
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// lineNumberWidth is the number of columns taken by the numbers written
+// ahead of lines by lineNumberWriter, up to the 9999th line.
+const lineNumberWidth = 6
+
+// lineNumberWriter is an io.Writer which prefixes each line written through
+// it with its line number.
+type lineNumberWriter struct {
+	w  io.Writer
+	cs *ConfigState
+
+	// lines is the number of lines which have been started.
+	lines int
+
+	// midLine is whether the last byte written did not end a line, so the
+	// next byte continues the current line rather than starting a new one.
+	midLine bool
+}
+
+func (l *lineNumberWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if !l.midLine {
+			l.lines++
+			withColor(l.w, []byte(fmt.Sprintf("%4d  ", l.lines)), l.cs.Color.Length...)
+			l.midLine = true
+		}
+		end := bytes.IndexByte(p, '\n') + 1
+		if end == 0 {
+			end = len(p)
+		} else {
+			l.midLine = false
+		}
+		n, err := l.w.Write(p[:end])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[end:]
+	}
+	return written, nil
+}
+
+// writeLineCount writes the trailer giving the number of lines numbered by l
+// when the ShowLineCount option is set.
+func writeLineCount(cs *ConfigState, w io.Writer, l *lineNumberWriter) {
+	if !cs.ShowLineCount {
+		return
+	}
+	if l.midLine {
+		w.Write(newlineBytes)
+	}
+	w.Write(openParenBytes)
+	withColor(w, linesEqualsBytes, cs.Color.Length...)
+	printNumber(w, cs, l.lines)
+	w.Write(closeParenBytes)
+	w.Write(newlineBytes)
+}
@@ -0,0 +1,39 @@
+package spew_test
+
+import (
+	"bytes"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LineNumbers Tests", func() {
+	It("prefixes each line with its number", func() {
+		cfg := spew.NewTestConfig()
+		cfg.LineNumbers = true
+		Expect(cfg.Sdump([]int{1, 2}, "a")).To(Equal(`   1  ([]int) (len: 2 cap: 2) {
+   2    (int) 1,
+   3    (int) 2
+   4  }
+   5  (string) (len: 1) "a"
+`))
+	})
+
+	It("ends the output with the line count when requested", func() {
+		cfg := spew.NewTestConfig()
+		cfg.LineNumbers = true
+		cfg.ShowLineCount = true
+		cfg.ShowDumpID = true
+		var buf bytes.Buffer
+		stats := cfg.FdumpStats(&buf, 1)
+		Expect(buf.String()).To(Equal("   1  (dump: " + stats.ID + ")\n   2  (int) 1\n(lines: 2)\n"))
+		Expect(stats.Bytes).To(Equal(buf.Len()))
+	})
+
+	It("ignores the line count without line numbers", func() {
+		cfg := spew.NewTestConfig()
+		cfg.ShowLineCount = true
+		Expect(cfg.Sdump(1)).To(Equal("(int) 1\n"))
+	})
+})
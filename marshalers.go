@@ -0,0 +1,49 @@
+package spew
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// methodText returns the text of v given by the methods which are only used
+// when requested.  These are GoString when v implements fmt.GoStringer and
+// the UseGoStringer option is set, MarshalText when v implements
+// encoding.TextMarshaler and the TextMarshalers option is set, and
+// MarshalJSON when v implements json.Marshaler and the JSONMarshalers option
+// is set, in that order.  It returns false when none applies or marshaling
+// fails, so v is displayed as usual.  Panics in the methods are written to w
+// by catchPanic.
+func methodText(cs *ConfigState, w io.Writer, v reflect.Value) (text string, ok bool) {
+	if !cs.UseGoStringer && !cs.TextMarshalers && !cs.JSONMarshalers {
+		return "", false
+	}
+	iface := v.Interface()
+	if g, isGo := iface.(fmt.GoStringer); isGo && cs.UseGoStringer {
+		return callText(cs, w, v, "GoString()", func() ([]byte, error) {
+			return []byte(g.GoString()), nil
+		})
+	}
+	if m, isText := iface.(encoding.TextMarshaler); isText && cs.TextMarshalers {
+		if text, ok := callText(cs, w, v, "MarshalText()", m.MarshalText); ok {
+			return text, true
+		}
+	}
+	if m, isJSON := iface.(json.Marshaler); isJSON && cs.JSONMarshalers {
+		return callText(cs, w, v, "MarshalJSON()", m.MarshalJSON)
+	}
+	return "", false
+}
+
+// callText returns the text returned by the method of v named by method,
+// which is called through fn, and false when it fails or panics.
+func callText(cs *ConfigState, w io.Writer, v reflect.Value, method string, fn func() ([]byte, error)) (text string, ok bool) {
+	defer catchPanic(cs, w, v, method)
+	b, err := fn()
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
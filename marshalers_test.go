@@ -0,0 +1,69 @@
+package spew_test
+
+import (
+	"errors"
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type marshalersQuantity struct {
+	Milli int64
+}
+
+func (q marshalersQuantity) MarshalText() ([]byte, error) {
+	if q.Milli < 0 {
+		return nil, errors.New("negative")
+	}
+	return []byte(fmt.Sprintf("%dm", q.Milli)), nil
+}
+
+func (q marshalersQuantity) String() string { return "quantity" }
+
+type marshalersPoint struct {
+	X, Y int
+}
+
+func (p marshalersPoint) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("[%d,%d]", p.X, p.Y)), nil
+}
+
+type marshalersID int
+
+func (id marshalersID) GoString() string { return fmt.Sprintf("ids.New(%d)", int(id)) }
+
+var _ = Describe("Marshalers Tests", func() {
+	It("displays text marshalers by their marshaled text", func() {
+		cs := spew.NewTestConfig()
+		cs.TextMarshalers = true
+		Expect(cs.Sdump(marshalersQuantity{500})).To(Equal("(spew_test.marshalersQuantity) 500m\n"))
+		Expect(cs.Sprintf("%v", marshalersQuantity{500})).To(Equal("500m"))
+	})
+
+	It("falls back to the usual display when marshaling fails", func() {
+		cs := spew.NewTestConfig()
+		cs.TextMarshalers = true
+		Expect(cs.Sdump(marshalersQuantity{-1})).To(Equal("(spew_test.marshalersQuantity) quantity\n"))
+	})
+
+	It("displays json marshalers by their marshaled JSON", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(marshalersPoint{1, 2})).To(Equal("(spew_test.marshalersPoint) {\n" +
+			"  X: (int) 1,\n" +
+			"  Y: (int) 2\n" +
+			"}\n"))
+		cs.JSONMarshalers = true
+		Expect(cs.Sdump(marshalersPoint{1, 2})).To(Equal("(spew_test.marshalersPoint) [1,2]\n"))
+	})
+
+	It("displays go stringers by their GoString", func() {
+		cs := spew.NewTestConfig()
+		cs.UseGoStringer = true
+		Expect(cs.Sdump(marshalersID(7))).To(Equal("(spew_test.marshalersID) ids.New(7)\n"))
+		Expect(cs.Sdump([]marshalersID{1})).To(Equal("([]spew_test.marshalersID) (len: 1 cap: 1) {\n" +
+			"  (spew_test.marshalersID) ids.New(1)\n" +
+			"}\n"))
+	})
+})
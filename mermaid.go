@@ -0,0 +1,226 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// mermaidState contains information about the state of a Mermaid export.
+type mermaidState struct {
+	nodes []string
+	edges []string
+	next  int
+
+	// ids maps the pointers which have been exported to the node of the
+	// value they point to, so values shared by several pointers and
+	// circular references are drawn as a single node.
+	ids map[visitKey]string
+}
+
+// newID returns the identifier for a new node.
+func (m *mermaidState) newID() string {
+	id := "n" + strconv.Itoa(m.next)
+	m.next++
+	return id
+}
+
+// mermaidEscape escapes s for use in a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "|", "#124;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+// isComposite returns whether n is drawn as a node of its own.
+func isComposite(n *node) bool {
+	switch n.kind {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return !n.isNil && !n.cycle && n.str == ""
+	}
+	return false
+}
+
+// childName returns the name of n within its parent for use as an edge or
+// field label.
+func childName(n *node) string {
+	switch n.parent.kind {
+	case reflect.Struct:
+		return n.name
+	case reflect.Map:
+		var key bytes.Buffer
+		writeCanonical(&key, n.key, n.parent.typ.Key())
+		return key.String()
+	}
+	return "[" + strconv.Itoa(n.index) + "]"
+}
+
+// target returns the identifier of the node for the value the non-nil
+// pointer n points to, exporting the value first if needed.  Pointers
+// displayed by their Error or String method have no pointee, so they are
+// exported as a node of their own.
+func (m *mermaidState) target(n *node) string {
+	key := visitKey{typ: n.typ, addr: n.addr}
+	if id, ok := m.ids[key]; ok {
+		return id
+	}
+	id := m.newID()
+	m.ids[key] = id
+	if n.str != "" || len(n.children) == 0 {
+		m.box(n, id)
+		return id
+	}
+	pointee := n.children[0]
+	if pointee.kind == reflect.Ptr && !pointee.isNil && !pointee.cycle {
+		m.nodes = append(m.nodes, id+`["`+mermaidEscape(n.typ.String())+`"]`)
+		m.edges = append(m.edges, id+" --> "+m.target(pointee))
+		return id
+	}
+	m.box(pointee, id)
+	return id
+}
+
+// staticType returns the type the container of n declares for it.
+func staticType(n *node) reflect.Type {
+	pt := n.parent.typ
+	if n.parent.kind == reflect.Struct {
+		return pt.Field(n.field).Type
+	}
+	return pt.Elem()
+}
+
+// mermaidValue returns the label text for the value of the node n which is
+// declared as static by its container.
+func mermaidValue(n *node, static reflect.Type) string {
+	var buf bytes.Buffer
+	switch {
+	case n.str != "":
+		buf.WriteString(n.str)
+	case n.cycle:
+		buf.Write(circularShortBytes)
+	case n.truncated:
+		buf.Write(maxShortBytes)
+	default:
+		writeCanonical(&buf, n, static)
+	}
+	return mermaidEscape(buf.String())
+}
+
+// box exports n as the node id.  Scalar children are listed in the label of
+// the node, while composite children and the values pointed to by pointers
+// become nodes of their own connected to it.
+func (m *mermaidState) box(n *node, id string) {
+	// Reserve the position of the node so it is listed before its children.
+	pos := len(m.nodes)
+	m.nodes = append(m.nodes, "")
+
+	var label bytes.Buffer
+	if n.typ != nil {
+		label.WriteString(mermaidEscape(n.typ.String()))
+	}
+	line := func(name, value string) {
+		if label.Len() > 0 {
+			label.WriteString("<br/>")
+		}
+		if name != "" {
+			label.WriteString(mermaidEscape(name) + ": ")
+		}
+		label.WriteString(value)
+	}
+
+	if !isComposite(n) {
+		line("", mermaidValue(n, n.typ))
+	} else {
+		for _, c := range n.children {
+			name := mermaidEscape(childName(c))
+			switch {
+			case c.kind == reflect.Ptr && c.cycle:
+				target, ok := m.ids[visitKey{typ: c.typ, addr: c.addr}]
+				if !ok {
+					// The cycle leads back to a value of another type at
+					// the same address, such as its first field.
+					line(name, string(circularShortBytes))
+					continue
+				}
+				m.edges = append(m.edges, id+" -->|"+name+"| "+target)
+			case c.kind == reflect.Ptr && !c.isNil && c.str == "":
+				m.edges = append(m.edges, id+" -->|"+name+"| "+m.target(c))
+			case isComposite(c):
+				cid := m.newID()
+				m.box(c, cid)
+				m.edges = append(m.edges, id+" ---|"+name+"| "+cid)
+			default:
+				line(name, mermaidValue(c, staticType(c)))
+			}
+		}
+		if n.truncated {
+			line("", string(maxShortBytes))
+		}
+	}
+	m.nodes[pos] = id + `["` + label.String() + `"]`
+}
+
+// fdumpMermaid writes the Mermaid flowchart for v to w.
+func fdumpMermaid(cs *ConfigState, w io.Writer, v interface{}) {
+	m := &mermaidState{ids: make(map[visitKey]string)}
+	if v == nil {
+		m.nodes = append(m.nodes, `n0["nil"]`)
+	} else {
+		root := buildTree(cs, reflect.ValueOf(v))
+		if root.kind == reflect.Ptr && !root.isNil {
+			m.target(root)
+		} else {
+			m.box(root, m.newID())
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("graph TD\n")
+	for _, line := range m.nodes {
+		buf.WriteString("    " + line + "\n")
+	}
+	for _, line := range m.edges {
+		buf.WriteString("    " + line + "\n")
+	}
+	w.Write(buf.Bytes())
+	flushSink(w)
+}
+
+/*
+FdumpMermaid writes the value v to w as a Mermaid flowchart (graph TD), which
+GitHub and GitLab render inline in Markdown.  Structs, maps, slices and arrays
+are drawn as nodes listing their scalar fields.  Nested values are connected
+to their container with a line, while pointers are drawn as arrows.  A value
+reached through several pointers, including circular references, is drawn as
+a single node.  For example, a pointer to a linked list node whose Next field
+points back to itself results in:
+
+	graph TD
+	    n0["main.Node<br/>Name: #quot;a#quot;"]
+	    n0 -->|Next| n0
+*/
+func (c *ConfigState) FdumpMermaid(w io.Writer, v interface{}) {
+	fdumpMermaid(c, w, v)
+}
+
+// SdumpMermaid returns the Mermaid flowchart for v exactly as written by
+// FdumpMermaid.
+func (c *ConfigState) SdumpMermaid(v interface{}) string {
+	var buf bytes.Buffer
+	fdumpMermaid(c, &buf, v)
+	return buf.String()
+}
+
+// FdumpMermaid writes the value v to w as a Mermaid flowchart.  See
+// ConfigState.FdumpMermaid for details.
+func FdumpMermaid(w io.Writer, v interface{}) {
+	fdumpMermaid(global(), w, v)
+}
+
+// SdumpMermaid returns the Mermaid flowchart for v exactly as written by
+// FdumpMermaid.
+func SdumpMermaid(v interface{}) string {
+	var buf bytes.Buffer
+	fdumpMermaid(global(), &buf, v)
+	return buf.String()
+}
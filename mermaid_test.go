@@ -0,0 +1,55 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type mermaidNode struct {
+	Name   string
+	Next   *mermaidNode
+	Shared *mermaidLeaf
+	Extra  *mermaidLeaf
+	Tags   []string
+}
+
+type mermaidLeaf struct {
+	N int
+}
+
+var _ = Describe("Mermaid Tests", func() {
+	It("exports pointer graphs as flowcharts", func() {
+		leaf := &mermaidLeaf{N: 1}
+		n := &mermaidNode{Name: "a", Shared: leaf, Extra: leaf, Tags: []string{"x"}}
+		n.Next = n
+		Expect(spew.SdumpMermaid(n)).To(Equal(`graph TD
+    n0["spew_test.mermaidNode<br/>Name: #quot;a#quot;"]
+    n1["spew_test.mermaidLeaf<br/>N: 1"]
+    n2["[]string<br/>[0]: #quot;x#quot;"]
+    n0 -->|Next| n0
+    n0 -->|Shared| n1
+    n0 -->|Extra| n1
+    n0 ---|Tags| n2
+`))
+	})
+
+	It("exports scalars and nil values", func() {
+		Expect(spew.SdumpMermaid(nil)).To(Equal("graph TD\n    n0[\"nil\"]\n"))
+		x := 5
+		Expect(spew.SdumpMermaid(&x)).To(Equal("graph TD\n    n0[\"int<br/>5\"]\n"))
+		Expect(spew.SdumpMermaid(map[string]interface{}{"k": nil})).To(Equal("graph TD\n    n0[\"map[string]interface {}<br/>#quot;k#quot;: nil\"]\n"))
+	})
+
+	It("uses the static type of fields after hidden ones", func() {
+		Expect(spew.SdumpMermaid(canonicalHidden{B: 3})).To(Equal("graph TD\n    n0[\"spew_test.canonicalHidden<br/>B: (int)3\"]\n"))
+	})
+
+	It("exports pointers displayed by their methods", func() {
+		Expect(spew.SdumpMermaid(&protoName{N: "a"})).To(MatchRegexp(`^graph TD\n    n0\["\*?spew_test.protoName<br/>name a"\]\n$`))
+		Expect(spew.SdumpMermaid([]*protoName{{N: "b"}})).To(Equal("graph TD\n" +
+			"    n0[\"[]*spew_test.protoName\"]\n" +
+			"    n1[\"spew_test.protoName<br/>name b\"]\n" +
+			"    n0 -->|[0]| n1\n"))
+	})
+})
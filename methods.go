@@ -0,0 +1,98 @@
+package spew
+
+import (
+	"io"
+	"reflect"
+	"strings"
+)
+
+// methodSignature returns the signature of the method m, such as
+// Read([]uint8) (int, error), with its parameters given by type and without
+// its receiver.
+func methodSignature(m reflect.Method) string {
+	t := m.Type
+	params := make([]string, 0, t.NumIn())
+	for i := 1; i < t.NumIn(); i++ {
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			params = append(params, "..."+t.In(i).Elem().String())
+			continue
+		}
+		params = append(params, t.In(i).String())
+	}
+	results := make([]string, t.NumOut())
+	for i := range results {
+		results[i] = t.Out(i).String()
+	}
+	sig := m.Name + "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return sig
+	case 1:
+		return sig + " " + results[0]
+	}
+	return sig + " (" + strings.Join(results, ", ") + ")"
+}
+
+// methodSet returns the signatures of the methods of the method set of t in
+// the order of their names.
+func methodSet(t reflect.Type) []string {
+	methods := make([]string, t.NumMethod())
+	for i := range methods {
+		methods[i] = methodSignature(t.Method(i))
+	}
+	return methods
+}
+
+// pointerMethods returns the signatures of the methods in the method set of
+// a pointer to t which are not in the method set of t itself, which are the
+// methods declared with a pointer receiver.  There are none when t is a
+// pointer.
+func pointerMethods(t reflect.Type) []string {
+	if t.Kind() == reflect.Ptr {
+		return nil
+	}
+	var methods []string
+	pt := reflect.PointerTo(t)
+	for i := 0; i < pt.NumMethod(); i++ {
+		if m := pt.Method(i); !hasMethod(t, m.Name) {
+			methods = append(methods, methodSignature(m))
+		}
+	}
+	return methods
+}
+
+// hasMethod returns whether the method set of t includes the method name.
+func hasMethod(t reflect.Type, name string) bool {
+	_, ok := t.MethodByName(name)
+	return ok
+}
+
+// writeMethods handles the ShowMethods option by writing a line listing the
+// method set of t, followed by a line listing the methods only a pointer to t
+// has when there are any.
+func writeMethods(w io.Writer, cs *ConfigState, t reflect.Type) {
+	methods := methodSet(t)
+	text := "none"
+	if len(methods) > 0 {
+		text = strings.Join(methods, ", ")
+	}
+	withColor(w, []byte("(methods: "+text+")"), cs.Color.Length...)
+	w.Write(newlineBytes)
+	if methods := pointerMethods(t); len(methods) > 0 {
+		withColor(w, []byte("(pointer methods: "+strings.Join(methods, ", ")+")"), cs.Color.Length...)
+		w.Write(newlineBytes)
+	}
+}
+
+// Methods returns the signatures of the methods in the method set of the type
+// of v, such as String() string, in the order of their names.  Parameters are
+// given by type only.  This helps to debug why a value does not satisfy an
+// interface, particularly since the methods declared with a pointer receiver
+// are not in the method set of a value which isn't a pointer.  Nil is returned
+// when v is nil.
+func Methods(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	return methodSet(reflect.TypeOf(v))
+}
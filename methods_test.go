@@ -0,0 +1,44 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type methodsCounter struct {
+	N int
+}
+
+func (c methodsCounter) String() string                         { return "" }
+func (c methodsCounter) Add(n ...int) (int, error)              { return 0, nil }
+func (c *methodsCounter) Reset()                                { c.N = 0 }
+func (c *methodsCounter) Merge(other methodsCounter, keep bool) {}
+
+var _ = Describe("Methods Tests", func() {
+	It("lists the method set of the type of a value", func() {
+		Expect(spew.Methods(methodsCounter{})).To(Equal([]string{
+			"Add(...int) (int, error)",
+			"String() string",
+		}))
+		Expect(spew.Methods(&methodsCounter{})).To(Equal([]string{
+			"Add(...int) (int, error)",
+			"Merge(spew_test.methodsCounter, bool)",
+			"Reset()",
+			"String() string",
+		}))
+		Expect(spew.Methods(nil)).To(BeNil())
+	})
+
+	It("follows Dump output with the method set", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowMethods = true
+		cs.DisableMethods = true
+		Expect(cs.Sdump(methodsCounter{1})).To(Equal("(spew_test.methodsCounter) {\n" +
+			"  N: (int) 1\n" +
+			"}\n" +
+			"(methods: Add(...int) (int, error), String() string)\n" +
+			"(pointer methods: Merge(spew_test.methodsCounter, bool), Reset())\n"))
+		Expect(cs.Sdump(1)).To(Equal("(int) 1\n(methods: none)\n"))
+	})
+})
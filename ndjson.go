@@ -0,0 +1,134 @@
+package spew
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// ndjsonEvent is the JSON object written for each node visited by
+// FdumpNDJSON.
+type ndjsonEvent struct {
+	Path      string          `json:"path"`
+	Type      string          `json:"type,omitempty"`
+	Kind      string          `json:"kind"`
+	Depth     int             `json:"depth"`
+	Value     json.RawMessage `json:"value,omitempty"`
+	Len       *int            `json:"len,omitempty"`
+	String    string          `json:"string,omitempty"`
+	Nil       bool            `json:"nil,omitempty"`
+	Circular  bool            `json:"circular,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// ndjsonValue returns the JSON encoding of the scalar value of n, or nil when
+// n has no scalar value.  Floats which JSON can't represent, complex numbers
+// and addresses are encoded as strings.
+func ndjsonValue(n *node) json.RawMessage {
+	var v interface{}
+	switch val := n.value.(type) {
+	case nil:
+		return nil
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			v = strconv.FormatFloat(val, 'g', -1, 64)
+		} else {
+			v = val
+		}
+	case complex128:
+		v = strconv.FormatComplex(val, 'g', -1, 128)
+	case uintptr:
+		v = "0x" + strconv.FormatUint(uint64(val), 16)
+	default:
+		v = val
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// fdumpNDJSON writes an event for each node of the passed arguments to w as
+// it is visited.
+func fdumpNDJSON(cs *ConfigState, w io.Writer, a ...interface{}) {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, arg := range a {
+		if arg == nil {
+			enc.Encode(ndjsonEvent{Kind: reflect.Interface.String(), Nil: true})
+			continue
+		}
+
+		wk := newWalker(cs)
+		wk.enter = func(n *node) {
+			ev := ndjsonEvent{
+				Path:      nodePath(n),
+				Kind:      n.kind.String(),
+				Depth:     n.depth,
+				Value:     ndjsonValue(n),
+				String:    n.str,
+				Nil:       n.isNil,
+				Circular:  n.cycle,
+				Truncated: n.truncated,
+			}
+			if n.typ != nil {
+				ev.Type = n.typ.String()
+			}
+			switch n.kind {
+			case reflect.Array, reflect.Slice, reflect.Map, reflect.String:
+				if !n.isNil && !n.cycle {
+					l := n.rv.Len()
+					ev.Len = &l
+				}
+			}
+			enc.Encode(ev)
+		}
+		wk.walk(&node{}, reflect.ValueOf(arg))
+	}
+	flushSink(w)
+}
+
+/*
+FdumpNDJSON writes the passed arguments to w as newline-delimited JSON, with
+one object for each node visited while walking them in the same order as Dump.
+This allows external tools to consume the traversal of very large values
+incrementally.  Each object holds the path of the node from the root, which is
+empty for the root itself, along with its type, kind and depth.  Scalars also
+hold their value, while arrays, slices, maps and strings hold their length.
+For example, a struct with a single int field results in:
+
+	{"path":"","type":"main.T","kind":"struct","depth":0}
+	{"path":".A","type":"int","kind":"int","depth":1,"value":1}
+
+Nodes may also hold the result of their Stringer or error interface as string,
+and flags marking them as nil, circular, or truncated due to MaxDepth.
+*/
+func (c *ConfigState) FdumpNDJSON(w io.Writer, a ...interface{}) {
+	fdumpNDJSON(c, w, a...)
+}
+
+// SdumpNDJSON returns a string with the passed arguments written exactly the
+// same as FdumpNDJSON.
+func (c *ConfigState) SdumpNDJSON(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpNDJSON(c, &buf, a...)
+	return buf.String()
+}
+
+// FdumpNDJSON writes the passed arguments to w as newline-delimited JSON.  See
+// ConfigState.FdumpNDJSON for details.
+func FdumpNDJSON(w io.Writer, a ...interface{}) {
+	fdumpNDJSON(global(), w, a...)
+}
+
+// SdumpNDJSON returns a string with the passed arguments written exactly the
+// same as FdumpNDJSON.
+func SdumpNDJSON(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpNDJSON(global(), &buf, a...)
+	return buf.String()
+}
@@ -0,0 +1,46 @@
+package spew_test
+
+import (
+	"math"
+	"strings"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type ndjsonTester struct {
+	A int
+	B []string
+	C map[string]float64
+	D *ndjsonTester
+}
+
+var _ = Describe("NDJSON Tests", func() {
+	It("writes an event per visited node", func() {
+		cs := spew.NewTestConfig()
+		cs.SortKeys = true
+		v := &ndjsonTester{A: 1, B: []string{"x"}, C: map[string]float64{"nan": math.NaN()}}
+		v.D = v
+		lines := strings.Split(strings.TrimSpace(cs.SdumpNDJSON(v)), "\n")
+		Expect(lines).To(Equal([]string{
+			`{"path":"","type":"*spew_test.ndjsonTester","kind":"ptr","depth":0}`,
+			`{"path":"","type":"spew_test.ndjsonTester","kind":"struct","depth":1}`,
+			`{"path":".A","type":"int","kind":"int","depth":2,"value":1}`,
+			`{"path":".B","type":"[]string","kind":"slice","depth":2,"len":1}`,
+			`{"path":".B[0]","type":"string","kind":"string","depth":3,"value":"x","len":1}`,
+			`{"path":".C","type":"map[string]float64","kind":"map","depth":2,"len":1}`,
+			`{"path":".C[\"nan\"]","type":"float64","kind":"float64","depth":3,"value":"NaN"}`,
+			`{"path":".D","type":"*spew_test.ndjsonTester","kind":"ptr","depth":2,"circular":true}`,
+		}))
+	})
+
+	It("marks nil and truncated nodes", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		Expect(cs.SdumpNDJSON([][]int{{1}}, nil)).To(Equal(
+			`{"path":"","type":"[][]int","kind":"slice","depth":0,"len":1}` + "\n" +
+				`{"path":"[0]","type":"[]int","kind":"slice","depth":1,"len":1,"truncated":true}` + "\n" +
+				`{"path":"","kind":"interface","depth":0,"nil":true}` + "\n"))
+	})
+})
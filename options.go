@@ -0,0 +1,166 @@
+package spew
+
+// Option configures a ConfigState created by NewConfig.  Options are applied
+// in order, so later options override earlier ones.
+type Option func(*ConfigState)
+
+/*
+NewConfig returns a ConfigState with the default settings of NewDefaultConfig
+modified by each of the passed options in order.  Since options are functions,
+new ones can be added without breaking existing callers, and sets of options
+can be shared between configurations.  For example:
+
+	cs := spew.NewConfig(
+		spew.WithIndent("\t"),
+		spew.WithMaxDepth(3),
+		spew.WithTheme("dracula"),
+	)
+
+Options for settings which have no dedicated helper can be written inline:
+
+	cs := spew.NewConfig(func(c *spew.ConfigState) { c.ShowSizes = true })
+*/
+func NewConfig(opts ...Option) *ConfigState {
+	c := NewDefaultConfig()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithIndent returns an Option which sets the Indent option.
+func WithIndent(indent string) Option {
+	return func(c *ConfigState) { c.Indent = indent }
+}
+
+// WithMaxDepth returns an Option which sets the MaxDepth option.
+func WithMaxDepth(depth int) Option {
+	return func(c *ConfigState) { c.MaxDepth = depth }
+}
+
+// WithTheme returns an Option which sets the colors to those of the named
+// theme.  See LookupTheme for the available themes.  The colors are left
+// unchanged when no theme has that name.
+func WithTheme(name string) Option {
+	return func(c *ConfigState) {
+		if cc, ok := LookupTheme(name); ok {
+			c.Color = cc
+		}
+	}
+}
+
+// WithColors returns an Option which sets the Color option.
+func WithColors(cc ColorConfiguration) Option {
+	return func(c *ConfigState) { c.Color = cc }
+}
+
+// WithDisableMethods returns an Option which sets the DisableMethods option.
+func WithDisableMethods(disable bool) Option {
+	return func(c *ConfigState) { c.DisableMethods = disable }
+}
+
+// WithDisablePointerMethods returns an Option which sets the
+// DisablePointerMethods option.
+func WithDisablePointerMethods(disable bool) Option {
+	return func(c *ConfigState) { c.DisablePointerMethods = disable }
+}
+
+// WithDisablePointerAddresses returns an Option which sets the
+// DisablePointerAddresses option.
+func WithDisablePointerAddresses(disable bool) Option {
+	return func(c *ConfigState) { c.DisablePointerAddresses = disable }
+}
+
+// WithDisableCapacities returns an Option which sets the DisableCapacities
+// option.
+func WithDisableCapacities(disable bool) Option {
+	return func(c *ConfigState) { c.DisableCapacities = disable }
+}
+
+// WithContinueOnMethod returns an Option which sets the ContinueOnMethod
+// option.
+func WithContinueOnMethod(enable bool) Option {
+	return func(c *ConfigState) { c.ContinueOnMethod = enable }
+}
+
+// WithSortKeys returns an Option which sets the SortKeys option.
+func WithSortKeys(enable bool) Option {
+	return func(c *ConfigState) { c.SortKeys = enable }
+}
+
+// WithCompact returns an Option which sets the Compact option.
+func WithCompact(enable bool) Option {
+	return func(c *ConfigState) { c.Compact = enable }
+}
+
+// With returns a clone of c modified by each of the passed options in order,
+// leaving c unchanged.  The With methods named after options are shorthand
+// for it and can be chained:
+//
+//	cs := spew.Config.WithMaxDepth(2).WithDisableMethods(true)
+func (c *ConfigState) With(opts ...Option) *ConfigState {
+	cc := c.Clone()
+	for _, opt := range opts {
+		opt(cc)
+	}
+	return cc
+}
+
+// WithIndent returns a clone of c with the Indent option set.
+func (c *ConfigState) WithIndent(indent string) *ConfigState {
+	return c.With(WithIndent(indent))
+}
+
+// WithMaxDepth returns a clone of c with the MaxDepth option set.
+func (c *ConfigState) WithMaxDepth(depth int) *ConfigState {
+	return c.With(WithMaxDepth(depth))
+}
+
+// WithTheme returns a clone of c with the colors of the named theme.
+func (c *ConfigState) WithTheme(name string) *ConfigState {
+	return c.With(WithTheme(name))
+}
+
+// WithColors returns a clone of c with the Color option set.
+func (c *ConfigState) WithColors(cc ColorConfiguration) *ConfigState {
+	return c.With(WithColors(cc))
+}
+
+// WithDisableMethods returns a clone of c with the DisableMethods option set.
+func (c *ConfigState) WithDisableMethods(disable bool) *ConfigState {
+	return c.With(WithDisableMethods(disable))
+}
+
+// WithDisablePointerMethods returns a clone of c with the
+// DisablePointerMethods option set.
+func (c *ConfigState) WithDisablePointerMethods(disable bool) *ConfigState {
+	return c.With(WithDisablePointerMethods(disable))
+}
+
+// WithDisablePointerAddresses returns a clone of c with the
+// DisablePointerAddresses option set.
+func (c *ConfigState) WithDisablePointerAddresses(disable bool) *ConfigState {
+	return c.With(WithDisablePointerAddresses(disable))
+}
+
+// WithDisableCapacities returns a clone of c with the DisableCapacities
+// option set.
+func (c *ConfigState) WithDisableCapacities(disable bool) *ConfigState {
+	return c.With(WithDisableCapacities(disable))
+}
+
+// WithContinueOnMethod returns a clone of c with the ContinueOnMethod option
+// set.
+func (c *ConfigState) WithContinueOnMethod(enable bool) *ConfigState {
+	return c.With(WithContinueOnMethod(enable))
+}
+
+// WithSortKeys returns a clone of c with the SortKeys option set.
+func (c *ConfigState) WithSortKeys(enable bool) *ConfigState {
+	return c.With(WithSortKeys(enable))
+}
+
+// WithCompact returns a clone of c with the Compact option set.
+func (c *ConfigState) WithCompact(enable bool) *ConfigState {
+	return c.With(WithCompact(enable))
+}
@@ -0,0 +1,66 @@
+package spew_test
+
+import (
+	"reflect"
+
+	"github.com/fatih/color"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Option Tests", func() {
+	It("starts from the default configuration", func() {
+		Expect(spew.NewConfig()).To(Equal(spew.NewDefaultConfig()))
+	})
+
+	It("applies options in order", func() {
+		cs := spew.NewConfig(
+			spew.WithIndent("\t"),
+			spew.WithMaxDepth(3),
+			spew.WithSortKeys(true),
+			spew.WithMaxDepth(2),
+			func(c *spew.ConfigState) { c.ShowSizes = true },
+		)
+		Expect(cs.Indent).To(Equal("\t"))
+		Expect(cs.MaxDepth).To(Equal(2))
+		Expect(cs.SortKeys).To(BeTrue())
+		Expect(cs.ShowSizes).To(BeTrue())
+	})
+
+	It("sets the colors of themes", func() {
+		dracula, ok := spew.LookupTheme("dracula")
+		Expect(ok).To(BeTrue())
+		Expect(spew.NewConfig(spew.WithTheme("dracula")).Color).To(Equal(dracula))
+		Expect(spew.NewConfig(spew.WithTheme("none")).Color).To(Equal(spew.ColorConfiguration{}))
+		Expect(spew.NewConfig(spew.WithTheme("missing")).Color).To(Equal(spew.NewDefaultConfig().Color))
+	})
+
+	It("returns copies of themes", func() {
+		theme, _ := spew.LookupTheme("default")
+		theme.String[0] = color.FgBlue
+		Expect(spew.NewConfig().Color.String).To(Equal([]color.Attribute{color.FgRed}))
+		Expect(spew.ThemeNames()).To(Equal([]string{"default", "dracula", "monochrome", "none", "solarized"}))
+	})
+
+	It("clones configurations", func() {
+		cs := spew.NewTestConfig()
+		cs.IntBaseTypes = map[reflect.Type]spew.IntBase{reflect.TypeOf(0): spew.IntBaseHex}
+		clone := cs.Clone()
+		Expect(clone).To(Equal(cs))
+		clone.IntBaseTypes[reflect.TypeOf(0)] = spew.IntBaseOctal
+		clone.Color.String = append(clone.Color.String, color.FgRed)
+		Expect(cs.IntBaseTypes[reflect.TypeOf(0)]).To(Equal(spew.IntBaseHex))
+		Expect(cs.Color.String).To(BeEmpty())
+	})
+
+	It("derives configurations with chained With methods", func() {
+		cs := spew.NewTestConfig()
+		derived := cs.WithMaxDepth(2).WithDisableMethods(true).With(spew.WithIndent("\t"))
+		Expect(derived.MaxDepth).To(Equal(2))
+		Expect(derived.DisableMethods).To(BeTrue())
+		Expect(derived.Indent).To(Equal("\t"))
+		Expect(cs).To(Equal(spew.NewTestConfig()))
+	})
+})
@@ -0,0 +1,83 @@
+package spew
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// MethodPanic describes a panic recovered from a method called while
+// displaying a value, such as a String method.  It is passed to the
+// OnMethodPanic option.
+type MethodPanic struct {
+	// Type is the type of the value whose method panicked.  It is a
+	// pointer to the type of the value displayed when the method was called
+	// through a pointer to it, which is done for addressable values unless
+	// the DisablePointerMethods option is set.
+	Type reflect.Type
+
+	// Method names the method which panicked, such as String().  Panics in
+	// formatters registered with RegisterFormatter are named formatter.
+	Method string
+
+	// Value is the value passed to panic.
+	Value interface{}
+
+	// Stack is the stack trace of the goroutine at the time of the panic as
+	// formatted by runtime/debug.Stack.
+	Stack []byte
+}
+
+// panicFrames returns up to n frames of the stack of the panicking goroutine
+// when called by a deferred function, starting at the function which
+// panicked, as in main.T.String (t.go:12).
+func panicFrames(n int) []string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	var lines []string
+	panicking := false
+	for len(lines) < n {
+		frame, more := frames.Next()
+		switch {
+		case !panicking:
+			panicking = frame.Function == "runtime.gopanic"
+		case !strings.HasPrefix(frame.Function, "runtime."):
+			lines = append(lines, frame.Function+" ("+filepath.Base(frame.File)+":"+strconv.Itoa(frame.Line)+")")
+		}
+		if !more {
+			break
+		}
+	}
+	return lines
+}
+
+// catchPanic handles any panics that might occur during the handleMethods
+// calls.  The panic is reported to the OnMethodPanic option when it is set,
+// and written as (PANIC: value), or as <String() panicked: value> followed by
+// up to PanicStackFrames frames of its stack when the VerbosePanics option is
+// set.  The method which panicked is named by method.
+func catchPanic(cs *ConfigState, w io.Writer, v reflect.Value, method string) {
+	err := recover()
+	if err == nil {
+		return
+	}
+	if cs.OnMethodPanic != nil {
+		cs.OnMethodPanic(MethodPanic{Type: v.Type(), Method: method, Value: err, Stack: debug.Stack()})
+	}
+	if !cs.VerbosePanics {
+		w.Write(panicBytes)
+		fmt.Fprintf(w, "%v", err)
+		w.Write(closeParenBytes)
+		return
+	}
+	text := fmt.Sprintf("<%s panicked: %v", method, err)
+	if frames := panicFrames(cs.PanicStackFrames); len(frames) > 0 {
+		text += " at " + strings.Join(frames, ", ")
+	}
+	w.Write([]byte(text + ">"))
+}
@@ -0,0 +1,50 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type panicsStringer struct {
+	items []int
+}
+
+func (p panicsStringer) String() string {
+	return string(rune(p.items[3]))
+}
+
+var _ = Describe("Method Panic Tests", func() {
+	It("names the method which panicked", func() {
+		cs := spew.NewTestConfig()
+		cs.VerbosePanics = true
+		Expect(cs.Sdump(panicsStringer{})).To(HavePrefix(
+			"(spew_test.panicsStringer) <String() panicked: runtime error: index out of range [3] with length 0>"))
+	})
+
+	It("includes frames of the stack of the panic", func() {
+		cs := spew.NewTestConfig()
+		cs.VerbosePanics = true
+		cs.PanicStackFrames = 1
+		Expect(cs.Sdump(panicsStringer{})).To(MatchRegexp(
+			`^\(spew_test\.panicsStringer\) <String\(\) panicked: .* at github\.com/ehowe/rainbow-spew_test\.panicsStringer\.String \(panics_test\.go:\d+\)>`))
+	})
+
+	It("reports panics to the hook", func() {
+		cs := spew.NewTestConfig()
+		var panics []spew.MethodPanic
+		cs.OnMethodPanic = func(p spew.MethodPanic) { panics = append(panics, p) }
+		Expect(cs.Sdump(panicsStringer{})).To(HavePrefix("(spew_test.panicsStringer) (PANIC: runtime error"))
+		Expect(panics).To(HaveLen(1))
+		Expect(panics[0].Method).To(Equal("String()"))
+		// The method is invoked through a pointer to the value when unsafe
+		// makes it addressable.
+		typ := "*spew_test.panicsStringer"
+		if spew.UnsafeDisabled {
+			typ = "spew_test.panicsStringer"
+		}
+		Expect(panics[0].Type.String()).To(Equal(typ))
+		Expect(panics[0].Value).To(MatchError(ContainSubstring("index out of range")))
+		Expect(string(panics[0].Stack)).To(ContainSubstring("panicsStringer.String"))
+	})
+})
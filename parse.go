@@ -0,0 +1,163 @@
+package spew
+
+import "reflect"
+
+// Node is a single value of the tree returned by Parse.  The tree holds the
+// values in the same order Dump displays them, so custom renderers can be
+// written on top of it without reimplementing the traversal.
+type Node struct {
+	// Parent is the node containing the node, or nil for the root.
+	Parent *Node
+
+	// Kind and Type describe the value after any interface has been
+	// unpacked.  Type is nil when the node is a nil interface, in which case
+	// Kind is reflect.Interface.
+	Kind reflect.Kind
+	Type reflect.Type
+
+	// Name and Tag are set when the node is a struct field and Key is set
+	// when the node is a map value.  Index is the position of the node
+	// within Parent.Children.  Keys are not linked into the tree, so their
+	// Parent is nil.
+	Name  string
+	Tag   reflect.StructTag
+	Key   *Node
+	Index int
+
+	// Depth is the number of nodes between the node and the root.
+	Depth int
+
+	// Addr is the address pointed to by pointers, channels, functions and
+	// unsafe pointers, or the address of the data of maps and slices.
+	Addr uintptr
+
+	// Value holds the value of scalar kinds normalized to one of bool,
+	// int64, uint64, float64, complex128, string or uintptr, and is nil for
+	// other kinds.
+	Value interface{}
+
+	// Str holds the result of invoking an error or Stringer interface.  The
+	// node has no children unless the ContinueOnMethod option is set.
+	Str string
+
+	// Children holds the elements of arrays, slices and maps, the fields of
+	// structs, and the value pointed to by pointers.
+	Children []*Node
+
+	// IsNil reports whether the value is nil, Circular whether it is a
+	// circular reference to a value already being displayed, and Truncated
+	// whether its children were omitted due to the MaxDepth option.
+	IsNil     bool
+	Circular  bool
+	Truncated bool
+
+	// rv is the value the node was parsed from, which is used by
+	// TextRenderer.
+	rv reflect.Value
+
+	// ids and stats are set on the roots rendered by Dump, so TextRenderer
+	// numbers the pointers of all of its arguments and counts their nodes as
+	// Dump does without a renderer.
+	ids   *pointerIDs
+	stats *DumpStats
+}
+
+// exportNode returns the Node for n and its descendants within parent.
+func exportNode(n *node, parent *Node) *Node {
+	e := &Node{
+		Parent:    parent,
+		Kind:      n.kind,
+		Type:      n.typ,
+		Name:      n.name,
+		Tag:       n.tag,
+		Index:     n.index,
+		Depth:     n.depth,
+		Addr:      n.addr,
+		Value:     n.value,
+		Str:       n.str,
+		IsNil:     n.isNil,
+		Circular:  n.cycle,
+		Truncated: n.truncated,
+		rv:        n.rv,
+	}
+	if n.key != nil {
+		e.Key = exportNode(n.key, nil)
+	}
+	if len(n.children) > 0 {
+		e.Children = make([]*Node, len(n.children))
+		for i, c := range n.children {
+			e.Children[i] = exportNode(c, e)
+		}
+	}
+	return e
+}
+
+// importNode returns the node for e and its descendants within parent, which
+// allows the renderers consuming nodes to render trees built or modified by
+// callers.
+func importNode(e *Node, parent *node) *node {
+	n := &node{
+		parent:    parent,
+		kind:      e.Kind,
+		typ:       e.Type,
+		name:      e.Name,
+		tag:       e.Tag,
+		index:     e.Index,
+		depth:     e.Depth,
+		addr:      e.Addr,
+		value:     e.Value,
+		str:       e.Str,
+		isNil:     e.IsNil,
+		cycle:     e.Circular,
+		truncated: e.Truncated,
+		rv:        e.rv,
+	}
+	if e.Key != nil {
+		n.key = importNode(e.Key, nil)
+	}
+	if len(e.Children) > 0 {
+		n.children = make([]*node, len(e.Children))
+		for i, c := range e.Children {
+			n.children[i] = importNode(c, n)
+		}
+	}
+	return n
+}
+
+// parse returns the tree of nodes for v.
+func parse(cs *ConfigState, v interface{}) *Node {
+	return parseValue(cs, reflect.ValueOf(v))
+}
+
+// parseValue returns the tree of nodes for v, which is a nil interface when v
+// is the zero Value.
+func parseValue(cs *ConfigState, v reflect.Value) *Node {
+	if !v.IsValid() {
+		return &Node{Kind: reflect.Interface, IsNil: true}
+	}
+	return exportNode(buildTree(cs, v), nil)
+}
+
+/*
+Parse walks v exactly as Dump does and returns the resulting tree of nodes
+rather than writing it, honoring options such as MaxDepth, SortKeys and
+DisableMethods.  This allows custom renderers to be written without forking
+the formatting code.  For example, the field names of a struct can be listed
+with:
+
+	for _, field := range spew.Parse(v).Children {
+		fmt.Println(field.Name)
+	}
+
+Pointers are nodes of their own whose only child is the value they point to,
+and circular references are marked rather than followed.
+*/
+func (c *ConfigState) Parse(v interface{}) *Node {
+	return parse(c, v)
+}
+
+// Parse walks v and returns the resulting tree of nodes.  See
+// ConfigState.Parse for details.
+func Parse(v interface{}) *Node {
+	return parse(global(), v)
+}
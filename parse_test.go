@@ -0,0 +1,60 @@
+package spew_test
+
+import (
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type parseTester struct {
+	A int `json:"a"`
+	B map[string]bool
+	C *parseTester
+}
+
+var _ = Describe("Parse Tests", func() {
+	It("returns the tree of nodes", func() {
+		v := &parseTester{A: 1, B: map[string]bool{"x": true}}
+		v.C = v
+		root := spew.NewTestConfig().Parse(v)
+		Expect(root.Kind).To(Equal(reflect.Ptr))
+		Expect(root.Parent).To(BeNil())
+		Expect(root.Children).To(HaveLen(1))
+
+		s := root.Children[0]
+		Expect(s.Parent).To(BeIdenticalTo(root))
+		Expect(s.Type).To(Equal(reflect.TypeOf(parseTester{})))
+		Expect(s.Depth).To(Equal(1))
+		Expect(s.Children).To(HaveLen(3))
+
+		a := s.Children[0]
+		Expect(a.Name).To(Equal("A"))
+		Expect(a.Tag.Get("json")).To(Equal("a"))
+		Expect(a.Value).To(Equal(int64(1)))
+
+		b := s.Children[1]
+		Expect(b.Children).To(HaveLen(1))
+		Expect(b.Children[0].Key.Value).To(Equal("x"))
+		Expect(b.Children[0].Value).To(Equal(true))
+
+		c := s.Children[2]
+		Expect(c.Index).To(Equal(2))
+		Expect(c.Circular).To(BeTrue())
+		Expect(c.Addr).To(Equal(reflect.ValueOf(v).Pointer()))
+		Expect(c.Children).To(BeEmpty())
+	})
+
+	It("marks nil, truncated and Stringer values", func() {
+		Expect(spew.Parse(nil)).To(Equal(&spew.Node{Kind: reflect.Interface, IsNil: true}))
+
+		cfg := spew.NewTestConfig()
+		cfg.MaxDepth = 1
+		root := cfg.Parse([][]int{{1}, nil})
+		Expect(root.Children[0].Truncated).To(BeTrue())
+		Expect(root.Children[1].IsNil).To(BeTrue())
+
+		Expect(spew.Parse(stringer("x")).Str).To(Equal("stringer x"))
+	})
+})
@@ -0,0 +1,54 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type pointerIDsNode struct {
+	A, B *int
+	Next *pointerIDsNode
+}
+
+var _ = Describe("Pointer IDs Tests", func() {
+	n := 5
+	v := &pointerIDsNode{A: &n, B: &n, Next: &pointerIDsNode{}}
+
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.PointerIDs = true
+		return cs
+	}
+
+	It("labels pointers with sequential identifiers in Dump output", func() {
+		expected := "(*spew_test.pointerIDsNode)(#1)({\n" +
+			"  A: (*int)(#2)(5),\n" +
+			"  B: (*int)(#2)(5),\n" +
+			"  Next: (*spew_test.pointerIDsNode)(#3)({\n" +
+			"    A: (*int)(<nil>),\n" +
+			"    B: (*int)(<nil>),\n" +
+			"    Next: (*spew_test.pointerIDsNode)(<nil>)\n" +
+			"  })\n" +
+			"})\n"
+		cs := newConfig()
+		Expect(cs.Sdump(v)).To(Equal(expected))
+		Expect(cs.Sdump(v)).To(Equal(expected))
+	})
+
+	It("shares identifiers between the arguments of a call", func() {
+		Expect(newConfig().Sdump(&n, &n)).To(Equal("(*int)(#1)(5)\n(*int)(#1)(5)\n"))
+	})
+
+	It("labels pointers with sequential identifiers in Formatter output", func() {
+		cs := newConfig()
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(v))).To(Equal(
+			"<*>(#1){A:<*>(#2)5 B:<*>(#2)5 Next:<*>(#3){A:<nil> B:<nil> Next:<nil>}}"))
+	})
+
+	It("labels pointers with sequential identifiers in trees", func() {
+		Expect(newConfig().SdumpTree(&n)).To(ContainSubstring("#1"))
+	})
+})
@@ -0,0 +1,81 @@
+package spew
+
+import (
+	"io"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// packagePath is the import path of this package, which prefixes the names
+// of its functions.
+const packagePath = "github.com/ehowe/rainbow-spew"
+
+// callerLocation returns the file and line of the first caller outside of
+// this package, followed by the name of its function when funcs is set, and
+// false when there is no such caller.
+func callerLocation(funcs bool) (string, bool) {
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") {
+			loc := filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+			if funcs {
+				loc += " " + frame.Function
+			}
+			return loc, true
+		}
+		if !more {
+			return "", false
+		}
+	}
+}
+
+// outputPrefix returns the prefix written ahead of the output of a call for
+// the TimestampFormat and ShowCaller options, such as "15:04:05 main.go:42: ",
+// and nil when neither applies.
+func outputPrefix(cs *ConfigState) []byte {
+	var prefix []byte
+	if cs.TimestampFormat != "" {
+		prefix = time.Now().AppendFormat(prefix, cs.TimestampFormat)
+		prefix = append(prefix, ' ')
+	}
+	if cs.ShowCaller {
+		if loc, ok := callerLocation(cs.CallerFuncs); ok {
+			prefix = append(prefix, loc+": "...)
+		}
+	}
+	return prefix
+}
+
+// prefixWriter is an io.Writer which writes prefix ahead of the first write
+// to w.
+type prefixWriter struct {
+	w      io.Writer
+	prefix []byte
+}
+
+// Write writes b to w, preceded by the prefix on the first call.  The number
+// of bytes written includes the prefix.
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	if p.prefix != nil {
+		b = append(p.prefix, b...)
+		p.prefix = nil
+	}
+	return p.w.Write(b)
+}
+
+// outputWriter returns the writer the output of the Print family of
+// functions written to w goes through, which copies it to the AlsoWrite
+// writer and writes the prefix given by outputPrefix ahead of it.
+func outputWriter(cs *ConfigState, w io.Writer) io.Writer {
+	w = teeWriter(cs, w)
+	prefix := outputPrefix(cs)
+	if prefix == nil {
+		return w
+	}
+	return &prefixWriter{w: w, prefix: prefix}
+}
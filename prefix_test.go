@@ -0,0 +1,58 @@
+package spew_test
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prefix Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.ShowCaller = true
+		return cs
+	}
+
+	It("prefixes dumps with the caller", func() {
+		cs := newConfig()
+		_, _, line, _ := runtime.Caller(0)
+		s := cs.Sdump(1)
+		Expect(s).To(Equal("prefix_test.go:" + strconv.Itoa(line+1) + ": (int) 1\n"))
+	})
+
+	It("prefixes printed output with the caller", func() {
+		cs := newConfig()
+		var buf bytes.Buffer
+		_, _, line, _ := runtime.Caller(0)
+		cs.Fprintf(&buf, "%v", 1)
+		Expect(buf.String()).To(Equal("prefix_test.go:" + strconv.Itoa(line+1) + ": 1"))
+	})
+
+	It("includes the function of the caller", func() {
+		cs := newConfig()
+		cs.CallerFuncs = true
+		Expect(cs.Sdump(1)).To(MatchRegexp(`^prefix_test\.go:\d+ github\.com/ehowe/rainbow-spew_test\.init\.func[\d.]+: \(int\) 1\n$`))
+	})
+
+	It("doesn't prefix output by default", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(1)).To(Equal("(int) 1\n"))
+		Expect(cs.Sprint(1)).To(Equal("1"))
+	})
+
+	It("prefixes output with a timestamp", func() {
+		cs := spew.NewTestConfig()
+		cs.TimestampFormat = "15:04:05.000"
+		Expect(cs.Sdump(1)).To(MatchRegexp(`^\d\d:\d\d:\d\d\.\d{3} \(int\) 1\n$`))
+		Expect(cs.Sprint(1)).To(Equal("1"))
+
+		var buf bytes.Buffer
+		cs.ShowCaller = true
+		cs.Fprint(&buf, 1)
+		Expect(buf.String()).To(MatchRegexp(`^\d\d:\d\d:\d\d\.\d{3} prefix_test\.go:\d+: 1$`))
+	})
+})
@@ -0,0 +1,237 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// protoState contains information about the state of a protobuf text format
+// dump operation.
+type protoState struct {
+	buf   bytes.Buffer
+	depth int
+}
+
+// indent writes the indentation for the current depth.
+func (p *protoState) indent() {
+	p.buf.WriteString(strings.Repeat("  ", p.depth))
+}
+
+// protoFieldName returns the name of the struct field described by n in the
+// protobuf text format.  The name given by a protobuf struct tag is used
+// when there is one, and the field name in snake_case otherwise.
+func protoFieldName(n *node) string {
+	for _, opt := range strings.Split(n.tag.Get("protobuf"), ",") {
+		if name, ok := strings.CutPrefix(opt, "name="); ok {
+			return name
+		}
+	}
+	return caseFieldName(n.name, FieldCaseSnake)
+}
+
+// isIdent returns whether s is an identifier which may be written unquoted as
+// an enum value.
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' ||
+			i > 0 && r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// protoScalar returns the text of the scalar value n, and false when n is not
+// a scalar.  Values with a Stringer or error interface are written as enum
+// values when their result is an identifier, and as strings otherwise.
+func protoScalar(n *node) (string, bool) {
+	if n.str != "" {
+		if isScalarKind(n.kind) && isIdent(n.str) {
+			return n.str, true
+		}
+		return strconv.Quote(n.str), true
+	}
+	if (n.kind == reflect.Slice || n.kind == reflect.Array) && !n.truncated &&
+		n.typ.Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, len(n.children))
+		for i, c := range n.children {
+			b[i] = byte(c.value.(uint64))
+		}
+		return strconv.Quote(string(b)), true
+	}
+	switch v := n.value.(type) {
+	case bool:
+		return strconv.FormatBool(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, n.typ.Bits()), true
+	case complex128:
+		return strconv.Quote(strconv.FormatComplex(v, 'g', -1, n.typ.Bits())), true
+	case string:
+		return strconv.Quote(v), true
+	case uintptr:
+		return strconv.FormatUint(uint64(v), 10), true
+	}
+	return "", false
+}
+
+// field writes n as the field name.  Pointers are followed, nil values are
+// omitted, and each element of arrays, slices and maps is written as a
+// repetition of the field.
+func (p *protoState) field(name string, n *node) {
+	for n.kind == reflect.Ptr && !n.isNil && !n.cycle && n.str == "" && len(n.children) > 0 {
+		n = n.children[0]
+	}
+	switch {
+	case n.isNil || n.kind == reflect.Invalid || n.kind == reflect.Chan ||
+		n.kind == reflect.Func || n.kind == reflect.UnsafePointer:
+		return
+	case n.cycle:
+		p.indent()
+		p.buf.WriteString("# " + name + ": " + string(circularBytes) + "\n")
+		return
+	case n.truncated:
+		p.indent()
+		p.buf.WriteString("# " + name + ": ")
+		p.buf.Write(maxNewlineBytes)
+		return
+	}
+
+	if s, ok := protoScalar(n); ok {
+		p.indent()
+		p.buf.WriteString(name + ": " + s + "\n")
+		return
+	}
+
+	switch n.kind {
+	case reflect.Struct:
+		p.message(name, func() { p.fields(n) })
+
+	case reflect.Map:
+		for _, c := range n.children {
+			p.message(name, func() {
+				p.field("key", c.key)
+				p.field("value", c)
+			})
+		}
+
+	case reflect.Slice, reflect.Array:
+		for _, c := range n.children {
+			if c.kind == reflect.Slice || c.kind == reflect.Array {
+				if _, ok := protoScalar(c); !ok {
+					// Repeated fields can't nest, so nested lists are
+					// written as messages with a repeated value field.
+					p.message(name, func() { p.field("value", c) })
+					continue
+				}
+			}
+			p.field(name, c)
+		}
+	}
+}
+
+// message writes the message field name with the fields written by body.
+func (p *protoState) message(name string, body func()) {
+	p.indent()
+	p.buf.WriteString(name + " {\n")
+	p.depth++
+	body()
+	p.depth--
+	p.indent()
+	p.buf.WriteString("}\n")
+}
+
+// fields writes the fields of the struct n.
+func (p *protoState) fields(n *node) {
+	for _, c := range n.children {
+		p.field(protoFieldName(c), c)
+	}
+}
+
+// root writes the fields of n when it is a struct or a pointer to one, and n
+// as a value field otherwise.
+func (p *protoState) root(n *node) {
+	for n.kind == reflect.Ptr && !n.isNil && !n.cycle && n.str == "" && len(n.children) > 0 {
+		n = n.children[0]
+	}
+	if n.kind == reflect.Struct && n.str == "" && !n.truncated {
+		p.fields(n)
+		return
+	}
+	p.field("value", n)
+}
+
+// fdumpProtoText writes the protobuf text format for each of the passed
+// arguments to w.
+func fdumpProtoText(cs *ConfigState, w io.Writer, a ...interface{}) {
+	p := &protoState{}
+	for i, arg := range a {
+		if i > 0 {
+			p.buf.WriteString("\n")
+		}
+		if arg == nil {
+			continue
+		}
+		p.root(buildTree(cs, reflect.ValueOf(arg)))
+	}
+	w.Write(p.buf.Bytes())
+	flushSink(w)
+}
+
+/*
+FdumpProtoText writes the passed arguments to w in the style of the protobuf
+text format, which tools that already parse protobuf messages understand and
+which some find easier to read for message-like structs.  The fields of
+structs are written as name: value pairs, with nested structs written as
+blocks.  For example:
+
+	name: "api"
+	replicas: 3
+	ports {
+	  port: 80
+	}
+	ports {
+	  port: 443
+	}
+
+Field names are taken from protobuf struct tags when present and are written in
+snake_case otherwise.  Pointers are followed, nil values are omitted, and each
+element of arrays and slices is written as a repetition of the field.  Maps
+are written as repeated entries with key and value fields, and byte slices as
+strings.  Values with a Stringer or error interface are written as enum values
+when the result is an identifier and as strings otherwise.  Circular references
+and values beyond MaxDepth are written as comments.  Arguments which are not
+structs are written as a value field, and multiple arguments are separated by
+blank lines.
+*/
+func (c *ConfigState) FdumpProtoText(w io.Writer, a ...interface{}) {
+	fdumpProtoText(c, w, a...)
+}
+
+// SdumpProtoText returns a string with the passed arguments written exactly
+// the same as FdumpProtoText.
+func (c *ConfigState) SdumpProtoText(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpProtoText(c, &buf, a...)
+	return buf.String()
+}
+
+// FdumpProtoText writes the passed arguments to w in the style of the
+// protobuf text format.  See ConfigState.FdumpProtoText for details.
+func FdumpProtoText(w io.Writer, a ...interface{}) {
+	fdumpProtoText(global(), w, a...)
+}
+
+// SdumpProtoText returns a string with the passed arguments written exactly
+// the same as FdumpProtoText.
+func SdumpProtoText(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpProtoText(global(), &buf, a...)
+	return buf.String()
+}
@@ -0,0 +1,99 @@
+package spew_test
+
+import (
+	"errors"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type protoPort struct {
+	Port int
+}
+
+type protoTester struct {
+	ServiceName string `protobuf:"bytes,1,opt,name=service"`
+	Replicas    uint8
+	Ports       []protoPort
+	Labels      map[string]int
+	Payload     []byte
+	Grid        [][]int
+	Owner       *protoPort
+	Err         error
+	Next        *protoTester
+}
+
+type protoName struct {
+	N string
+}
+
+func (p *protoName) String() string {
+	return "name " + p.N
+}
+
+type protoNamed struct {
+	Name *protoName
+}
+
+var _ = Describe("ProtoText Tests", func() {
+	It("writes structs in protobuf text format", func() {
+		cs := spew.NewTestConfig()
+		cs.SortKeys = true
+		v := &protoTester{
+			ServiceName: "api",
+			Replicas:    3,
+			Ports:       []protoPort{{80}, {443}},
+			Labels:      map[string]int{"b": 2, "a": 1},
+			Payload:     []byte("hi\n"),
+			Grid:        [][]int{{1, 2}, {3}},
+			Err:         errors.New("boom"),
+		}
+		v.Next = v
+		Expect(cs.SdumpProtoText(v)).To(Equal(`service: "api"
+replicas: 3
+ports {
+  port: 80
+}
+ports {
+  port: 443
+}
+labels {
+  key: "a"
+  value: 1
+}
+labels {
+  key: "b"
+  value: 2
+}
+payload: "hi\n"
+grid {
+  value: 1
+  value: 2
+}
+grid {
+  value: 3
+}
+err: "boom"
+# next: <already shown>
+`))
+	})
+
+	It("writes values beyond MaxDepth as comments", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		v := protoTester{Ports: []protoPort{{80}}}
+		Expect(cs.SdumpProtoText(v)).To(Equal("service: \"\"\nreplicas: 0\n# ports: <max depth reached>\n"))
+	})
+
+	It("writes other arguments as value fields", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.SdumpProtoText(1.5, []string{"a", "b"})).To(Equal("value: 1.5\n\nvalue: \"a\"\nvalue: \"b\"\n"))
+	})
+
+	It("writes pointers displayed by their methods", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.SdumpProtoText(protoNamed{Name: &protoName{N: "a"}})).To(Equal("name: \"name a\"\n"))
+		Expect(cs.SdumpProtoText(&protoName{N: "b"}, errors.New("x"))).To(Equal("value: \"name b\"\n\nvalue: \"x\"\n"))
+	})
+})
@@ -0,0 +1,157 @@
+package spew
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// QuietCheck is a set of the heuristics used to find anomalies when the Quiet
+// option is set.
+type QuietCheck uint
+
+const (
+	// QuietNilRequired reports nil pointers, interfaces, maps, slices,
+	// channels and functions in struct fields tagged as required.
+	QuietNilRequired QuietCheck = 1 << iota
+
+	// QuietEmptyRequired reports empty strings in struct fields tagged as
+	// required.
+	QuietEmptyRequired
+
+	// QuietNaN reports floats which are NaN or infinite.
+	QuietNaN
+
+	// QuietPastTimes reports time.Time values before the current time.
+	QuietPastTimes
+
+	// QuietFutureTimes reports time.Time values after the current time.
+	QuietFutureTimes
+
+	// QuietDefaultChecks are the checks used when QuietChecks is 0.
+	QuietDefaultChecks = QuietNilRequired | QuietEmptyRequired | QuietNaN
+)
+
+// timeType is the reflect type of time.Time.
+var timeType = reflect.TypeOf(time.Time{})
+
+// isRequired returns whether the struct tag marks its field as required,
+// either with a spew or a validate tag which includes the required option.
+func isRequired(tag reflect.StructTag) bool {
+	for _, key := range []string{"spew", "validate"} {
+		for _, opt := range tagOptions(tag, key) {
+			if opt == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// anomaly returns a description of why n is interesting according to checks,
+// or an empty string when it is not.
+func anomaly(n *node, checks QuietCheck, now time.Time) string {
+	required := n.parent != nil && n.parent.kind == reflect.Struct && isRequired(n.tag)
+	switch {
+	case checks&QuietNilRequired != 0 && required && n.isNil:
+		return "nil required value"
+
+	case checks&QuietEmptyRequired != 0 && required && n.kind == reflect.String &&
+		n.value == "":
+		return "empty required string"
+
+	case checks&QuietNaN != 0 && (n.kind == reflect.Float32 || n.kind == reflect.Float64):
+		f, _ := n.value.(float64)
+		if math.IsNaN(f) {
+			return "NaN"
+		}
+		if math.IsInf(f, 0) {
+			return "infinite float"
+		}
+
+	case checks&(QuietPastTimes|QuietFutureTimes) != 0 && n.typ == timeType:
+		v := n.rv
+		if !v.CanInterface() {
+			if UnsafeDisabled {
+				return ""
+			}
+			v = unsafeReflectValue(v)
+		}
+		t := v.Interface().(time.Time)
+		if checks&QuietPastTimes != 0 && t.Before(now) {
+			return "time in the past: " + t.String()
+		}
+		if checks&QuietFutureTimes != 0 && t.After(now) {
+			return "time in the future: " + t.String()
+		}
+	}
+	return ""
+}
+
+// nodePath returns the Go selector expression which leads from the root to n,
+// such as .Servers[0].Config["port"].
+func nodePath(n *node) string {
+	var parts []string
+	for ; n.parent != nil; n = n.parent {
+		switch n.parent.kind {
+		case reflect.Struct:
+			parts = append(parts, "."+n.name)
+		case reflect.Map:
+			var key bytes.Buffer
+			writeCanonical(&key, n.key, n.parent.typ.Key())
+			parts = append(parts, "["+key.String()+"]")
+		case reflect.Array, reflect.Slice:
+			parts = append(parts, fmt.Sprintf("[%d]", n.index))
+		}
+	}
+	var buf strings.Builder
+	for i := len(parts) - 1; i >= 0; i-- {
+		buf.WriteString(parts[i])
+	}
+	return buf.String()
+}
+
+// dumpAnomalies writes a line to w for each node of v which one of the quiet
+// checks of cs finds interesting.  Each line holds the path of the node from
+// the root, its type, and the reason it was reported.
+func dumpAnomalies(cs *ConfigState, w io.Writer, v reflect.Value) {
+	checks := cs.QuietChecks
+	if checks == 0 {
+		checks = QuietDefaultChecks
+	}
+	now := time.Now()
+	root := v.Type().String()
+
+	wk := newWalker(cs)
+	wk.enter = func(n *node) {
+		reason := anomaly(n, checks, now)
+		if reason == "" {
+			return
+		}
+		w.Write(openParenBytes)
+		printType(w, cs, root)
+		w.Write(closeParenBytes)
+		w.Write([]byte(nodePath(n)))
+		w.Write(colonSpaceBytes)
+		w.Write([]byte(reason))
+
+		// Nil interfaces have no type of their own, so the type of the
+		// struct field holding them is shown instead.
+		typ := n.typ
+		if typ == nil && n.parent != nil && n.parent.kind == reflect.Struct {
+			typ = n.parent.typ.Field(n.field).Type
+		}
+		if typ != nil {
+			w.Write(spaceBytes)
+			w.Write(openParenBytes)
+			printType(w, cs, typ.String())
+			w.Write(closeParenBytes)
+		}
+		w.Write(newlineBytes)
+	}
+	wk.walk(&node{}, v)
+}
@@ -0,0 +1,67 @@
+package spew_test
+
+import (
+	"math"
+	"time"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type quietServer struct {
+	Host    string `validate:"required"`
+	Port    *int   `spew:"required"`
+	Backup  *int
+	Handler interface{} `spew:"required"`
+}
+
+type quietHidden struct {
+	Port    int         `spew:"-"`
+	Handler interface{} `spew:"required"`
+}
+
+type quietConfig struct {
+	Servers []quietServer
+	Ratios  map[string]float64
+	Expires time.Time
+}
+
+var _ = Describe("Quiet Tests", func() {
+	port := 80
+	v := quietConfig{
+		Servers: []quietServer{
+			{Host: "a", Port: &port, Handler: 1},
+			{},
+		},
+		Ratios:  map[string]float64{"ok": 1, "bad": math.NaN()},
+		Expires: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	It("reports only anomalies", func() {
+		cs := spew.NewTestConfig()
+		cs.Quiet = true
+		cs.SortKeys = true
+		Expect(cs.Sdump(v)).To(Equal(`(spew_test.quietConfig).Servers[1].Host: empty required string (string)
+(spew_test.quietConfig).Servers[1].Port: nil required value (*int)
+(spew_test.quietConfig).Servers[1].Handler: nil required value (interface {})
+(spew_test.quietConfig).Ratios["bad"]: NaN (float64)
+`))
+	})
+
+	It("honors the configured checks", func() {
+		cs := spew.NewTestConfig()
+		cs.Quiet = true
+		cs.QuietChecks = spew.QuietPastTimes
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.quietConfig).Expires: time in the past: 2000-01-01 00:00:00 +0000 UTC (time.Time)\n"))
+
+		cs.QuietChecks = spew.QuietFutureTimes
+		Expect(cs.Sdump(v)).To(BeEmpty())
+	})
+
+	It("uses the static type of fields after hidden ones", func() {
+		cs := spew.NewTestConfig()
+		cs.Quiet = true
+		Expect(cs.Sdump(quietHidden{})).To(Equal("(spew_test.quietHidden).Handler: nil required value (interface {})\n"))
+	})
+})
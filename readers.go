@@ -0,0 +1,114 @@
+package spew
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	unreadBytes   = []byte("unread: ")
+	bufferedBytes = []byte("buffered: ")
+)
+
+// bufioBuffered returns the bytes buffered by the bufio.Reader v, which are
+// read from its unexported fields since peeking through its methods forgets
+// the last byte read for UnreadByte, and false when its layout is unknown.
+func bufioBuffered(v reflect.Value) ([]byte, bool) {
+	buf, r, w := v.FieldByName("buf"), v.FieldByName("r"), v.FieldByName("w")
+	if buf.Kind() != reflect.Slice || buf.Type().Elem().Kind() != reflect.Uint8 ||
+		r.Kind() != reflect.Int || w.Kind() != reflect.Int {
+		return nil, false
+	}
+	b := buf.Bytes()
+	start, end := int(r.Int()), int(w.Int())
+	if start < 0 || start > end || end > len(b) {
+		return nil, false
+	}
+	return b[start:end], true
+}
+
+// peekReader returns up to n of the upcoming bytes of the reader v, the total
+// number of upcoming bytes, and whether they are the bytes buffered by a
+// bufio.Reader rather than all the unread bytes.  It returns false when v is
+// not a pointer to a *bytes.Buffer, *bytes.Reader, *strings.Reader or
+// *bufio.Reader, or to one which can be addressed, since the contents of
+// other readers can't be seen without consuming them.
+func peekReader(v reflect.Value, n int) (peek []byte, total int, buffered, ok bool) {
+	if v.Kind() != reflect.Ptr {
+		if !v.CanAddr() {
+			return nil, 0, false, false
+		}
+		v = v.Addr()
+	}
+	if v.IsNil() {
+		return nil, 0, false, false
+	}
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return nil, 0, false, false
+		}
+		v = unsafeReflectValue(v)
+	}
+
+	var b []byte
+	switch r := v.Interface().(type) {
+	case *bytes.Buffer:
+		b = r.Bytes()
+	case *bytes.Reader:
+		b = make([]byte, r.Len())
+		r.ReadAt(b, r.Size()-int64(len(b)))
+	case *strings.Reader:
+		b = make([]byte, r.Len())
+		r.ReadAt(b, r.Size()-int64(len(b)))
+	case *bufio.Reader:
+		if b, ok = bufioBuffered(v.Elem()); !ok {
+			return nil, 0, false, false
+		}
+		buffered = true
+	default:
+		return nil, 0, false, false
+	}
+	return b[:min(n, len(b))], len(b), buffered, true
+}
+
+// dumpPeek writes the upcoming bytes peek of a reader, preceded by the number
+// of unread or buffered bytes and followed by the number of bytes not shown.
+func (d *dumpState) dumpPeek(peek []byte, total int, buffered bool) {
+	withParens(d, func(d *dumpState) {
+		label := unreadBytes
+		if buffered {
+			label = bufferedBytes
+		}
+		withColor(d.w, label, d.cs.Color.Length...)
+		printNumber(d.w, d.cs, total)
+	})
+	d.w.Write(spaceBytes)
+	printString(d.w, d.cs, strconv.Quote(string(peek)))
+	writeTruncation(d.w, d.cs, total-len(peek))
+	if total > len(peek) {
+		d.countTruncation()
+	}
+}
+
+/*
+PeekReader returns a reader of the bytes of r with up to n of them buffered
+ahead of being read, so Dump shows them when the PeekReaders option is set.
+The contents of arbitrary readers can't be shown without consuming them, so
+wrapping a reader this way lets the bytes about to be read be seen in dumps
+while leaving them to be read as usual.  Errors from filling the buffer are
+returned by the reads which reach them.  For example:
+
+	r := spew.PeekReader(resp.Body, 64)
+	spew.Dump(r)
+
+	// (*bufio.Reader) (buffered: 64) "{\"id\": 7, ..."
+*/
+func PeekReader(r io.Reader, n int) *bufio.Reader {
+	br := bufio.NewReaderSize(r, n)
+	br.Peek(n)
+	return br
+}
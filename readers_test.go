@@ -0,0 +1,55 @@
+package spew_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing/iotest"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type readersRequest struct {
+	Body io.Reader
+	Raw  *bytes.Buffer
+}
+
+var _ = Describe("Peek Readers Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.PeekReaders = 5
+		return cs
+	}
+
+	It("displays the unread bytes of readers without consuming them", func() {
+		cs := newConfig()
+		r := strings.NewReader("hello world")
+		b := make([]byte, 1)
+		r.Read(b)
+		req := readersRequest{Body: r, Raw: bytes.NewBufferString("abc")}
+		Expect(cs.Sdump(req)).To(Equal("(spew_test.readersRequest) {\n" +
+			"  Body: (*strings.Reader) (unread: 10) \"ello \"... (+5 bytes),\n" +
+			"  Raw: (*bytes.Buffer) (unread: 3) \"abc\"\n" +
+			"}\n"))
+		Expect(r.Len()).To(Equal(10))
+		Expect(cs.Sdump(bytes.NewReader(nil))).To(Equal("(*bytes.Reader) (unread: 0) \"\"\n"))
+	})
+
+	It("displays the bytes buffered by PeekReader", func() {
+		cs := newConfig()
+		cs.PeekReaders = 20
+		r := spew.PeekReader(iotest.OneByteReader(strings.NewReader("0123456789abcdefghij")), 16)
+		Expect(cs.Sdump(r)).To(Equal("(*bufio.Reader) (buffered: 16) \"0123456789abcdef\"\n"))
+		all, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(all)).To(Equal("0123456789abcdefghij"))
+	})
+
+	It("displays readers as usual without the option", func() {
+		cs := spew.NewTestConfig()
+		cs.DisablePointerAddresses = true
+		Expect(cs.Sdump(strings.NewReader("hi"))).To(ContainSubstring("s: (string) (len: 2) \"hi\""))
+	})
+})
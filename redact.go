@@ -0,0 +1,115 @@
+package spew
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// DefaultRedactFields is the list of patterns used to find the struct fields
+// and map entries to redact when the Redact option is set and RedactFields is
+// nil.  Keys are only matched when the whole name is a key or names the kind
+// of key, such as APIKey and private_key, so names like Monkey, PrimaryKey
+// and SortKeys are left alone.
+var DefaultRedactFields = []string{
+	"password", "passwd", "token", "secret", "authorization",
+	"^keys?$", "(api|private|access|signing|encryption)_?keys?",
+}
+
+// redactor decides which values are redacted according to the Redact,
+// RedactFields and RedactTypes options.  A nil redactor redacts nothing.
+type redactor struct {
+	fields []*regexp.Regexp
+	types  []reflect.Type
+}
+
+// newRedactor returns the redactor for the options of cs, or nil when the
+// Redact option is not set.  Patterns which are not valid regular
+// expressions are skipped.
+func newRedactor(cs *ConfigState) *redactor {
+	if !cs.Redact {
+		return nil
+	}
+	patterns := cs.RedactFields
+	if patterns == nil {
+		patterns = DefaultRedactFields
+	}
+	r := &redactor{types: cs.RedactTypes}
+	for _, pattern := range patterns {
+		if re, err := regexp.Compile("(?i)" + pattern); err == nil {
+			r.fields = append(r.fields, re)
+		}
+	}
+	return r
+}
+
+// typeListed returns whether typ is one of types or implements one of the
+// interface types among them.
+func typeListed(types []reflect.Type, typ reflect.Type) bool {
+	for _, t := range types {
+		if t == typ || t.Kind() == reflect.Interface && typ.Implements(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// redacts returns whether the value of type typ held by the struct field or
+// map entry named name is redacted.  The name is empty for other values.
+func (r *redactor) redacts(name string, typ reflect.Type) bool {
+	if r == nil {
+		return false
+	}
+	if name != "" {
+		for _, re := range r.fields {
+			if re.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return typeListed(r.types, typ)
+}
+
+// mapKeyName returns the name map entries with the key v are redacted by,
+// which is the key itself when it is a string, and empty otherwise.
+func mapKeyName(v reflect.Value) string {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.String {
+		return ""
+	}
+	return v.String()
+}
+
+// keyName returns the string key of the map entry the node n is the value
+// of, or empty when it is not the value of one.
+func (n *node) keyName() string {
+	if n.key != nil && n.parent != nil && n.parent.kind == reflect.Map {
+		if s, ok := n.key.value.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// redactName returns the name the node n is redacted by, which is the name of
+// the struct field or the string key of the map entry it is the value of.
+func (n *node) redactName() string {
+	if n.name != "" {
+		return n.name
+	}
+	return n.keyName()
+}
+
+// customRedaction returns the text the RedactFunc option displays in place of
+// the value v at the struct field path, held by the map entry with the string
+// key keyName unless it is empty, and whether v is redacted.
+func (c *ConfigState) customRedaction(path, keyName string, v reflect.Value) (string, bool) {
+	if c.RedactFunc == nil {
+		return "", false
+	}
+	if keyName != "" {
+		path = fieldPath(path, keyName)
+	}
+	return c.RedactFunc(path, v)
+}
@@ -0,0 +1,113 @@
+package spew_test
+
+import (
+	"fmt"
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type redactCard [4]int
+
+type redactRequest struct {
+	User     string
+	Password string
+	Headers  map[string]string
+	Card     redactCard
+}
+
+var _ = Describe("Redaction Tests", func() {
+	v := redactRequest{
+		User:     "ana",
+		Password: "hunter2",
+		Headers:  map[string]string{"Authorization": "Bearer abc", "Accept": "*/*"},
+		Card:     redactCard{4, 1, 1, 1},
+	}
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.SortKeys = true
+		cs.Redact = true
+		cs.RedactTypes = []reflect.Type{reflect.TypeOf(redactCard{})}
+		return cs
+	}
+
+	It("redacts matched fields, map entries and types in Dump output", func() {
+		Expect(newConfig().Sdump(v)).To(Equal("(spew_test.redactRequest) {\n" +
+			"  User: (string) (len: 3) \"ana\",\n" +
+			"  Password: (string) [REDACTED len=7],\n" +
+			"  Headers: (map[string]string) (len: 2) {\n" +
+			"    (string) (len: 6) \"Accept\": (string) (len: 3) \"*/*\",\n" +
+			"    (string) (len: 13) \"Authorization\": (string) [REDACTED len=10]\n" +
+			"  },\n" +
+			"  Card: (spew_test.redactCard) [REDACTED len=4]\n" +
+			"}\n"))
+	})
+
+	It("redacts map entries displayed as tables", func() {
+		cs := newConfig()
+		cs.MapTables = true
+		Expect(cs.Sdump(v.Headers)).To(ContainSubstring("| \"Authorization\" | [REDACTED len=10] |"))
+	})
+
+	It("redacts in Formatter output", func() {
+		Expect(fmt.Sprintf("%+v", newConfig().NewFormatter(v))).To(Equal(
+			"{User:ana Password:[REDACTED len=7] Headers:map[Accept:*/* " +
+				"Authorization:[REDACTED len=10]] Card:[REDACTED len=4]}"))
+	})
+
+	It("redacts in parsed trees", func() {
+		root := newConfig().Parse(v)
+		Expect(root.Children[1].Str).To(Equal("[REDACTED len=7]"))
+		Expect(root.Children[2].Children[1].Str).To(Equal("[REDACTED len=10]"))
+		Expect(root.Children[3].Str).To(Equal("[REDACTED len=4]"))
+	})
+
+	It("uses the configured field patterns", func() {
+		cs := newConfig()
+		cs.RedactFields = []string{"^user$"}
+		Expect(fmt.Sprintf("%v", cs.NewFormatter(v))).To(HavePrefix(
+			"{[REDACTED len=3] hunter2 "))
+	})
+
+	It("redacts nothing unless enabled", func() {
+		cs := newConfig()
+		cs.Redact = false
+		Expect(cs.Sdump(v)).To(ContainSubstring(`"hunter2"`))
+	})
+
+	It("masks values with the RedactFunc", func() {
+		var paths []string
+		cs := newConfig()
+		cs.Redact = false
+		cs.RedactFunc = func(path string, v reflect.Value) (string, bool) {
+			paths = append(paths, path)
+			if path == "User" || path == "Headers.Accept" {
+				return v.String()[:1] + "***", true
+			}
+			return "", false
+		}
+		Expect(fmt.Sprintf("%v", cs.NewFormatter(v))).To(Equal(
+			"{a*** hunter2 map[Accept:**** Authorization:Bearer abc] [4 1 1 1]}"))
+		Expect(paths).To(ContainElements("", "Password", "Headers", "Headers.Authorization", "Card"))
+
+		Expect(cs.Sdump(v.User)).To(Equal("(string) (len: 3) \"ana\"\n"))
+		Expect(cs.Sdump(v)).To(ContainSubstring("  User: (string) a***,\n"))
+		Expect(cs.Parse(v).Children[2].Children[0].Str).To(Equal("****"))
+
+		cs.MapTables = true
+		Expect(cs.Sdump(v)).To(ContainSubstring("| \"Accept\"        | ****         |"))
+	})
+
+	It("redacts only the names of keys by default", func() {
+		cs := newConfig()
+		m := map[string]string{
+			"Key": "a", "APIKey": "b", "api_key": "c", "PrivateKey": "d",
+			"Monkey": "e", "Keyboard": "f", "PrimaryKey": "g", "SortKeys": "h", "hotkeys": "i",
+		}
+		Expect(fmt.Sprintf("%v", cs.NewFormatter(m))).To(Equal("map[APIKey:[REDACTED len=1] " +
+			"Key:[REDACTED len=1] Keyboard:f Monkey:e PrimaryKey:g " +
+			"PrivateKey:[REDACTED len=1] SortKeys:h api_key:[REDACTED len=1] hotkeys:i]"))
+	})
+})
@@ -0,0 +1,43 @@
+package spew
+
+import "reflect"
+
+var (
+	rtypeType = reflect.TypeOf(reflect.TypeOf(0))
+
+	kindEqualsBytes = []byte("kind: ")
+	pkgEqualsBytes  = []byte("pkg: ")
+)
+
+// reflectType returns the type described by v when v is the implementation
+// of reflect.Type used by the reflect package, and false otherwise.
+func reflectType(v reflect.Value) (reflect.Type, bool) {
+	if v.Type() != rtypeType || v.IsNil() {
+		return nil, false
+	}
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return nil, false
+		}
+		v = unsafeReflectValue(v)
+	}
+	t, ok := v.Interface().(reflect.Type)
+	return t, ok
+}
+
+// dumpReflectType writes the type t following a header by its string form,
+// its kind, and the import path of its package when it is a named type,
+// rather than by the internals of the reflect package describing it.
+func (d *dumpState) dumpReflectType(t reflect.Type) {
+	printType(d.w, d.cs, t.String())
+	d.w.Write(spaceBytes)
+	withParens(d, func(d *dumpState) {
+		withColor(d.w, kindEqualsBytes, d.cs.Color.Length...)
+		withColor(d.w, []byte(t.Kind().String()), d.cs.Color.Keyword...)
+		if pkg := t.PkgPath(); pkg != "" {
+			d.w.Write(spaceBytes)
+			withColor(d.w, pkgEqualsBytes, d.cs.Color.Length...)
+			printString(d.w, d.cs, pkg)
+		}
+	})
+}
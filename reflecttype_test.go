@@ -0,0 +1,62 @@
+package spew_test
+
+import (
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type reflectTypeField struct {
+	Name string
+	Type reflect.Type
+	Elem reflect.Type
+	None reflect.Type
+	elem reflect.Type
+}
+
+var _ = Describe("Reflect Type Tests", func() {
+	It("displays reflect.Type values by the type they describe", func() {
+		cs := spew.NewTestConfig()
+		cs.ReflectTypes = true
+		f := reflectTypeField{
+			Name: "f",
+			Type: reflect.TypeOf(reflectTypeField{}),
+			Elem: reflect.TypeOf([]int(nil)),
+		}
+		Expect(cs.Sdump(f)).To(Equal("(spew_test.reflectTypeField) {\n" +
+			"  Name: (string) (len: 1) \"f\",\n" +
+			"  Type: (*reflect.rtype) spew_test.reflectTypeField (kind: struct pkg: github.com/ehowe/rainbow-spew_test),\n" +
+			"  Elem: (*reflect.rtype) []int (kind: slice),\n" +
+			"  None: (reflect.Type) <nil>,\n" +
+			"  elem: (reflect.Type) <nil>\n" +
+			"}\n"))
+	})
+
+	It("displays reflect.Type values with methods disabled", func() {
+		cs := spew.NewTestConfig()
+		cs.ReflectTypes = true
+		cs.DisableMethods = true
+		Expect(cs.Sdump(reflect.TypeOf(0))).To(Equal("(*reflect.rtype) int (kind: int)\n"))
+	})
+
+	It("displays reflect.Type values held by unexported fields", func() {
+		if spew.UnsafeDisabled {
+			Skip("reading reflect.Type values held by unexported fields requires the unsafe package")
+		}
+		cs := spew.NewTestConfig()
+		cs.ReflectTypes = true
+		Expect(cs.Sdump(reflectTypeField{elem: reflect.TypeOf("")})).To(HaveSuffix(
+			"  elem: (*reflect.rtype) string (kind: string)\n}\n"))
+	})
+
+	It("displays reflect.Type values as usual by default", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		cs.MaxDepth = 1
+		s := cs.Sdump(reflect.TypeOf(0))
+		Expect(s).To(HavePrefix("(*reflect.rtype)(0x"))
+		Expect(s).NotTo(ContainSubstring("(kind: int)"))
+	})
+})
@@ -0,0 +1,69 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+)
+
+var reflectValueType = reflect.TypeOf(reflect.Value{})
+
+// heldValue returns the value held by v when v is a reflect.Value, following
+// reflect.Values holding reflect.Values, and v otherwise.  The zero Value is
+// returned when the held value is.  v is returned unchanged when it can't be
+// converted to an interface without unsafe and unsafe is disabled.
+func heldValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Type() == reflectValueType {
+		if !v.CanInterface() {
+			if UnsafeDisabled {
+				return v
+			}
+			v = unsafeReflectValue(v)
+		}
+		v = v.Interface().(reflect.Value)
+	}
+	return v
+}
+
+// FdumpValue displays the value held by rv to w exactly the same as Dump
+// displays it, rather than the reflect.Value struct itself, which suits code
+// already working with reflection.  The zero Value is displayed as a nil
+// interface.
+func (c *ConfigState) FdumpValue(w io.Writer, rv reflect.Value) {
+	fdumpValues(c, w, nil, []reflect.Value{rv})
+}
+
+// DumpValue displays the value held by rv to standard out.  See FdumpValue
+// for details.
+func (c *ConfigState) DumpValue(rv reflect.Value) {
+	fdumpValues(c, os.Stdout, nil, []reflect.Value{rv})
+}
+
+// SdumpValue returns a string with the value held by rv displayed exactly the
+// same as DumpValue.
+func (c *ConfigState) SdumpValue(rv reflect.Value) string {
+	var buf bytes.Buffer
+	fdumpValues(c, &buf, nil, []reflect.Value{rv})
+	return buf.String()
+}
+
+// FdumpValue displays the value held by rv to w.  See
+// ConfigState.FdumpValue for details.
+func FdumpValue(w io.Writer, rv reflect.Value) {
+	fdumpValues(global(), w, nil, []reflect.Value{rv})
+}
+
+// DumpValue displays the value held by rv to standard out.  See
+// ConfigState.FdumpValue for details.
+func DumpValue(rv reflect.Value) {
+	fdumpValues(global(), os.Stdout, nil, []reflect.Value{rv})
+}
+
+// SdumpValue returns a string with the value held by rv displayed exactly the
+// same as DumpValue.
+func SdumpValue(rv reflect.Value) string {
+	var buf bytes.Buffer
+	fdumpValues(global(), &buf, nil, []reflect.Value{rv})
+	return buf.String()
+}
@@ -0,0 +1,50 @@
+package spew_test
+
+import (
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type reflectValueHolder struct {
+	Name  string
+	Value reflect.Value
+}
+
+var _ = Describe("Reflect Value Tests", func() {
+	It("dumps the value held by a reflect.Value", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.SdumpValue(reflect.ValueOf(42))).To(Equal("(int) 42\n"))
+		Expect(cs.SdumpValue(reflect.Value{})).To(Equal("(interface {}) <nil>\n"))
+	})
+
+	It("dumps values of unexported fields", func() {
+		cs := spew.NewTestConfig()
+		v := reflect.ValueOf(struct{ n int }{7}).Field(0)
+		Expect(cs.SdumpValue(v)).To(Equal("(int) 7\n"))
+	})
+
+	It("dumps nested reflect.Values as the values they hold", func() {
+		cs := spew.NewTestConfig()
+		cs.ReflectValues = true
+		h := reflectValueHolder{Name: "n", Value: reflect.ValueOf([]int{1})}
+		Expect(cs.Sdump(h)).To(Equal("(spew_test.reflectValueHolder) {\n" +
+			"  Name: (string) (len: 1) \"n\",\n" +
+			"  Value: ([]int) (len: 1 cap: 1) {\n" +
+			"    (int) 1\n" +
+			"  }\n" +
+			"}\n"))
+		Expect(cs.Sprintf("%v", h)).To(Equal("{n [1]}"))
+	})
+
+	It("dumps nested zero reflect.Values as invalid", func() {
+		cs := spew.NewTestConfig()
+		cs.ReflectValues = true
+		Expect(cs.Sdump(reflectValueHolder{})).To(Equal("(spew_test.reflectValueHolder) {\n" +
+			"  Name: (string) \"\",\n" +
+			"  Value: <invalid>\n" +
+			"}\n"))
+	})
+})
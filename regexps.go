@@ -0,0 +1,46 @@
+package spew
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+var (
+	regexpType = reflect.TypeOf(regexp.Regexp{})
+
+	longestBytes = []byte("longest")
+)
+
+// regexpPattern returns the source pattern of the regexp.Regexp v, or of the
+// one v points to, and whether it prefers the leftmost-longest match.  It
+// returns false when v is neither, or its layout is unknown.
+func regexpPattern(v reflect.Value) (expr string, longest, ok bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false, false
+		}
+		v = v.Elem()
+	}
+	if v.Type() != regexpType {
+		return "", false, false
+	}
+	e, l := v.FieldByName("expr"), v.FieldByName("longest")
+	if e.Kind() != reflect.String || l.Kind() != reflect.Bool {
+		return "", false, false
+	}
+	return e.String(), l.Bool(), true
+}
+
+// dumpRegexp writes the source pattern expr of a regexp following a header,
+// marked when it prefers the leftmost-longest match, rather than its compiled
+// program.
+func (d *dumpState) dumpRegexp(expr string, longest bool) {
+	printString(d.w, d.cs, strconv.Quote(expr))
+	if longest {
+		d.w.Write(spaceBytes)
+		withParens(d, func(d *dumpState) {
+			withColor(d.w, longestBytes, d.cs.Color.Keyword...)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package spew_test
+
+import (
+	"regexp"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type regexpsRoute struct {
+	Path  *regexp.Regexp
+	host  *regexp.Regexp
+	Match regexp.Regexp
+	none  *regexp.Regexp
+}
+
+var _ = Describe("Regexp Tests", func() {
+	It("displays regexps by their source pattern", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		cs.Regexps = true
+		r := regexpsRoute{
+			Path:  regexp.MustCompile(`^/users/(\d+)$`),
+			host:  regexp.MustCompilePOSIX(`[a-z]+\.example\.com`),
+			Match: *regexp.MustCompile(`x*`),
+		}
+		Expect(cs.Sdump(r)).To(Equal("(spew_test.regexpsRoute) {\n" +
+			"  Path: (*regexp.Regexp) \"^/users/(\\\\d+)$\",\n" +
+			"  host: (*regexp.Regexp) \"[a-z]+\\\\.example\\\\.com\" (longest),\n" +
+			"  Match: (regexp.Regexp) \"x*\",\n" +
+			"  none: (*regexp.Regexp)(<nil>)\n" +
+			"}\n"))
+	})
+
+	It("displays regexps as usual by default", func() {
+		cs := spew.NewTestConfig()
+		cs.DisablePointerAddresses = true
+		Expect(cs.Sdump(regexp.MustCompile("x*"))).To(Equal("(*regexp.Regexp)(x*)\n"))
+	})
+})
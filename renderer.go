@@ -0,0 +1,75 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+)
+
+// Renderer writes the tree of nodes parsed from a single argument passed to
+// Dump, Fdump or Sdump when set as the Renderer option.  This allows custom
+// output formats to be plugged in without reimplementing the traversal of
+// values.  See Parse for the structure of the tree.
+type Renderer interface {
+	// Render writes the tree rooted at root to w according to the
+	// configuration cs.  Implementations should end their output with a
+	// newline so the output of consecutive arguments is separated.
+	Render(w io.Writer, cs *ConfigState, root *Node)
+}
+
+// RendererFunc is an adapter to allow the use of an ordinary function as a
+// Renderer.
+type RendererFunc func(w io.Writer, cs *ConfigState, root *Node)
+
+// Render calls f(w, cs, root).
+func (f RendererFunc) Render(w io.Writer, cs *ConfigState, root *Node) {
+	f(w, cs, root)
+}
+
+var (
+	// TextRenderer writes the default text output of Dump.  It displays
+	// the value the tree was parsed from, so changes to the tree are not
+	// reflected in its output.
+	TextRenderer Renderer = RendererFunc(renderText)
+
+	// TreeRenderer writes the output of FdumpTree.
+	TreeRenderer Renderer = RendererFunc(renderTree)
+
+	// SexpRenderer writes the output of FdumpSexp.
+	SexpRenderer Renderer = RendererFunc(renderSexp)
+
+	// ProtoTextRenderer writes the output of FdumpProtoText.
+	ProtoTextRenderer Renderer = RendererFunc(renderProtoText)
+)
+
+// renderText implements TextRenderer.
+func renderText(w io.Writer, cs *ConfigState, root *Node) {
+	ids := root.ids
+	if ids == nil && (cs.PointerIDs || cs.SharedRefs) {
+		ids = &pointerIDs{}
+	}
+	dumpValue(cs, w, ids, root.stats, root.rv, wrapWidth(cs, w))
+}
+
+// renderTree implements TreeRenderer.
+func renderTree(w io.Writer, cs *ConfigState, root *Node) {
+	t := &treeState{w: w, cs: cs}
+	if cs.PointerIDs {
+		t.ids = &pointerIDs{}
+	}
+	t.draw(importNode(root, nil))
+}
+
+// renderSexp implements SexpRenderer.
+func renderSexp(w io.Writer, cs *ConfigState, root *Node) {
+	var buf bytes.Buffer
+	writeSexp(&buf, cs, importNode(root, nil))
+	buf.WriteByte('\n')
+	w.Write(buf.Bytes())
+}
+
+// renderProtoText implements ProtoTextRenderer.
+func renderProtoText(w io.Writer, cs *ConfigState, root *Node) {
+	p := &protoState{}
+	p.root(importNode(root, nil))
+	w.Write(p.buf.Bytes())
+}
@@ -0,0 +1,75 @@
+package spew_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type rendererTester struct {
+	A int
+	B []string
+}
+
+var _ = Describe("Renderer Tests", func() {
+	v := rendererTester{A: 1, B: []string{"x"}}
+
+	It("renders each argument with the configured renderer", func() {
+		cfg := spew.NewTestConfig()
+		cfg.Renderer = spew.RendererFunc(func(w io.Writer, cs *spew.ConfigState, root *spew.Node) {
+			if root.Type == nil {
+				fmt.Fprintln(w, "nil")
+				return
+			}
+			fmt.Fprintf(w, "%s with %d children\n", root.Type, len(root.Children))
+		})
+		Expect(cfg.Sdump(v, nil)).To(Equal("spew_test.rendererTester with 2 children\nnil\n"))
+	})
+
+	It("provides the built-in renderers", func() {
+		cfg := spew.NewTestConfig()
+		want := cfg.Sdump(v, nil)
+		tree := cfg.SdumpTree(v, nil)
+		sexp := cfg.SdumpSexp(v, nil)
+
+		cfg.Renderer = spew.TextRenderer
+		Expect(cfg.Sdump(v, nil)).To(Equal(want))
+		cfg.Renderer = spew.TreeRenderer
+		Expect(cfg.Sdump(v, nil)).To(Equal(tree))
+		cfg.Renderer = spew.SexpRenderer
+		Expect(cfg.Sdump(v, nil)).To(Equal(sexp))
+	})
+
+	It("renders trees modified by callers", func() {
+		cfg := spew.NewTestConfig()
+		root := cfg.Parse(v)
+		root.Children = root.Children[:1]
+		var sexp, tree, proto bytes.Buffer
+		spew.SexpRenderer.Render(&sexp, cfg, root)
+		spew.TreeRenderer.Render(&tree, cfg, root)
+		spew.ProtoTextRenderer.Render(&proto, cfg, root)
+		Expect(sexp.String()).To(Equal(`(struct "spew_test.rendererTester" (A (int "int" 1)))` + "\n"))
+		Expect(tree.String()).To(Equal("(spew_test.rendererTester)\n└── A: (int) 1\n"))
+		Expect(proto.String()).To(Equal("a: 1\n"))
+	})
+
+	It("numbers pointers with the text renderer as Dump does", func() {
+		shared := &rendererTester{A: 2}
+		pair := []*rendererTester{shared, shared}
+		for _, set := range []func(cfg *spew.ConfigState){
+			func(cfg *spew.ConfigState) { cfg.PointerIDs = true },
+			func(cfg *spew.ConfigState) { cfg.SharedRefs = true },
+			func(cfg *spew.ConfigState) { cfg.ShowSummary = true },
+		} {
+			cfg := spew.NewTestConfig()
+			set(cfg)
+			want := cfg.Sdump(pair, shared)
+			cfg.Renderer = spew.TextRenderer
+			Expect(cfg.Sdump(pair, shared)).To(Equal(want))
+		}
+	})
+})
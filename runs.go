@@ -0,0 +1,44 @@
+package spew
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// runLength returns the number of consecutive elements of the array or slice
+// v from index i up to index n which are identical to element i when the
+// CollapseRuns option is set, and 1 otherwise.  Elements which can't be
+// compared, such as slices, never form runs.
+func (c *ConfigState) runLength(v reflect.Value, i, n int) int {
+	if !c.CollapseRuns {
+		return 1
+	}
+	first := v.Index(i)
+	if !first.Comparable() {
+		return 1
+	}
+	run := 1
+	for ; i+run < n; run++ {
+		next := v.Index(i + run)
+		if !next.Comparable() || !next.Equal(first) {
+			break
+		}
+	}
+	return run
+}
+
+// writeRunCount writes the count following an element displayed in place of
+// a run of run identical elements, such as ×1024.  The multiplication sign
+// is written as an x when the Symbols option is SymbolsASCII.  Nothing is
+// written for runs of a single element.
+func writeRunCount(w io.Writer, cs *ConfigState, run int) {
+	if run < 2 {
+		return
+	}
+	times := " ×"
+	if cs.Symbols == SymbolsASCII {
+		times = " x"
+	}
+	withColor(w, []byte(times+strconv.Itoa(run)), cs.Color.Length...)
+}
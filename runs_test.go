@@ -0,0 +1,46 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Collapse Runs Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.CollapseRuns = true
+		return cs
+	}
+
+	It("collapses runs of identical elements in Dump output", func() {
+		v := []string{"x", "x", "x", "y", "x"}
+		Expect(newConfig().Sdump(v)).To(Equal("([]string) (len: 5 cap: 5) {\n" +
+			"  (string) (len: 1) \"x\" ×3,\n" +
+			"  (string) (len: 1) \"y\",\n" +
+			"  (string) (len: 1) \"x\"\n" +
+			"}\n"))
+	})
+
+	It("collapses runs of zero-initialized arrays", func() {
+		var v [1024]int32
+		cs := newConfig()
+		cs.Symbols = spew.SymbolsASCII
+		Expect(cs.Sdump(v)).To(Equal("([1024]int32) (len: 1024 cap: 1024) {\n" +
+			"  (int32) 0 x1024\n" +
+			"}\n"))
+	})
+
+	It("does not collapse elements which can't be compared", func() {
+		v := []interface{}{[]int{1}, []int{1}, 2, 2}
+		Expect(fmt.Sprintf("%v", newConfig().NewFormatter(v))).To(Equal("[[1] [1] 2 ×2]"))
+	})
+
+	It("collapses runs within the MaxElements limit", func() {
+		cs := newConfig()
+		cs.MaxElements = 3
+		Expect(fmt.Sprintf("%v", cs.NewFormatter([]int{0, 0, 0, 0, 0}))).To(Equal("[0 ×3 ... (+2 more)]"))
+	})
+})
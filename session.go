@@ -0,0 +1,92 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// pointerIDs assigns short sequential identifiers to pointer addresses in the
+// order they are first seen.
+type pointerIDs struct {
+	ids  map[uintptr]int
+	next int
+}
+
+// id returns the identifier for addr, assigning the next one if addr has not
+// been seen before.
+func (p *pointerIDs) id(addr uintptr) int {
+	if p.ids == nil {
+		p.ids = make(map[uintptr]int)
+	}
+	id, ok := p.ids[addr]
+	if !ok {
+		p.next++
+		id = p.next
+		p.ids[addr] = id
+	}
+	return id
+}
+
+// write outputs the identifier for addr in the form #N to Writer w.  Null
+// pointers are displayed as <nil> just like printHexPtr does, and the address
+// itself is displayed when p is nil.
+func (p *pointerIDs) write(w io.Writer, addr uintptr) {
+	if p == nil {
+		printHexPtr(w, addr)
+		return
+	}
+	if addr == 0 {
+		w.Write(nilAngleBytes)
+		return
+	}
+	w.Write([]byte("#" + strconv.Itoa(p.id(addr))))
+}
+
+// Session dumps values using a ConfigState while sharing the pointer
+// identifiers across all of the dumps it performs.  Pointers are labeled with
+// a short identifier such as #3 instead of their address, and an object which
+// appears in several dumps made through the same session is labeled with the
+// same identifier in each of them.  This makes it possible to follow an object
+// through a debugging session without comparing raw addresses.
+//
+// A Session is safe for concurrent use.
+type Session struct {
+	cs  *ConfigState
+	mtx sync.Mutex
+	ids pointerIDs
+}
+
+// NewSession returns a new Session which dumps values according to cs.  The
+// configuration used by the top-level functions at the time is used when cs
+// is nil.
+func NewSession(cs *ConfigState) *Session {
+	if cs == nil {
+		cs = global()
+	}
+	return &Session{cs: cs}
+}
+
+// Fdump formats and displays the passed arguments to io.Writer w.  It formats
+// exactly the same as Dump.
+func (s *Session) Fdump(w io.Writer, a ...interface{}) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	fdumpIDs(s.cs, w, &s.ids, a...)
+}
+
+// Dump displays the passed parameters to standard out like ConfigState.Dump
+// with pointers labeled by their session identifiers.
+func (s *Session) Dump(a ...interface{}) {
+	s.Fdump(os.Stdout, a...)
+}
+
+// Sdump returns a string with the passed arguments formatted exactly the same
+// as Dump.
+func (s *Session) Sdump(a ...interface{}) string {
+	var buf bytes.Buffer
+	s.Fdump(&buf, a...)
+	return buf.String()
+}
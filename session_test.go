@@ -0,0 +1,25 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Session Tests", func() {
+	It("labels the same pointer with the same ID across dumps", func() {
+		s := spew.NewSession(spew.NewTestConfig())
+		shared := &ptrTester{s: &struct{}{}}
+		other := 5
+
+		Expect(s.Sdump(shared)).To(Equal("(*spew_test.ptrTester)(#1)({\n  s: (*struct {})(#2)({\n  })\n})\n"))
+		Expect(s.Sdump(&other)).To(Equal("(*int)(#3)(5)\n"))
+		Expect(s.Sdump(shared.s)).To(Equal("(*struct {})(#2)({\n})\n"))
+	})
+
+	It("does not share IDs between sessions", func() {
+		v := 1
+		Expect(spew.NewSession(nil).Sdump(&v)).To(Equal("(*int)(#1)(1)\n"))
+		Expect(spew.NewSession(nil).Sdump(&v)).To(Equal("(*int)(#1)(1)\n"))
+	})
+})
@@ -0,0 +1,166 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// sexpEscaper escapes the characters which are special within the strings of
+// an s-expression.
+var sexpEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// sexpString returns s as a quoted s-expression string.
+func sexpString(s string) string {
+	return `"` + sexpEscaper.Replace(s) + `"`
+}
+
+// sexpFloat returns the s-expression atom for the float f.
+func sexpFloat(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "+inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// sexpAddr returns the s-expression atom for the address addr.
+func sexpAddr(addr uintptr) string {
+	return "#x" + strconv.FormatUint(uint64(addr), 16)
+}
+
+// writeSexp writes the s-expression for n to buf.
+func writeSexp(buf *bytes.Buffer, cs *ConfigState, n *node) {
+	switch {
+	case n.kind == reflect.Interface && n.isNil:
+		buf.WriteString("nil")
+		return
+	case n.kind == reflect.Invalid:
+		buf.WriteString("invalid")
+		return
+	}
+
+	buf.WriteString("(" + n.kind.String() + " " + sexpString(n.typ.String()))
+	switch n.kind {
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if !n.isNil && !cs.DisablePointerAddresses {
+			buf.WriteString(" " + sexpAddr(n.addr))
+		}
+	}
+	switch {
+	case n.str != "":
+		buf.WriteString(" :string " + sexpString(n.str))
+	case n.isNil:
+		buf.WriteString(" :nil")
+	case n.cycle:
+		buf.WriteString(" :circular")
+	case n.truncated:
+		buf.WriteString(" :max")
+	}
+
+	switch v := n.value.(type) {
+	case bool:
+		buf.WriteString(" " + strconv.FormatBool(v))
+	case int64:
+		buf.WriteString(" " + strconv.FormatInt(v, 10))
+	case uint64:
+		buf.WriteString(" " + strconv.FormatUint(v, 10))
+	case float64:
+		buf.WriteString(" " + sexpFloat(v, n.typ.Bits()))
+	case complex128:
+		bits := n.typ.Bits() / 2
+		buf.WriteString(" (complex " + sexpFloat(real(v), bits) + " " +
+			sexpFloat(imag(v), bits) + ")")
+	case string:
+		buf.WriteString(" " + sexpString(v))
+	case uintptr:
+		buf.WriteString(" " + sexpAddr(v))
+	}
+
+	for _, c := range n.children {
+		buf.WriteByte(' ')
+		switch n.kind {
+		case reflect.Struct:
+			buf.WriteString("(" + c.name + " ")
+			writeSexp(buf, cs, c)
+			buf.WriteByte(')')
+		case reflect.Map:
+			buf.WriteString("(entry ")
+			writeSexp(buf, cs, c.key)
+			buf.WriteByte(' ')
+			writeSexp(buf, cs, c)
+			buf.WriteByte(')')
+		default:
+			writeSexp(buf, cs, c)
+		}
+	}
+	buf.WriteByte(')')
+}
+
+// fdumpSexp writes the s-expression for each of the passed arguments to w.
+func fdumpSexp(cs *ConfigState, w io.Writer, a ...interface{}) {
+	var buf bytes.Buffer
+	for _, arg := range a {
+		if arg == nil {
+			buf.WriteString("nil\n")
+			continue
+		}
+		writeSexp(&buf, cs, buildTree(cs, reflect.ValueOf(arg)))
+		buf.WriteByte('\n')
+	}
+	w.Write(buf.Bytes())
+	flushSink(w)
+}
+
+/*
+FdumpSexp writes the passed arguments to w as s-expressions, one per line,
+which tools and Lisp-based validators can parse far more easily than the
+free-form Dump output.  Each value is written as a list headed by its kind
+and quoted type, followed by its value or its elements.  Struct fields are
+written as lists headed by the field name, and map entries as lists headed by
+entry holding the key and the value.  For example:
+
+	(ptr "*main.T" #xc000012345 (struct "main.T" (Name (string "string" "a")) (Tags (map "map[string]int" (entry (string "string" "x") (int "int" 1))))))
+
+Pointers, channels and functions include their address unless the
+DisablePointerAddresses option is set.  Values are marked with :nil when they
+are nil, :circular for circular references, :max when MaxDepth was reached,
+and :string followed by the result of their Stringer or error interface.  Nil
+interfaces are written as nil.
+*/
+func (c *ConfigState) FdumpSexp(w io.Writer, a ...interface{}) {
+	fdumpSexp(c, w, a...)
+}
+
+// SdumpSexp returns a string with the passed arguments written exactly the
+// same as FdumpSexp.
+func (c *ConfigState) SdumpSexp(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpSexp(c, &buf, a...)
+	return buf.String()
+}
+
+// FdumpSexp writes the passed arguments to w as s-expressions.  See
+// ConfigState.FdumpSexp for details.
+func FdumpSexp(w io.Writer, a ...interface{}) {
+	fdumpSexp(global(), w, a...)
+}
+
+// SdumpSexp returns a string with the passed arguments written exactly the
+// same as FdumpSexp.
+func SdumpSexp(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpSexp(global(), &buf, a...)
+	return buf.String()
+}
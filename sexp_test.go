@@ -0,0 +1,60 @@
+package spew_test
+
+import (
+	"errors"
+	"math"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type sexpTester struct {
+	Name string
+	Tags map[string]int
+	Next *sexpTester
+	Err  error
+}
+
+var _ = Describe("Sexp Tests", func() {
+	It("writes values as s-expressions", func() {
+		cs := spew.NewTestConfig()
+		cs.SortKeys = true
+		cs.DisablePointerAddresses = true
+		v := &sexpTester{Name: `say "hi"`, Tags: map[string]int{"b": 2, "a": 1}, Err: errors.New("boom")}
+		v.Next = v
+		// Without unsafe the Error method is invoked on the pointer itself
+		// rather than on the struct it points to.
+		err := `(Err (ptr "*errors.errorString" (struct "errors.errorString" :string "boom")))`
+		if spew.UnsafeDisabled {
+			err = `(Err (ptr "*errors.errorString" :string "boom"))`
+		}
+		Expect(cs.SdumpSexp(v)).To(Equal(`(ptr "*spew_test.sexpTester" ` +
+			`(struct "spew_test.sexpTester" ` +
+			`(Name (string "string" "say \"hi\"")) ` +
+			`(Tags (map "map[string]int" (entry (string "string" "a") (int "int" 1)) (entry (string "string" "b") (int "int" 2)))) ` +
+			`(Next (ptr "*spew_test.sexpTester" :circular)) ` +
+			err + `))` + "\n"))
+	})
+
+	It("writes scalars", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.SdumpSexp(nil, true, int8(-3), uint(4), 2.0, float32(math.Inf(-1)), math.NaN(), 1+2i, []error{nil})).To(Equal(
+			"nil\n" +
+				`(bool "bool" true)` + "\n" +
+				`(int8 "int8" -3)` + "\n" +
+				`(uint "uint" 4)` + "\n" +
+				`(float64 "float64" 2.0)` + "\n" +
+				`(float32 "float32" -inf)` + "\n" +
+				`(float64 "float64" nan)` + "\n" +
+				`(complex128 "complex128" (complex 1.0 2.0))` + "\n" +
+				`(slice "[]error" nil)` + "\n"))
+	})
+
+	It("marks nil and truncated values", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		Expect(cs.SdumpSexp([][]int{{1}, nil})).To(Equal(
+			`(slice "[][]int" (slice "[]int" :max) (slice "[]int" :nil))` + "\n"))
+	})
+})
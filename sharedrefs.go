@@ -0,0 +1,14 @@
+package spew
+
+var (
+	seeRefBytes   = []byte("-> see ")
+	aboveRefBytes = []byte(" above")
+)
+
+// sharedRef writes the reference displayed in place of the value behind a
+// pointer to addr which has already been displayed.
+func (d *dumpState) sharedRef(addr uintptr) {
+	d.w.Write(seeRefBytes)
+	d.ids.write(d.w, addr)
+	d.w.Write(aboveRefBytes)
+}
@@ -0,0 +1,41 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type sharedRefsConfig struct {
+	Name string
+}
+
+type sharedRefsService struct {
+	A, B *sharedRefsConfig
+	Self *sharedRefsService
+}
+
+var _ = Describe("Shared Refs Tests", func() {
+	It("references pointers which were already displayed", func() {
+		c := &sharedRefsConfig{Name: "x"}
+		v := &sharedRefsService{A: c, B: c}
+		v.Self = v
+		cs := spew.NewTestConfig()
+		cs.SharedRefs = true
+		cs.DisablePointerAddresses = true
+		Expect(cs.Sdump(v, []*sharedRefsConfig{c, c})).To(Equal(
+			"(*spew_test.sharedRefsService)(#1)({\n" +
+				"  A: (*spew_test.sharedRefsConfig)(#2)({\n" +
+				"    Name: (string) (len: 1) \"x\"\n" +
+				"  }),\n" +
+				"  B: (*spew_test.sharedRefsConfig)(#2)(-> see #2 above),\n" +
+				"  Self: (*spew_test.sharedRefsService)(#1)(<already shown>)\n" +
+				"})\n" +
+				"([]*spew_test.sharedRefsConfig) (len: 2 cap: 2) {\n" +
+				"  (*spew_test.sharedRefsConfig)(#2)({\n" +
+				"    Name: (string) (len: 1) \"x\"\n" +
+				"  }),\n" +
+				"  (*spew_test.sharedRefsConfig)(#2)(-> see #2 above)\n" +
+				"}\n"))
+	})
+})
@@ -0,0 +1,34 @@
+package spew
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// Sink is an io.Writer which transforms dump output before writing it to an
+// underlying writer, such as the compressing writer returned by GzipSink.
+// Dump functions writing to a Sink flush it once they are done so each dump
+// can be read back as soon as it has been written, even while the Sink stays
+// open for further dumps.  Close must be called once all dumps have been
+// written.
+type Sink interface {
+	io.WriteCloser
+
+	// Flush writes any buffered output to the underlying writer.
+	Flush() error
+}
+
+// GzipSink returns a Sink which compresses the output written to it with
+// gzip before writing it to w.  Dumps of large data structures, particularly
+// with pointer addresses disabled, compress extremely well, which makes this
+// useful for archiving them.
+func GzipSink(w io.Writer) Sink {
+	return gzip.NewWriter(w)
+}
+
+// flushSink flushes w when it is a Sink in order to mark the end of a dump.
+func flushSink(w io.Writer) {
+	if s, ok := w.(Sink); ok {
+		s.Flush()
+	}
+}
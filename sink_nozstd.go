@@ -0,0 +1,6 @@
+//go:build !zstd
+
+package spew
+
+// zstdAvailable specifies whether ZstdSink is compiled in.
+const zstdAvailable = false
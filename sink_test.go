@@ -0,0 +1,35 @@
+package spew_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sink Tests", func() {
+	It("compresses dumps with gzip", func() {
+		var buf bytes.Buffer
+		sink := spew.GzipSink(&buf)
+		spew.Fdump(sink, 1)
+
+		// The dump is flushed so it can be read before the sink is closed.
+		r, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		out := make([]byte, len("(int) 1\n"))
+		_, err = io.ReadFull(r, out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal("(int) 1\n"))
+
+		spew.Fdump(sink, "a")
+		Expect(sink.Close()).To(Succeed())
+		r, err = gzip.NewReader(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		all, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(all)).To(Equal("(int) 1\n(string) (len: 1) \"a\"\n"))
+	})
+})
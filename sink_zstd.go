@@ -0,0 +1,24 @@
+//go:build zstd
+
+package spew
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zstdAvailable specifies whether ZstdSink is compiled in.
+const zstdAvailable = true
+
+// ZstdSink returns a Sink which compresses the output written to it with
+// zstd before writing it to w.  It is only available when building with the
+// zstd build tag.
+func ZstdSink(w io.Writer) Sink {
+	enc, err := zstd.NewWriter(w)
+	if err != nil {
+		// NewWriter only fails for invalid options, and none are passed.
+		panic(err)
+	}
+	return enc
+}
@@ -0,0 +1,33 @@
+//go:build zstd
+
+package spew_test
+
+import (
+	"bytes"
+	"io"
+
+	spew "github.com/ehowe/rainbow-spew"
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Zstd Sink Tests", func() {
+	It("compresses dumps with zstd", func() {
+		var buf bytes.Buffer
+		sink := spew.ZstdSink(&buf)
+		spew.Fdump(sink, 1)
+		Expect(sink.Close()).To(Succeed())
+
+		r, err := zstd.NewReader(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Close()
+		all, err := io.ReadAll(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(all)).To(Equal("(int) 1\n"))
+	})
+
+	It("is reported as available", func() {
+		Expect(spew.Capabilities().Zstd).To(BeTrue())
+	})
+})
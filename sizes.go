@@ -0,0 +1,287 @@
+package spew
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// Estimated sizes of runtime structures which are not visible through
+// reflection.
+const (
+	// mapHeaderSize is the size of the runtime header of a map.
+	mapHeaderSize = 48
+
+	// mapEntryOverhead is the per entry bookkeeping overhead of a map.
+	mapEntryOverhead = 1
+
+	// chanHeaderSize is the size of the runtime header of a channel.
+	chanHeaderSize = 96
+)
+
+// sizer estimates the memory used by values.  Objects which live outside of
+// the value itself, such as the targets of pointers and the backing arrays of
+// slices, are attributed to the first value that reaches them.  The objects
+// are numbered in the order they are first reached, and the numbers of those
+// attributed to an earlier value are recorded in claimed.  The sizes of the
+// values located in memory are recorded in known by the first measurement,
+// along with the numbers of the objects attributed to them, so the values
+// displayed below a measured value are not measured again.  The entries of
+// maps are measured in the order they are displayed in with cs, if any.
+type sizer struct {
+	cs      *ConfigState
+	order   map[uintptr]int
+	known   map[visitKey]knownSize
+	claimed spanSet
+}
+
+// knownSize is the size of the objects reached from a value, which are those
+// numbered within objects.
+type knownSize struct {
+	size    uintptr
+	objects span
+}
+
+// span is the range of object numbers from start up to but excluding end.
+type span struct {
+	start, end int
+}
+
+// spanSet is a set of object numbers held as sorted, disjoint spans.
+type spanSet []span
+
+// add adds the numbers of sp to the set.
+func (s *spanSet) add(sp span) {
+	if sp.start >= sp.end {
+		return
+	}
+	spans := *s
+	i := sort.Search(len(spans), func(i int) bool { return spans[i].end >= sp.start })
+	j := i
+	for ; j < len(spans) && spans[j].start <= sp.end; j++ {
+		sp.start = min(sp.start, spans[j].start)
+		sp.end = max(sp.end, spans[j].end)
+	}
+	*s = slices.Replace(spans, i, j, sp)
+}
+
+// overlaps returns whether the set holds any of the numbers of sp.
+func (s spanSet) overlaps(sp span) bool {
+	i := sort.Search(len(s), func(i int) bool { return s[i].end > sp.start })
+	return i < len(s) && s[i].start < sp.end
+}
+
+// newSizer returns a sizer for values displayed with cs which has not
+// attributed any objects yet.
+func newSizer(cs *ConfigState) *sizer {
+	return &sizer{cs: cs, order: make(map[uintptr]int), known: make(map[visitKey]knownSize)}
+}
+
+// measure returns the estimated shallow and retained sizes of v in bytes.  The
+// shallow size is the memory occupied by v itself while the retained size
+// additionally includes all of the objects reachable from v which have not
+// been attributed to an earlier value.  The objects newly reached from v are
+// returned so they can be attributed to v with commit once v is done being
+// displayed.
+func (s *sizer) measure(v reflect.Value) (shallow, retained uintptr, reached *spanSet) {
+	reached = &spanSet{}
+	shallow = v.Type().Size()
+	// Only the first measurement records the sizes of values, since the
+	// objects are numbered in the order it reaches them.
+	return shallow, shallow + s.heap(v, reached, len(s.order) == 0), reached
+}
+
+// commit attributes the passed objects to the value they were reached from.
+func (s *sizer) commit(reached *spanSet) {
+	for _, sp := range *reached {
+		s.claimed.add(sp)
+	}
+}
+
+// claim returns whether the object at addr has not been attributed yet, and
+// marks it as reached if so.
+func (s *sizer) claim(addr uintptr, reached *spanSet) bool {
+	n, ok := s.order[addr]
+	if !ok {
+		n = len(s.order)
+		s.order[addr] = n
+	}
+	sp := span{n, n + 1}
+	if s.claimed.overlaps(sp) || reached.overlaps(sp) {
+		return false
+	}
+	reached.add(sp)
+	return true
+}
+
+// heap returns the estimated size of the unattributed objects which live
+// outside of v but are reachable from it.  The size recorded for v is used
+// when none of its objects have been reached since, and the size of v is
+// recorded when record is set.
+func (s *sizer) heap(v reflect.Value, reached *spanSet, record bool) uintptr {
+	if !v.CanAddr() || isScalarKind(v.Kind()) {
+		return s.reach(v, reached, record)
+	}
+	key := visitKey{typ: v.Type(), addr: v.UnsafeAddr()}
+	if known, ok := s.known[key]; ok {
+		if !s.claimed.overlaps(known.objects) && !reached.overlaps(known.objects) {
+			reached.add(known.objects)
+			return known.size
+		}
+		record = false
+	}
+	start := len(s.order)
+	size := s.reach(v, reached, record)
+	if record {
+		s.known[key] = knownSize{size: size, objects: span{start, len(s.order)}}
+	}
+	return size
+}
+
+// reach returns the estimated size of the unattributed objects which live
+// outside of v but are reachable from it, measuring the values v holds with
+// heap.
+func (s *sizer) reach(v reflect.Value, reached *spanSet, record bool) uintptr {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || !s.claim(v.Pointer(), reached) {
+			return 0
+		}
+		return v.Type().Elem().Size() + s.heap(v.Elem(), reached, record)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		e := v.Elem()
+		switch e.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			// Pointer shaped values are stored directly in the interface.
+			return s.heap(e, reached, record)
+		}
+		return e.Type().Size() + s.heap(e, reached, record)
+
+	case reflect.String:
+		return uintptr(v.Len())
+
+	case reflect.Slice:
+		if v.IsNil() || v.Cap() == 0 || !s.claim(v.Pointer(), reached) {
+			return 0
+		}
+		size := uintptr(v.Cap()) * v.Type().Elem().Size()
+		if !isScalarKind(v.Type().Elem().Kind()) {
+			for i := 0; i < v.Len(); i++ {
+				size += s.heap(v.Index(i), reached, record)
+			}
+		}
+		return size
+
+	case reflect.Array:
+		var size uintptr
+		if !isScalarKind(v.Type().Elem().Kind()) {
+			for i := 0; i < v.Len(); i++ {
+				size += s.heap(v.Index(i), reached, record)
+			}
+		}
+		return size
+
+	case reflect.Struct:
+		var size uintptr
+		for i := 0; i < v.NumField(); i++ {
+			size += s.heap(v.Field(i), reached, record)
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() || !s.claim(v.Pointer(), reached) {
+			return 0
+		}
+		vt := v.Type()
+		entrySize := vt.Key().Size() + vt.Elem().Size() + mapEntryOverhead
+		size := uintptr(mapHeaderSize) + uintptr(v.Len())*entrySize
+		// The entries are measured in the order they are displayed in, which
+		// the sizes recorded for them depend on.
+		keys := v.MapKeys()
+		if s.cs != nil && s.cs.SortKeys {
+			sortValues(keys, s.cs)
+		}
+		for _, key := range keys {
+			size += s.heap(key, reached, record) + s.heap(v.MapIndex(key), reached, record)
+		}
+		return size
+
+	case reflect.Chan:
+		if v.IsNil() || !s.claim(v.Pointer(), reached) {
+			return 0
+		}
+		return chanHeaderSize + uintptr(v.Cap())*v.Type().Elem().Size()
+	}
+	return 0
+}
+
+// isScalarKind returns whether values of kind can never refer to memory
+// outside of themselves.
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32,
+		reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// isSizedKind returns whether ShowSizes annotates values of kind.
+func isSizedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Struct, reflect.Ptr:
+		return true
+	}
+	return false
+}
+
+// SizeOf returns the estimated shallow and retained sizes of v in bytes.  The
+// shallow size is the memory occupied by v itself, while the retained size
+// also includes everything reachable from v such as the targets of pointers,
+// the backing arrays of slices, and the contents of maps and strings.  Objects
+// reachable through several paths are only counted once.
+//
+// The sizes are estimates since the layout of runtime structures such as maps
+// is not visible through reflection.  See the ShowSizes option for annotating
+// each value in Dump output with its sizes.
+func SizeOf(v interface{}) (shallow, retained uintptr) {
+	if v == nil {
+		return 0, 0
+	}
+	shallow, retained, _ = newSizer(nil).measure(reflect.ValueOf(v))
+	return shallow, retained
+}
+
+// DeepSize returns the estimated transitive size of v in bytes, which is the
+// retained size given by SizeOf.  It is 0 when v is nil.  The transitive size
+// of each composite value held by v is written next to it in the output of
+// Dump with the ShowSizes option, which attributes the objects shared by
+// several values to the first of them.
+func DeepSize(v interface{}) uintptr {
+	_, retained := SizeOf(v)
+	return retained
+}
+
+// printSizes outputs the shallow and retained sizes of v when the ShowSizes
+// option is set.  The function returned must be called once v has been
+// completely displayed in order to attribute the objects reached from v to it.
+func (d *dumpState) printSizes(v reflect.Value) (commit func()) {
+	if d.sizes == nil || !isSizedKind(v.Kind()) {
+		return func() {}
+	}
+	shallow, retained, reached := d.sizes.measure(v)
+	withParens(d, func(d *dumpState) {
+		withColor(d.w, sizeEqualsBytes, d.cs.Color.Length...)
+		printNumber(d.w, d.cs, uint64(shallow))
+		d.w.Write(spaceBytes)
+		withColor(d.w, retainedEqualsBytes, d.cs.Color.Length...)
+		printNumber(d.w, d.cs, uint64(retained))
+	})
+	return func() { d.sizes.commit(reached) }
+}
@@ -0,0 +1,108 @@
+package spew_test
+
+import (
+	"strings"
+	"unsafe"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type sizeTester struct {
+	A []int64
+	B []int64
+}
+
+type sizeShared struct {
+	P *int64
+	Q *int64
+}
+
+type sizeRing struct {
+	V    []int64
+	Next *sizeRing
+}
+
+var _ = Describe("Size Tests", func() {
+	It("returns the shallow and retained sizes of values", func() {
+		shallow, retained := spew.SizeOf(int64(1))
+		Expect(shallow).To(Equal(uintptr(8)))
+		Expect(retained).To(Equal(uintptr(8)))
+
+		s := make([]int64, 2, 4)
+		shallow, retained = spew.SizeOf(s)
+		Expect(shallow).To(Equal(unsafe.Sizeof(s)))
+		Expect(retained).To(Equal(unsafe.Sizeof(s) + 32))
+
+		str := "hello"
+		shallow, retained = spew.SizeOf(&str)
+		Expect(shallow).To(Equal(unsafe.Sizeof(&str)))
+		Expect(retained).To(Equal(unsafe.Sizeof(&str) + unsafe.Sizeof(str) + 5))
+
+		shallow, retained = spew.SizeOf(nil)
+		Expect(shallow).To(BeZero())
+		Expect(retained).To(BeZero())
+	})
+
+	It("counts shared objects once", func() {
+		s := make([]int64, 4)
+		v := sizeTester{A: s, B: s}
+		shallow, retained := spew.SizeOf(v)
+		Expect(shallow).To(Equal(unsafe.Sizeof(v)))
+		Expect(retained).To(Equal(unsafe.Sizeof(v) + 32))
+	})
+
+	It("attributes shared objects to the first field in Dump", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowSizes = true
+		s := make([]int64, 4)
+		v := sizeTester{A: s, B: s}
+		out := cs.Sdump(v)
+		lines := strings.Split(out, "\n")
+		Expect(lines[0]).To(Equal("(spew_test.sizeTester) (size: 48 retained: 80) {"))
+		Expect(lines[1]).To(Equal("  A: ([]int64) (len: 4 cap: 4) (size: 24 retained: 56) {"))
+		Expect(out).To(ContainSubstring("  B: ([]int64) (len: 4 cap: 4) (size: 24 retained: 24) {"))
+	})
+
+	It("annotates pointers with the sizes of what they retain", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowSizes = true
+		cs.DisablePointerAddresses = true
+		str := "hello"
+		Expect(cs.Sdump(&str)).To(Equal("(*string)(size: 8 retained: 29)((len: 5) \"hello\")\n"))
+	})
+
+	It("returns the transitive size of values", func() {
+		// The pointers, shared by both fields, and their target.
+		n := int64(7)
+		Expect(spew.DeepSize(sizeShared{P: &n, Q: &n})).To(Equal(uintptr(2*8 + 8)))
+
+		// The map, its header, an entry with its overhead, and the key.
+		Expect(spew.DeepSize(map[string]int64{"ab": 1})).To(Equal(uintptr(8 + 48 + 16 + 8 + 1 + 2)))
+
+		// The slice, its backing array of string headers, and their bytes.
+		Expect(spew.DeepSize([]string{"a", "bc"})).To(Equal(uintptr(24 + 2*16 + 1 + 2)))
+
+		Expect(spew.DeepSize(nil)).To(BeZero())
+	})
+
+	It("attributes the objects of cyclic values to their first owner", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowSizes = true
+		cs.DisablePointerAddresses = true
+		a := &sizeRing{V: make([]int64, 1)}
+		a.Next = &sizeRing{V: make([]int64, 1), Next: a}
+		Expect(cs.Sdump(a)).To(Equal("(*spew_test.sizeRing)(size: 8 retained: 88)({\n" +
+			"  V: ([]int64) (len: 1 cap: 1) (size: 24 retained: 32) {\n" +
+			"    (int64) 0\n" +
+			"  },\n" +
+			"  Next: (*spew_test.sizeRing)(size: 8 retained: 48)({\n" +
+			"    V: ([]int64) (len: 1 cap: 1) (size: 24 retained: 32) {\n" +
+			"      (int64) 0\n" +
+			"    },\n" +
+			"    Next: (*spew_test.sizeRing)(size: 8 retained: 8)(<already shown>)\n" +
+			"  })\n" +
+			"})\n"))
+	})
+})
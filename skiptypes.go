@@ -0,0 +1,45 @@
+package spew
+
+import "reflect"
+
+// skippedAngleBytes is displayed in place of the contents of values of the
+// SkipTypes.
+var skippedAngleBytes = []byte("<skipped>")
+
+// isSkippedType returns whether the contents of values of typ are skipped
+// according to the SkipTypes option.  Interface types skip every type which
+// implements them.
+func (c *ConfigState) isSkippedType(typ reflect.Type) bool {
+	return typeListed(c.SkipTypes, typ)
+}
+
+// placeholderType displays the type of v ahead of a placeholder displayed in
+// place of its contents.
+func (d *dumpState) placeholderType(v reflect.Value) {
+	if !d.ignoreNextType {
+		d.indent()
+		withParens(d, func(d *dumpState) {
+			printType(d.w, d.cs, v.Type().String())
+		})
+		d.w.Write(spaceBytes)
+	}
+	d.ignoreNextType = false
+}
+
+// dumpSkipped displays the value v of one of the SkipTypes along with its
+// type.
+func (d *dumpState) dumpSkipped(v reflect.Value) {
+	d.placeholderType(v)
+	withColor(d.w, skippedAngleBytes, d.cs.Color.Length...)
+}
+
+// formatSkipped formats the value v of one of the SkipTypes.
+func (f *formatState) formatSkipped(v reflect.Value) {
+	if !f.ignoreNextType && f.fs.Flag('#') {
+		f.punct(openParenBytes)
+		f.fs.Write([]byte(f.cs.typeName(v.Type().String())))
+		f.punct(closeParenBytes)
+	}
+	f.ignoreNextType = false
+	withColor(f.fs, skippedAngleBytes, f.cs.Color.Length...)
+}
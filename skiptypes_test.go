@@ -0,0 +1,67 @@
+package spew_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type skipPool struct {
+	mu    sync.Mutex
+	conns []int
+}
+
+type skipService struct {
+	Name string
+	Ctx  context.Context
+	Pool *skipPool
+}
+
+var _ = Describe("Skip Types Tests", func() {
+	v := skipService{
+		Name: "api",
+		Ctx:  context.Background(),
+		Pool: &skipPool{conns: []int{1, 2}},
+	}
+	skip := []reflect.Type{
+		reflect.TypeOf((*context.Context)(nil)).Elem(),
+		reflect.TypeOf((*skipPool)(nil)),
+	}
+
+	It("skips the contents of listed types in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.SkipTypes = skip
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.skipService) {\n" +
+			"  Name: (string) (len: 3) \"api\",\n" +
+			"  Ctx: (context.backgroundCtx) <skipped>,\n" +
+			"  Pool: (*spew_test.skipPool) <skipped>\n" +
+			"}\n"))
+	})
+
+	It("skips values behind pointers to listed types", func() {
+		cs := spew.NewTestConfig()
+		cs.DisablePointerAddresses = true
+		cs.SkipTypes = []reflect.Type{reflect.TypeOf(skipPool{})}
+		Expect(cs.Sdump(v.Pool)).To(Equal("(*spew_test.skipPool)(<skipped>)\n"))
+	})
+
+	It("skips the contents of listed types in Formatter output", func() {
+		cs := spew.NewTestConfig()
+		cs.SkipTypes = skip
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(v))).To(Equal(
+			"{Name:api Ctx:<skipped> Pool:<skipped>}"))
+	})
+
+	It("skips the contents of listed types in parsed trees", func() {
+		cs := spew.NewTestConfig()
+		cs.SkipTypes = skip
+		root := cs.Parse(v)
+		Expect(root.Children[2].Str).To(Equal("<skipped>"))
+		Expect(root.Children[2].Children).To(BeEmpty())
+	})
+})
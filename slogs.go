@@ -0,0 +1,78 @@
+package spew
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+var (
+	slogValueType = reflect.TypeOf(slog.Value{})
+	slogAttrType  = reflect.TypeOf(slog.Attr{})
+)
+
+// slogValue returns the slog.Value or slog.Attr held by v, and false when v
+// holds neither.
+func slogValue(v reflect.Value) (interface{}, bool) {
+	if v.Type() != slogValueType && v.Type() != slogAttrType {
+		return nil, false
+	}
+	if !v.CanInterface() {
+		if UnsafeDisabled {
+			return nil, false
+		}
+		v = unsafeReflectValue(v)
+	}
+	return v.Interface(), true
+}
+
+// dumpSlog writes the slog.Value or slog.Attr v held by sv, which is either,
+// by what it holds instead of by its internal fields.  Values are resolved
+// first, so LogValuer values are displayed as the value they log.
+func (d *dumpState) dumpSlog(v reflect.Value, staticType reflect.Type, sv interface{}) {
+	d.typeHeader(v, staticType)
+	if attr, ok := sv.(slog.Attr); ok {
+		writeFieldName(d.w, d.cs, attr.Key)
+		d.punct(colonSpaceBytes)
+		sv = attr.Value
+	}
+	d.dumpSlogValue(sv.(slog.Value))
+}
+
+// dumpSlogValue writes the slog.Value sv following a header, as the Go value
+// for its kind, or as its attributes when it is a group.
+func (d *dumpState) dumpSlogValue(sv slog.Value) {
+	sv = sv.Resolve()
+	if sv.Kind() == slog.KindAny && sv.Any() == nil {
+		d.w.Write(nilSymbol(d.cs))
+		return
+	}
+	if sv.Kind() != slog.KindGroup {
+		d.ignoreNextIndent = true
+		d.dump(d.unpackValue(reflect.ValueOf(sv.Any())))
+		d.ignoreNextIndent = false
+		return
+	}
+
+	d.punct(openBraceNewlineBytes)
+	d.depth++
+	if d.depth > d.maxDepth {
+		d.indent()
+		d.line(maxSymbol(d.cs))
+		d.countTruncation()
+	} else {
+		attrs := sv.Group()
+		for i, attr := range attrs {
+			d.indent()
+			writeFieldName(d.w, d.cs, attr.Key)
+			d.punct(colonSpaceBytes)
+			d.dumpSlogValue(attr.Value)
+			if i < len(attrs)-1 {
+				d.punct(commaBytes)
+			}
+			d.line(newlineBytes)
+		}
+	}
+	d.depth--
+	d.indent()
+	d.punct(closeBraceBytes)
+}
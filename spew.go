@@ -17,7 +17,6 @@
 package spew
 
 import (
-	"fmt"
 	"io"
 )
 
@@ -30,7 +29,7 @@ import (
 //
 //	fmt.Errorf(format, spew.NewFormatter(a), spew.NewFormatter(b))
 func Errorf(format string, a ...interface{}) (err error) {
-	return fmt.Errorf(format, convertArgs(a)...)
+	return global().Errorf(format, a...)
 }
 
 // Fprint is a wrapper for fmt.Fprint that treats each argument as if it were
@@ -42,7 +41,7 @@ func Errorf(format string, a ...interface{}) (err error) {
 //
 //	fmt.Fprint(w, spew.NewFormatter(a), spew.NewFormatter(b))
 func Fprint(w io.Writer, a ...interface{}) (n int, err error) {
-	return fmt.Fprint(w, convertArgs(a)...)
+	return global().Fprint(w, a...)
 }
 
 // Fprintf is a wrapper for fmt.Fprintf that treats each argument as if it were
@@ -54,7 +53,7 @@ func Fprint(w io.Writer, a ...interface{}) (n int, err error) {
 //
 //	fmt.Fprintf(w, format, spew.NewFormatter(a), spew.NewFormatter(b))
 func Fprintf(w io.Writer, format string, a ...interface{}) (n int, err error) {
-	return fmt.Fprintf(w, format, convertArgs(a)...)
+	return global().Fprintf(w, format, a...)
 }
 
 // Fprintln is a wrapper for fmt.Fprintln that treats each argument as if it
@@ -65,7 +64,7 @@ func Fprintf(w io.Writer, format string, a ...interface{}) (n int, err error) {
 //
 //	fmt.Fprintln(w, spew.NewFormatter(a), spew.NewFormatter(b))
 func Fprintln(w io.Writer, a ...interface{}) (n int, err error) {
-	return fmt.Fprintln(w, convertArgs(a)...)
+	return global().Fprintln(w, a...)
 }
 
 // Print is a wrapper for fmt.Print that treats each argument as if it were
@@ -77,7 +76,7 @@ func Fprintln(w io.Writer, a ...interface{}) (n int, err error) {
 //
 //	fmt.Print(spew.NewFormatter(a), spew.NewFormatter(b))
 func Print(a ...interface{}) (n int, err error) {
-	return fmt.Print(convertArgs(a)...)
+	return global().Print(a...)
 }
 
 // Printf is a wrapper for fmt.Printf that treats each argument as if it were
@@ -89,7 +88,7 @@ func Print(a ...interface{}) (n int, err error) {
 //
 //	fmt.Printf(format, spew.NewFormatter(a), spew.NewFormatter(b))
 func Printf(format string, a ...interface{}) (n int, err error) {
-	return fmt.Printf(format, convertArgs(a)...)
+	return global().Printf(format, a...)
 }
 
 // Println is a wrapper for fmt.Println that treats each argument as if it were
@@ -101,7 +100,7 @@ func Printf(format string, a ...interface{}) (n int, err error) {
 //
 //	fmt.Println(spew.NewFormatter(a), spew.NewFormatter(b))
 func Println(a ...interface{}) (n int, err error) {
-	return fmt.Println(convertArgs(a)...)
+	return global().Println(a...)
 }
 
 // Sprint is a wrapper for fmt.Sprint that treats each argument as if it were
@@ -112,7 +111,7 @@ func Println(a ...interface{}) (n int, err error) {
 //
 //	fmt.Sprint(spew.NewFormatter(a), spew.NewFormatter(b))
 func Sprint(a ...interface{}) string {
-	return fmt.Sprint(convertArgs(a)...)
+	return global().Sprint(a...)
 }
 
 // Sprintf is a wrapper for fmt.Sprintf that treats each argument as if it were
@@ -123,7 +122,7 @@ func Sprint(a ...interface{}) string {
 //
 //	fmt.Sprintf(format, spew.NewFormatter(a), spew.NewFormatter(b))
 func Sprintf(format string, a ...interface{}) string {
-	return fmt.Sprintf(format, convertArgs(a)...)
+	return global().Sprintf(format, a...)
 }
 
 // Sprintln is a wrapper for fmt.Sprintln that treats each argument as if it
@@ -134,15 +133,5 @@ func Sprintf(format string, a ...interface{}) string {
 //
 //	fmt.Sprintln(spew.NewFormatter(a), spew.NewFormatter(b))
 func Sprintln(a ...interface{}) string {
-	return fmt.Sprintln(convertArgs(a)...)
-}
-
-// convertArgs accepts a slice of arguments and returns a slice of the same
-// length with each argument converted to a default spew Formatter interface.
-func convertArgs(args []interface{}) (formatters []interface{}) {
-	formatters = make([]interface{}, len(args))
-	for index, arg := range args {
-		formatters[index] = NewFormatter(arg)
-	}
-	return formatters
+	return global().Sprintln(a...)
 }
@@ -22,6 +22,7 @@ import (
 	"os"
 
 	spew "github.com/ehowe/rainbow-spew"
+	"github.com/fatih/color"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -300,4 +301,23 @@ var _ = Describe("Spew Tests", func() {
 		Entry("Entry 38", func() *spew.ConfigState { return scsNoCap }, fCSSdump, "", func() interface{} { return make([]string, 0, 10) }, "([]string) {\n}\n"),
 		Entry("Entry 39", func() *spew.ConfigState { return scsNoCap }, fCSSdump, "", func() interface{} { return make([]string, 1, 10) }, "([]string) (len: 1) {\n(string) \"\"\n}\n"),
 	)
+
+	It("disables colors independently for Dump and Formatter output", func() {
+		noColor := color.NoColor
+		color.NoColor = false
+		defer func() { color.NoColor = noColor }()
+
+		colored := color.New(color.FgMagenta).Sprint("5")
+
+		cfg := spew.NewDefaultConfig()
+		cfg.DisableFormatterColor = true
+		Expect(cfg.Sdump(5)).To(ContainSubstring(colored))
+		Expect(cfg.Sprint(5)).To(Equal("5"))
+
+		cfg = spew.NewDefaultConfig()
+		cfg.DisableDumpColor = true
+		cfg.DimPunctuation = true
+		Expect(cfg.Sdump(5)).To(Equal("(int) 5\n"))
+		Expect(cfg.Sprint(5)).To(Equal(colored))
+	})
 })
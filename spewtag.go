@@ -0,0 +1,107 @@
+package spew
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// tagOptions returns the comma separated options of the struct tag with the
+// passed key, with surrounding spaces removed.
+func tagOptions(tag reflect.StructTag, key string) []string {
+	val, ok := tag.Lookup(key)
+	if !ok {
+		return nil
+	}
+	opts := strings.Split(val, ",")
+	for i, opt := range opts {
+		opts[i] = strings.TrimSpace(opt)
+	}
+	return opts
+}
+
+// fieldOptions holds the options of the spew struct tag of a field which
+// control how it is displayed.
+type fieldOptions struct {
+	// skip omits the field, redact replaces its value with a placeholder,
+	// stringer invokes its error or Stringer interface even when the
+	// DisableMethods option is set, and json displays the JSON document it
+	// holds indented.
+	skip     bool
+	redact   bool
+	stringer bool
+	json     bool
+
+	// maxDepth is the maximum number of levels to descend into the value of
+	// the field, or -1 when there is no limit.
+	maxDepth int
+}
+
+// spewTagOptions returns the options of the spew struct tag in tag.  Unknown
+// options, such as those handled elsewhere, are ignored.
+func spewTagOptions(tag reflect.StructTag) fieldOptions {
+	o := fieldOptions{maxDepth: -1}
+	for _, opt := range tagOptions(tag, "spew") {
+		switch opt {
+		case "-":
+			o.skip = true
+		case "redact":
+			o.redact = true
+		case "string":
+			o.stringer = true
+		case "json":
+			o.json = true
+		default:
+			if val, ok := strings.CutPrefix(opt, "maxdepth="); ok {
+				if n, err := strconv.Atoi(val); err == nil && n >= 0 {
+					o.maxDepth = n
+				}
+			}
+		}
+	}
+	return o
+}
+
+// fieldMaxDepth returns the depth beyond which the elements of containers are
+// not displayed within the value of a field with the options o visited at
+// depth, given the limit of the enclosing values.
+func (o fieldOptions) fieldMaxDepth(limit int, depth int) int {
+	if o.maxDepth >= 0 && depth+o.maxDepth < limit {
+		return depth + o.maxDepth
+	}
+	return limit
+}
+
+// redactedText returns the placeholder displayed in place of the redacted
+// value v, which gives the length of values which have one.
+func redactedText(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return "[REDACTED len=" + strconv.Itoa(v.Len()) + "]"
+	}
+	return "[REDACTED]"
+}
+
+// dumpRedacted displays the placeholder text for the redacted value v along
+// with its type.
+func (d *dumpState) dumpRedacted(v reflect.Value, text string) {
+	d.countRedaction()
+	d.placeholderType(v)
+	printRedacted(d.w, d.cs, text)
+}
+
+// formatField formats the value v of a struct field with the options o of its
+// spew struct tag.
+func (f *formatState) formatField(v reflect.Value, o fieldOptions) {
+	if o.redact {
+		printRedacted(f.fs, f.cs, redactedText(v))
+		return
+	}
+	if limit := o.fieldMaxDepth(f.maxDepth, f.depth); limit != f.maxDepth {
+		defer func(maxDepth int) { f.maxDepth = maxDepth }(f.maxDepth)
+		f.maxDepth = limit
+	}
+	f.forceMethods = o.stringer
+	f.format(v)
+	f.forceMethods = false
+}
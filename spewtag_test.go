@@ -0,0 +1,75 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type spewTagColor int
+
+func (c spewTagColor) String() string {
+	return "red"
+}
+
+type spewTagInner struct {
+	Leaf struct{ N int }
+}
+
+type spewTagTester struct {
+	Name     string
+	Internal int          `spew:"-"`
+	Password string       `spew:"redact"`
+	Color    spewTagColor `spew:"string"`
+	Plain    spewTagColor
+	Inner    *spewTagInner `spew:"maxdepth=1"`
+}
+
+var _ = Describe("Spew Tag Tests", func() {
+	v := spewTagTester{
+		Name:     "a",
+		Internal: 1,
+		Password: "hunter2",
+		Color:    1,
+		Plain:    1,
+		Inner:    &spewTagInner{},
+	}
+
+	It("applies spew tag options in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		cs.DisablePointerAddresses = true
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.spewTagTester) {\n" +
+			"  Name: (string) (len: 1) \"a\",\n" +
+			"  Password: (string) [REDACTED len=7],\n" +
+			"  Color: (spew_test.spewTagColor) red,\n" +
+			"  Plain: (spew_test.spewTagColor) 1,\n" +
+			"  Inner: (*spew_test.spewTagInner)({\n" +
+			"    Leaf: (struct { N int }) {\n" +
+			"      <max depth reached>\n" +
+			"    }\n" +
+			"  })\n" +
+			"}\n"))
+	})
+
+	It("applies spew tag options in Formatter output", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		Expect(fmt.Sprintf("%+v", cs.NewFormatter(v))).To(MatchRegexp(
+			`^\{Name:a Password:\[REDACTED len=7\] Color:red Plain:1 Inner:<\*>\(0x[0-9a-f]+\)\{Leaf:\{<max>\}\}\}$`))
+	})
+
+	It("applies spew tag options in parsed trees", func() {
+		cs := spew.NewTestConfig()
+		cs.DisableMethods = true
+		root := cs.Parse(v)
+		Expect(root.Children).To(HaveLen(5))
+		Expect(root.Children[1].Str).To(Equal("[REDACTED len=7]"))
+		Expect(root.Children[1].Value).To(BeNil())
+		Expect(root.Children[2].Str).To(Equal("red"))
+		Expect(root.Children[3].Str).To(BeEmpty())
+		Expect(root.Children[4].Children[0].Children[0].Truncated).To(BeTrue())
+	})
+})
@@ -0,0 +1,110 @@
+package spew
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// sqlKeywords houses the SQL keywords highlighted by the HighlightSQL option.
+var sqlKeywords = map[string]bool{
+	"ALTER": true, "AND": true, "AS": true, "ASC": true, "BETWEEN": true,
+	"BY": true, "CASE": true, "CREATE": true, "DELETE": true, "DESC": true,
+	"DISTINCT": true, "DROP": true, "ELSE": true, "END": true, "EXISTS": true,
+	"FROM": true, "FULL": true, "GROUP": true, "HAVING": true, "IN": true,
+	"INNER": true, "INSERT": true, "INTO": true, "IS": true, "JOIN": true,
+	"LEFT": true, "LIKE": true, "LIMIT": true, "NOT": true, "NULL": true,
+	"OFFSET": true, "ON": true, "OR": true, "ORDER": true, "OUTER": true,
+	"RETURNING": true, "RIGHT": true, "SELECT": true, "SET": true,
+	"TABLE": true, "THEN": true, "UNION": true, "UPDATE": true, "VALUES": true,
+	"WHEN": true, "WHERE": true, "WITH": true,
+}
+
+// sqlStatements maps the keywords which start SQL statements to the clauses
+// one of which must follow them for strings to be considered SQL regardless
+// of the name of the field holding them.
+var sqlStatements = map[string][]string{
+	"SELECT": {"FROM"},
+	"INSERT": {"INTO"},
+	"UPDATE": {"SET"},
+	"DELETE": {"FROM"},
+	"WITH":   {"AS"},
+	"CREATE": {"TABLE", "INDEX", "VIEW"},
+	"ALTER":  {"TABLE"},
+	"DROP":   {"TABLE", "INDEX", "VIEW"},
+}
+
+// isSQL returns whether the string s held by the struct field name, if any,
+// should be highlighted as SQL.  Strings are SQL when the field name mentions
+// a query or SQL, or when they start with a statement keyword which is later
+// followed by one of its clauses, as in SELECT ... FROM.
+func isSQL(name, s string) bool {
+	lower := strings.ToLower(name)
+	if strings.Contains(lower, "query") || strings.Contains(lower, "sql") {
+		return true
+	}
+	words := strings.Fields(strings.ToUpper(s))
+	if len(words) == 0 {
+		return false
+	}
+	for _, clause := range sqlStatements[words[0]] {
+		for _, word := range words[1:] {
+			if word == clause {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isWordByte returns whether c is part of an SQL keyword or identifier.
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// printSQL outputs the string s quoted like printString with its SQL keywords
+// highlighted with the Keyword color.  Words inside SQL string literals and
+// escape sequences of the quoted string are never highlighted.
+func printSQL(writer io.Writer, cs *ConfigState, s string) {
+	quoted := strconv.Quote(s)
+	inLiteral := false
+	start := 0
+	for i := 0; i < len(quoted); {
+		c := quoted[i]
+		switch {
+		case c == '\'':
+			inLiteral = !inLiteral
+			i++
+
+		case c == '\\':
+			// Skip the escape sequence so its letters aren't mistaken for
+			// part of a word.
+			switch quoted[i+1] {
+			case 'x':
+				i += 4
+			case 'u':
+				i += 6
+			case 'U':
+				i += 10
+			default:
+				i += 2
+			}
+
+		case isWordByte(c):
+			j := i
+			for j < len(quoted) && isWordByte(quoted[j]) {
+				j++
+			}
+			if !inLiteral && sqlKeywords[strings.ToUpper(quoted[i:j])] {
+				printString(writer, cs, quoted[start:i])
+				withColor(writer, []byte(quoted[i:j]), cs.Color.Keyword...)
+				start = j
+			}
+			i = j
+
+		default:
+			i++
+		}
+	}
+	printString(writer, cs, quoted[start:])
+}
@@ -0,0 +1,145 @@
+package spew
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DumpStats describes a single invocation of Dump.  It is returned by the
+// FdumpStats functions.
+type DumpStats struct {
+	// ID is the short unique identifier assigned to the dump.  It is
+	// included in the dump header when the ShowDumpID option is set, which
+	// makes it possible to locate a dump referenced in a bug report in
+	// aggregated logs.
+	ID string
+
+	// TraceID is the identifier returned by the TraceID option, if any.
+	TraceID string
+
+	// Bytes is the number of bytes written.
+	Bytes int
+
+	// Nodes is the number of values displayed, and Kinds counts them by
+	// their kind.  Pointers and the values they point to are counted
+	// separately.
+	Nodes int
+	Kinds map[reflect.Kind]int
+
+	// Depth is the deepest level of nesting reached.
+	Depth int
+
+	// Truncations is the number of values cut short by the MaxDepth,
+	// MaxElements and MaxStringLength options, and Redactions is the number
+	// of values hidden by redaction.
+	Truncations int
+	Redactions  int
+}
+
+// countingWriter is an io.Writer which counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// newDumpID returns a short random identifier for a dump.
+func newDumpID() string {
+	return fmt.Sprintf("%06x", rand.Uint32()&0xffffff)
+}
+
+// writeDumpHeader writes the header identifying the dump described by stats
+// when the ShowDumpID option is set.
+func writeDumpHeader(cs *ConfigState, w io.Writer, stats *DumpStats) {
+	if !cs.ShowDumpID {
+		return
+	}
+	w.Write(openParenBytes)
+	withColor(w, dumpIDEqualsBytes, cs.Color.Length...)
+	w.Write([]byte(stats.ID))
+	if stats.TraceID != "" {
+		w.Write(spaceBytes)
+		withColor(w, traceIDEqualsBytes, cs.Color.Length...)
+		w.Write([]byte(stats.TraceID))
+	}
+	w.Write(closeParenBytes)
+	w.Write(newlineBytes)
+}
+
+// countNode records a value of kind displayed at the current depth in the
+// statistics of the dump, if any.
+func (d *dumpState) countNode(kind reflect.Kind) {
+	if d.stats == nil {
+		return
+	}
+	d.stats.Nodes++
+	d.stats.Kinds[kind]++
+	d.stats.Depth = max(d.stats.Depth, d.depth)
+}
+
+// countTruncation records a value cut short in the statistics of the dump, if
+// any.
+func (d *dumpState) countTruncation() {
+	if d.stats != nil {
+		d.stats.Truncations++
+	}
+}
+
+// countRedaction records a redacted value in the statistics of the dump, if
+// any.
+func (d *dumpState) countRedaction() {
+	if d.stats != nil {
+		d.stats.Redactions++
+	}
+}
+
+// writeSummary writes the footer summarizing the dump described by stats when
+// the ShowSummary option is set.  The kinds are listed in the order of their
+// declaration by the reflect package.
+func writeSummary(cs *ConfigState, w io.Writer, stats *DumpStats) {
+	if !cs.ShowSummary {
+		return
+	}
+	text := fmt.Sprintf("(nodes: %d depth: %d truncated: %d redacted: %d bytes: %d)",
+		stats.Nodes, stats.Depth, stats.Truncations, stats.Redactions, stats.Bytes)
+	withColor(w, []byte(text), cs.Color.Length...)
+	w.Write(newlineBytes)
+	if len(stats.Kinds) == 0 {
+		return
+	}
+	kinds := make([]reflect.Kind, 0, len(stats.Kinds))
+	for kind := range stats.Kinds {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	counts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		counts[i] = kind.String() + "=" + strconv.Itoa(stats.Kinds[kind])
+	}
+	withColor(w, []byte("(kinds: "+strings.Join(counts, " ")+")"), cs.Color.Length...)
+	w.Write(newlineBytes)
+}
+
+// FdumpStats formats and displays the passed arguments to io.Writer w exactly
+// the same as Fdump and returns statistics about the dump, including the
+// unique identifier assigned to it.
+func (c *ConfigState) FdumpStats(w io.Writer, a ...interface{}) DumpStats {
+	return fdumpIDs(c, w, nil, a...)
+}
+
+// FdumpStats formats and displays the passed arguments to io.Writer w exactly
+// the same as Fdump and returns statistics about the dump, including the
+// unique identifier assigned to it.
+func FdumpStats(w io.Writer, a ...interface{}) DumpStats {
+	return fdumpIDs(global(), w, nil, a...)
+}
@@ -0,0 +1,64 @@
+package spew_test
+
+import (
+	"bytes"
+	"reflect"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stats Tests", func() {
+	It("returns a unique ID for each dump", func() {
+		cs := spew.NewTestConfig()
+		var buf bytes.Buffer
+		first := cs.FdumpStats(&buf, 1)
+		second := cs.FdumpStats(&buf, 1)
+		Expect(first.ID).To(MatchRegexp("^[0-9a-f]{6}$"))
+		Expect(first.ID).NotTo(Equal(second.ID))
+		Expect(first.Bytes).To(Equal(len("(int) 1\n")))
+		Expect(buf.String()).To(Equal("(int) 1\n(int) 1\n"))
+	})
+
+	It("writes the ID and trace ID in the header", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowDumpID = true
+		cs.TraceID = func() string { return "4bf92f35" }
+		var buf bytes.Buffer
+		stats := cs.FdumpStats(&buf, 1)
+		Expect(stats.TraceID).To(Equal("4bf92f35"))
+		Expect(buf.String()).To(Equal("(dump: " + stats.ID + " trace: 4bf92f35)\n(int) 1\n"))
+		Expect(stats.Bytes).To(Equal(buf.Len()))
+	})
+
+	It("counts the values displayed, truncated and redacted", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxElements = 2
+		cs.Redact = true
+		v := struct {
+			Items    []int
+			Password string
+		}{[]int{1, 2, 3}, "hunter2"}
+		var buf bytes.Buffer
+		stats := cs.FdumpStats(&buf, v)
+		Expect(stats.Nodes).To(Equal(5))
+		Expect(stats.Kinds).To(Equal(map[reflect.Kind]int{reflect.Struct: 1, reflect.Slice: 1, reflect.Int: 2, reflect.String: 1}))
+		Expect(stats.Depth).To(Equal(2))
+		Expect(stats.Truncations).To(Equal(1))
+		Expect(stats.Redactions).To(Equal(1))
+	})
+
+	It("ends the output with a summary", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowSummary = true
+		var buf bytes.Buffer
+		stats := cs.FdumpStats(&buf, []string{"a"})
+		Expect(buf.String()).To(Equal("([]string) (len: 1 cap: 1) {\n" +
+			"  (string) (len: 1) \"a\"\n" +
+			"}\n" +
+			"(nodes: 2 depth: 1 truncated: 0 redacted: 0 bytes: 55)\n" +
+			"(kinds: slice=1 string=1)\n"))
+		Expect(stats.Bytes).To(Equal(buf.Len()))
+	})
+})
@@ -0,0 +1,81 @@
+package spew
+
+// SymbolMode specifies which symbols, if any, replace the words used for
+// sentinel values such as nil, true and false in Dump output.
+type SymbolMode int
+
+const (
+	// SymbolsNone writes sentinel values as words.  This is the default.
+	SymbolsNone SymbolMode = iota
+
+	// SymbolsUnicode writes nil as ∅, true and false as ✓ and ✗, and the
+	// marker for values beyond MaxDepth as an ellipsis.
+	SymbolsUnicode
+
+	// SymbolsASCII writes pure ASCII equivalents of the Unicode symbols for
+	// terminals or logs which can't display them.
+	SymbolsASCII
+)
+
+// symbolSet houses the symbols written for each sentinel value.
+type symbolSet struct {
+	nil, true, false, max []byte
+}
+
+// unicodeSymbols houses the symbols for SymbolsUnicode.
+var unicodeSymbols = symbolSet{
+	nil:   []byte("∅"),
+	true:  []byte("✓"),
+	false: []byte("✗"),
+	max:   []byte("…\n"),
+}
+
+// asciiSymbols houses the symbols for SymbolsASCII.
+var asciiSymbols = symbolSet{
+	nil:   []byte("()"),
+	true:  []byte("[x]"),
+	false: []byte("[ ]"),
+	max:   []byte("...\n"),
+}
+
+// symbols returns the symbol set selected by the Symbols option, or nil when
+// sentinel values are written as words.
+func (c *ConfigState) symbols() *symbolSet {
+	switch c.Symbols {
+	case SymbolsUnicode:
+		return &unicodeSymbols
+	case SymbolsASCII:
+		return &asciiSymbols
+	}
+	return nil
+}
+
+// nilSymbol returns the text written for nil values.
+func nilSymbol(cs *ConfigState) []byte {
+	if s := cs.symbols(); s != nil {
+		return s.nil
+	}
+	return nilAngleBytes
+}
+
+// boolSymbol returns the text written for the bool val.
+func boolSymbol(cs *ConfigState, val bool) []byte {
+	s := cs.symbols()
+	switch {
+	case s == nil && val:
+		return trueBytes
+	case s == nil:
+		return falseBytes
+	case val:
+		return s.true
+	}
+	return s.false
+}
+
+// maxSymbol returns the line written in place of values beyond MaxDepth.
+func maxSymbol(cs *ConfigState) []byte {
+	if s := cs.symbols(); s != nil {
+		return s.max
+	}
+	return maxNewlineBytes
+}
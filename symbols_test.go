@@ -0,0 +1,70 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type symbolsTester struct {
+	On   bool
+	Off  bool
+	Ptr  *int
+	Next *symbolsTester
+}
+
+var _ = Describe("Symbols Tests", func() {
+	v := symbolsTester{On: true, Next: &symbolsTester{}}
+
+	It("writes sentinel values as words by default", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		cs.DisablePointerAddresses = true
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.symbolsTester) {\n" +
+			"  On: (bool) true,\n" +
+			"  Off: (bool) false,\n" +
+			"  Ptr: (*int)(<nil>),\n" +
+			"  Next: (*spew_test.symbolsTester)({\n" +
+			"    <max depth reached>\n" +
+			"  })\n" +
+			"}\n"))
+	})
+
+	It("writes sentinel values as Unicode symbols", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		cs.DisablePointerAddresses = true
+		cs.Symbols = spew.SymbolsUnicode
+		Expect(cs.Sdump(v, nil)).To(Equal("(spew_test.symbolsTester) {\n" +
+			"  On: (bool) ✓,\n" +
+			"  Off: (bool) ✗,\n" +
+			"  Ptr: (*int)(∅),\n" +
+			"  Next: (*spew_test.symbolsTester)({\n" +
+			"    …\n" +
+			"  })\n" +
+			"}\n" +
+			"(interface {}) ∅\n"))
+	})
+
+	It("writes sentinel values as ASCII symbols", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxDepth = 1
+		cs.DisablePointerAddresses = true
+		cs.Symbols = spew.SymbolsASCII
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.symbolsTester) {\n" +
+			"  On: (bool) [x],\n" +
+			"  Off: (bool) [ ],\n" +
+			"  Ptr: (*int)(()),\n" +
+			"  Next: (*spew_test.symbolsTester)({\n" +
+			"    ...\n" +
+			"  })\n" +
+			"}\n"))
+	})
+
+	It("leaves the output of the formatter alone", func() {
+		cs := spew.NewTestConfig()
+		cs.Symbols = spew.SymbolsUnicode
+		Expect(cs.Sprintf("%v", v)).To(Equal(spew.NewTestConfig().Sprintf("%v", v)))
+		Expect(cs.Sprintf("%v", true)).To(Equal("true"))
+	})
+})
@@ -0,0 +1,137 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	tableKeyBytes   = []byte("key")
+	tableValueBytes = []byte("value")
+	tablePipeBytes  = []byte("|")
+)
+
+// isTabular returns whether the entries of the map v with the passed keys are
+// displayed as a table, which requires every key and value to be a scalar or
+// a string.
+func isTabular(v reflect.Value, keys []reflect.Value) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	cell := func(v reflect.Value) bool {
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return false
+			}
+			v = v.Elem()
+		}
+		return v.Kind() == reflect.String || isScalarKind(v.Kind())
+	}
+	for _, key := range keys {
+		if !cell(key) || !cell(v.MapIndex(key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleWidth returns the number of columns s is displayed in, which
+// excludes its ANSI escape sequences.
+func visibleWidth(s string) int {
+	cw := &columnWriter{w: io.Discard}
+	cw.Write([]byte(s))
+	return cw.col
+}
+
+// tableCell returns the text of the key or value v in a table.  The type of
+// values held by interfaces is included since the type of the map does not
+// give it.
+func (d *dumpState) tableCell(v reflect.Value) string {
+	var buf bytes.Buffer
+	c := &dumpState{
+		w:        &buf,
+		cs:       d.cs,
+		maxDepth: d.maxDepth,
+		stats:    d.stats,
+		redactor: d.redactor,
+		path:     d.path,
+		keyName:  d.keyName,
+		pointers: make(map[uintptr]int),
+		visited:  make(map[visitKey]bool),
+	}
+	typed := v.Kind() == reflect.Interface
+	v = c.unpackValue(v)
+	if typed {
+		writeGlyph(&buf, d.cs, v.Kind())
+		c.punct(openParenBytes)
+		printType(&buf, d.cs, v.Type().String())
+		c.punct(closeParenBytes)
+		buf.Write(spaceBytes)
+	}
+	text, custom := d.cs.customRedaction(d.path, d.keyName, v)
+	switch {
+	case v.Kind() != reflect.String:
+		c.ignoreNextType = true
+		c.dump(v)
+	case d.redactor.redacts(d.keyName, v.Type()):
+		c.countRedaction()
+		printRedacted(&buf, d.cs, redactedText(v))
+	case custom:
+		c.countRedaction()
+		printRedacted(&buf, d.cs, text)
+	default:
+		c.countNode(v.Kind())
+		s, removed := d.cs.truncateString(v.String())
+		printString(&buf, d.cs, strconv.Quote(s))
+		writeTruncation(&buf, d.cs, removed)
+		if removed > 0 {
+			c.countTruncation()
+		}
+	}
+	return buf.String()
+}
+
+// tableEscaper escapes the pipes and line breaks of cells, which would end
+// the cell and the row of a Markdown table.
+var tableEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// tableEscape returns the cell s escaped for a Markdown table.
+func tableEscape(s string) string {
+	return tableEscaper.Replace(s)
+}
+
+// dumpMapTable displays the entries of the map v with the passed keys as a
+// Markdown table with a column of keys and a column of values.
+func (d *dumpState) dumpMapTable(v reflect.Value, keys []reflect.Value) {
+	cells := make([][2]string, len(keys))
+	widths := [2]int{len(tableKeyBytes), len(tableValueBytes)}
+	for i, key := range keys {
+		cells[i][0] = tableEscape(d.tableCell(key))
+		d.keyName = mapKeyName(key)
+		cells[i][1] = tableEscape(d.tableCell(v.MapIndex(key)))
+		d.keyName = ""
+		for col, cell := range cells[i] {
+			widths[col] = max(widths[col], visibleWidth(cell))
+		}
+	}
+
+	row := func(cols [2]string) {
+		d.indent()
+		for col, cell := range cols {
+			d.punct(tablePipeBytes)
+			d.w.Write(spaceBytes)
+			d.w.Write([]byte(cell))
+			d.w.Write([]byte(strings.Repeat(" ", widths[col]-visibleWidth(cell)+1)))
+		}
+		d.punct(tablePipeBytes)
+		d.w.Write(newlineBytes)
+	}
+	row([2]string{string(tableKeyBytes), string(tableValueBytes)})
+	row([2]string{strings.Repeat("-", widths[0]), strings.Repeat("-", widths[1])})
+	for _, cols := range cells {
+		row(cols)
+	}
+}
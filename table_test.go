@@ -0,0 +1,86 @@
+package spew_test
+
+import (
+	"strings"
+	"time"
+
+	spew "github.com/ehowe/rainbow-spew"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// tableLines is displayed as that many lines of x.
+type tableLines int
+
+func (t tableLines) String() string { return strings.TrimSuffix(strings.Repeat("x\n", int(t)), "\n") }
+
+var _ = Describe("MapTables Tests", func() {
+	It("displays maps of scalars as tables", func() {
+		cfg := spew.NewTestConfig()
+		cfg.MapTables = true
+		cfg.SortKeys = true
+		v := struct {
+			Limits map[string]int
+		}{map[string]int{"cpu": 2, "memory": 1024}}
+		Expect(cfg.Sdump(v)).To(Equal(`(struct { Limits map[string]int }) {
+  Limits: (map[string]int) (len: 2) {
+    | key      | value |
+    | -------- | ----- |
+    | "cpu"    | 2     |
+    | "memory" | 1024  |
+  }
+}
+`))
+	})
+
+	It("includes the types of values held by interfaces", func() {
+		cfg := spew.NewTestConfig()
+		cfg.MapTables = true
+		cfg.SortKeys = true
+		v := map[string]interface{}{"a": true, "b": "x", "c": time.Second}
+		Expect(cfg.Sdump(v)).To(Equal(`(map[string]interface {}) (len: 3) {
+  | key | value              |
+  | --- | ------------------ |
+  | "a" | (bool) true        |
+  | "b" | (string) "x"       |
+  | "c" | (time.Duration) 1s |
+}
+`))
+	})
+
+	It("aligns colored cells", func() {
+		noColor := color.NoColor
+		color.NoColor = false
+		defer func() { color.NoColor = noColor }()
+
+		cfg := spew.NewDefaultConfig()
+		cfg.MapTables = true
+		cfg.SortKeys = true
+		str := color.New(cfg.Color.String...).Sprint
+		num := color.New(cfg.Color.Number...).Sprint
+		Expect(cfg.Sdump(map[string]int{"a": 10, "bb": 2})).To(HaveSuffix(
+			"  | " + str(`"a"`) + "  | " + num("10") + "    |\n" +
+				"  | " + str(`"bb"`) + " | " + num("2") + "     |\n}\n"))
+	})
+
+	It("falls back to braces for other maps", func() {
+		cfg := spew.NewTestConfig()
+		cfg.MapTables = true
+		Expect(cfg.Sdump(map[string][]int{"a": {1}})).To(ContainSubstring(`"a": ([]int)`))
+		Expect(cfg.Sdump(map[string]interface{}{"a": nil})).To(ContainSubstring(`"a": (interface {}) <nil>`))
+	})
+
+	It("escapes pipes and line breaks in cells", func() {
+		cfg := spew.NewTestConfig()
+		cfg.MapTables = true
+		cfg.SortKeys = true
+		v := map[string]interface{}{"a|b": tableLines(2)}
+		Expect(cfg.Sdump(v)).To(Equal(`(map[string]interface {}) (len: 1) {
+  | key    | value                         |
+  | ------ | ----------------------------- |
+  | "a\|b" | (spew_test.tableLines) x<br>x |
+}
+`))
+	})
+})
@@ -0,0 +1,26 @@
+package spew
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// writeFieldTags outputs the struct tags of a field with the keys given by
+// the ShowTags option to Writer w, in the order of the option, as a raw
+// string following a space.  Nothing is written when the field has none of
+// the tags.
+func writeFieldTags(w io.Writer, cs *ConfigState, tag reflect.StructTag) {
+	var tags []string
+	for _, key := range cs.ShowTags {
+		if val, ok := tag.Lookup(key); ok {
+			tags = append(tags, key+":"+strconv.Quote(val))
+		}
+	}
+	if len(tags) == 0 {
+		return
+	}
+	w.Write(spaceBytes)
+	withColor(w, []byte("`"+strings.Join(tags, " ")+"`"), cs.Color.Length...)
+}
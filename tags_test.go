@@ -0,0 +1,38 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type tagsTester struct {
+	UserID int    `db:"uid" json:"user_id,omitempty"`
+	Name   string `json:"name"`
+	Note   string
+}
+
+var _ = Describe("ShowTags Tests", func() {
+	v := tagsTester{UserID: 1}
+
+	It("displays the selected struct tags in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowTags = []string{"json", "db"}
+		Expect(cs.Sdump(v)).To(Equal("(spew_test.tagsTester) {\n" +
+			"  UserID `json:\"user_id,omitempty\" db:\"uid\"`: (int) 1,\n" +
+			"  Name `json:\"name\"`: (string) \"\",\n" +
+			"  Note: (string) \"\"\n" +
+			"}\n"))
+	})
+
+	It("displays the selected struct tags in trees", func() {
+		cs := spew.NewTestConfig()
+		cs.ShowTags = []string{"db"}
+		Expect(cs.SdumpTree(v)).To(ContainSubstring("UserID `db:\"uid\"`: (int) 1\n"))
+	})
+
+	It("displays no tags by default", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(v)).NotTo(ContainSubstring("`"))
+	})
+})
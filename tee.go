@@ -0,0 +1,105 @@
+package spew
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// plainWriter is an io.Writer which removes ANSI escape sequences, such as
+// colors, from the output written to w.
+type plainWriter struct {
+	w      io.Writer
+	escape bool
+}
+
+// Write writes b to w with its escape sequences removed.  The number of bytes
+// of b consumed is returned, which includes the escape sequences.
+func (p *plainWriter) Write(b []byte) (int, error) {
+	plain := make([]byte, 0, len(b))
+	for _, c := range b {
+		switch {
+		case p.escape:
+			// Escape sequences end with a letter, such as the m of a
+			// color sequence.
+			p.escape = !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z')
+		case c == '\x1b':
+			p.escape = true
+		default:
+			plain = append(plain, c)
+		}
+	}
+	if _, err := p.w.Write(plain); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// teeWriter returns a writer which writes to w and, without colors, to the
+// AlsoWrite writer of cs when it is set, or w itself otherwise.
+func teeWriter(cs *ConfigState, w io.Writer) io.Writer {
+	if cs.AlsoWrite == nil {
+		return w
+	}
+	return io.MultiWriter(w, &plainWriter{w: cs.AlsoWrite})
+}
+
+// RingBuffer is an io.Writer which retains the most recent output written to
+// it up to a fixed size, discarding older output.  It suits the AlsoWrite
+// option for keeping the last dumps of a long-running process around for
+// post-mortem inspection.  It is safe for concurrent use.
+type RingBuffer struct {
+	mu   sync.Mutex
+	buf  []byte
+	head int
+	n    int
+}
+
+// NewRingBuffer returns a RingBuffer which retains the last size bytes
+// written to it.  It panics when size is not positive.
+func NewRingBuffer(size int) *RingBuffer {
+	if size <= 0 {
+		panic(fmt.Sprintf("spew: ring buffer of non-positive size %d", size))
+	}
+	return &RingBuffer{buf: make([]byte, size)}
+}
+
+// Write appends b to the buffer, discarding the oldest output beyond its
+// size.  It never fails.
+func (r *RingBuffer) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	written := len(b)
+	// Only the end of b fits when it is longer than the buffer.
+	if over := len(b) - len(r.buf); over > 0 {
+		b = b[over:]
+		r.head, r.n = 0, 0
+	}
+	for len(b) > 0 {
+		end := (r.head + r.n) % len(r.buf)
+		c := copy(r.buf[end:], b)
+		b = b[c:]
+		if r.n += c; r.n > len(r.buf) {
+			r.head = (r.head + r.n - len(r.buf)) % len(r.buf)
+			r.n = len(r.buf)
+		}
+	}
+	return written, nil
+}
+
+// Bytes returns a copy of the output retained by the buffer.
+func (r *RingBuffer) Bytes() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]byte, 0, r.n)
+	if end := r.head + r.n; end <= len(r.buf) {
+		return append(out, r.buf[r.head:end]...)
+	}
+	out = append(out, r.buf[r.head:]...)
+	return append(out, r.buf[:r.head+r.n-len(r.buf)]...)
+}
+
+// String returns the output retained by the buffer.
+func (r *RingBuffer) String() string {
+	return string(r.Bytes())
+}
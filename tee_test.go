@@ -0,0 +1,60 @@
+package spew_test
+
+import (
+	"bytes"
+
+	spew "github.com/ehowe/rainbow-spew"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tee Tests", func() {
+	It("copies dumps without colors", func() {
+		noColor := color.NoColor
+		color.NoColor = false
+		defer func() { color.NoColor = noColor }()
+
+		var plain bytes.Buffer
+		cs := spew.NewTestConfig()
+		cs.Color.Number = []color.Attribute{color.FgMagenta}
+		cs.AlsoWrite = &plain
+		s := cs.Sdump(1)
+		Expect(s).To(Equal("(int) " + color.New(color.FgMagenta).Sprint("1") + "\n"))
+		Expect(plain.String()).To(Equal("(int) 1\n"))
+
+		plain.Reset()
+		var buf bytes.Buffer
+		cs.Fprintf(&buf, "%v", 2)
+		Expect(buf.String()).To(ContainSubstring("\x1b["))
+		Expect(plain.String()).To(Equal("2"))
+	})
+
+	It("retains the most recent output in a ring buffer", func() {
+		ring := spew.NewRingBuffer(16)
+		cs := spew.NewTestConfig()
+		cs.AlsoWrite = ring
+		cs.Sdump("first")
+		cs.Sdump(2)
+		Expect(ring.String()).To(Equal("\"first\"\n(int) 2\n"))
+	})
+
+	It("wraps around the ring buffer", func() {
+		ring := spew.NewRingBuffer(5)
+		for _, s := range []string{"ab", "cd", "efg", "h", "ijklmnop", "q"} {
+			ring.Write([]byte(s))
+		}
+		Expect(ring.String()).To(Equal("mnopq"))
+
+		ring = spew.NewRingBuffer(4)
+		ring.Write([]byte("abc"))
+		Expect(ring.String()).To(Equal("abc"))
+		ring.Write([]byte("de"))
+		Expect(ring.String()).To(Equal("bcde"))
+	})
+
+	It("rejects ring buffers without room", func() {
+		Expect(func() { spew.NewRingBuffer(0) }).To(PanicWith("spew: ring buffer of non-positive size 0"))
+		Expect(func() { spew.NewRingBuffer(-1) }).To(PanicWith("spew: ring buffer of non-positive size -1"))
+	})
+})
@@ -0,0 +1,11 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !zos
+
+package spew
+
+import "os"
+
+// terminalWidth returns 0 since the width of terminals can't be determined
+// on this platform.
+func terminalWidth(f *os.File) int {
+	return 0
+}
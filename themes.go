@@ -0,0 +1,94 @@
+package spew
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/fatih/color"
+)
+
+// themes houses the named color configurations available through
+// LookupTheme.
+var themes = map[string]ColorConfiguration{
+	"default": {
+		String:   []color.Attribute{color.FgRed},
+		Number:   []color.Attribute{color.FgMagenta},
+		Bool:     []color.Attribute{color.FgYellow},
+		Type:     []color.Attribute{color.FgGreen, color.Underline},
+		Length:   []color.Attribute{color.FgCyan},
+		Redacted: []color.Attribute{color.BgRed, color.FgHiWhite},
+		Keyword:  []color.Attribute{color.FgBlue, color.Bold},
+		Depth:    []color.Attribute{color.FgBlue, color.FgMagenta, color.FgCyan, color.FgYellow},
+		Label:    []color.Attribute{color.FgHiCyan, color.Bold},
+	},
+	"dracula": {
+		String:   []color.Attribute{color.FgHiYellow},
+		Number:   []color.Attribute{color.FgHiMagenta},
+		Bool:     []color.Attribute{color.FgHiMagenta},
+		Type:     []color.Attribute{color.FgHiCyan, color.Italic},
+		Length:   []color.Attribute{color.FgHiBlack},
+		Redacted: []color.Attribute{color.BgHiRed, color.FgBlack},
+		Keyword:  []color.Attribute{color.FgHiRed, color.Bold},
+		Depth:    []color.Attribute{color.FgHiMagenta, color.FgHiCyan, color.FgHiGreen, color.FgHiYellow},
+		Label:    []color.Attribute{color.FgHiGreen, color.Bold},
+	},
+	"solarized": {
+		String:   []color.Attribute{color.FgCyan},
+		Number:   []color.Attribute{color.FgMagenta},
+		Bool:     []color.Attribute{color.FgYellow},
+		Type:     []color.Attribute{color.FgBlue},
+		Length:   []color.Attribute{color.FgHiBlack},
+		Redacted: []color.Attribute{color.BgRed, color.FgWhite},
+		Keyword:  []color.Attribute{color.FgGreen, color.Bold},
+		Depth:    []color.Attribute{color.FgBlue, color.FgCyan, color.FgGreen, color.FgYellow},
+		Label:    []color.Attribute{color.FgYellow, color.Bold},
+	},
+	"monochrome": {
+		Type:     []color.Attribute{color.Bold},
+		Length:   []color.Attribute{color.Faint},
+		Redacted: []color.Attribute{color.ReverseVideo},
+		Keyword:  []color.Attribute{color.Bold},
+		Depth:    []color.Attribute{color.Faint},
+		Label:    []color.Attribute{color.Bold},
+	},
+	"none": {},
+}
+
+// cloneColors returns a copy of cc which shares no attribute slices with it.
+func cloneColors(cc ColorConfiguration) ColorConfiguration {
+	return ColorConfiguration{
+		String:   slices.Clone(cc.String),
+		Number:   slices.Clone(cc.Number),
+		Bool:     slices.Clone(cc.Bool),
+		Type:     slices.Clone(cc.Type),
+		Length:   slices.Clone(cc.Length),
+		Redacted: slices.Clone(cc.Redacted),
+		Keyword:  slices.Clone(cc.Keyword),
+		Depth:    slices.Clone(cc.Depth),
+		Label:    slices.Clone(cc.Label),
+	}
+}
+
+// LookupTheme returns the color configuration of the named theme and whether
+// a theme with that name exists.  The built-in themes are default, dracula,
+// solarized, monochrome, which uses only text styles, and none, which
+// disables colors.  The returned configuration is a copy which may be
+// modified freely.
+func LookupTheme(name string) (ColorConfiguration, bool) {
+	cc, ok := themes[name]
+	if !ok {
+		return ColorConfiguration{}, false
+	}
+	return cloneColors(cc), true
+}
+
+// ThemeNames returns the sorted names of the themes available through
+// LookupTheme.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
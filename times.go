@@ -0,0 +1,51 @@
+package spew
+
+import (
+	"io"
+	"reflect"
+	"time"
+)
+
+// durationType is the reflect type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// relativeTime returns how long ago or ahead of now t is, as in 2m13s ago or
+// in 5s, rounded to the second unless it is under one.
+func relativeTime(t time.Time) string {
+	d := time.Since(t)
+	ahead := d < 0
+	if ahead {
+		d = -d
+	}
+	if d >= time.Second {
+		d = d.Round(time.Second)
+	} else {
+		d = d.Round(time.Millisecond)
+	}
+	if ahead {
+		return "in " + d.String()
+	}
+	return d.String() + " ago"
+}
+
+// timeFormatter returns the formatter for the values of typ used by the
+// HumanTimes option, and false when typ is neither time.Time nor
+// time.Duration.
+func (c *ConfigState) timeFormatter(typ reflect.Type) (func(w io.Writer, v interface{}), bool) {
+	switch typ {
+	case timeType:
+		return func(w io.Writer, v interface{}) {
+			t := v.(time.Time)
+			text := t.Format(time.RFC3339Nano)
+			if c.RelativeTimes && !t.IsZero() {
+				text += " (" + relativeTime(t) + ")"
+			}
+			withColor(w, []byte(text), c.Color.Number...)
+		}, true
+	case durationType:
+		return func(w io.Writer, v interface{}) {
+			withColor(w, []byte(v.(time.Duration).String()), c.Color.Number...)
+		}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,41 @@
+package spew_test
+
+import (
+	"time"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type timesEvent struct {
+	At      time.Time
+	Timeout time.Duration
+}
+
+var _ = Describe("Times Tests", func() {
+	newConfig := func() *spew.ConfigState {
+		cs := spew.NewTestConfig()
+		cs.HumanTimes = true
+		cs.DisableMethods = true
+		return cs
+	}
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	It("displays times and durations in human terms", func() {
+		cs := newConfig()
+		Expect(cs.Sdump(timesEvent{at, 90 * time.Second})).To(Equal("(spew_test.timesEvent) {\n" +
+			"  At: (time.Time) 2024-05-01T12:00:00Z,\n" +
+			"  Timeout: (time.Duration) 1m30s\n" +
+			"}\n"))
+		Expect(cs.Sprintf("%v", timesEvent{at, time.Second})).To(Equal("{2024-05-01T12:00:00Z 1s}"))
+	})
+
+	It("displays how long ago times are", func() {
+		cs := newConfig()
+		cs.RelativeTimes = true
+		Expect(cs.Sdump(time.Now().Add(-133 * time.Second))).To(MatchRegexp(`^\(time\.Time\) \S+ \(2m13s ago\)\n$`))
+		Expect(cs.Sdump(time.Now().Add(time.Hour))).To(MatchRegexp(`^\(time\.Time\) \S+ \(in 1h0m0s\)\n$`))
+		Expect(cs.Sdump(time.Time{})).To(Equal("(time.Time) 0001-01-01T00:00:00Z\n"))
+	})
+})
@@ -0,0 +1,267 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+var (
+	treeBranchBytes = []byte("├── ")
+	treeLastBytes   = []byte("└── ")
+	treePipeBytes   = []byte("│   ")
+	treeBlankBytes  = []byte("    ")
+)
+
+// treeState contains information about the state of a tree dump operation.
+type treeState struct {
+	w  io.Writer
+	cs *ConfigState
+
+	// guides holds whether each ancestor level still has siblings to draw,
+	// which determines whether a vertical guide continues through it.
+	guides []bool
+
+	// ids labels pointers with the identifiers it assigns instead of their
+	// addresses when it is not nil.
+	ids *pointerIDs
+}
+
+// guide writes the guide b for the passed level in the color for that level.
+func (t *treeState) guide(b []byte, level int) {
+	if len(t.cs.Color.Depth) == 0 {
+		t.w.Write(b)
+		return
+	}
+	attr := t.cs.Color.Depth[level%len(t.cs.Color.Depth)]
+	withColor(t.w, b, attr)
+}
+
+// label writes the name of n within its parent, such as a field name, index
+// or map key, followed by a colon.
+func (t *treeState) label(n *node) {
+	if n.parent == nil {
+		return
+	}
+	switch n.parent.kind {
+	case reflect.Struct:
+		writeFieldName(t.w, t.cs, n.name)
+		writeFieldTags(t.w, t.cs, n.tag)
+	case reflect.Map:
+		var key bytes.Buffer
+		writeCanonical(&key, n.key, n.parent.typ.Key())
+		t.w.Write(key.Bytes())
+	default:
+		t.w.Write(openBracketBytes)
+		t.w.Write([]byte(strconv.Itoa(n.index)))
+		t.w.Write(closeBracketBytes)
+	}
+	t.w.Write(colonSpaceBytes)
+}
+
+// value writes the type and value of n on its line of the tree.  Pointers are
+// collapsed into the value they point to, which is returned since its
+// children are the ones drawn below the line.
+func (t *treeState) value(n *node) *node {
+	if n.kind == reflect.Interface && n.isNil {
+		t.w.Write(nilAngleBytes)
+		return n
+	}
+	if n.kind == reflect.Invalid {
+		t.w.Write(invalidAngleBytes)
+		return n
+	}
+
+	t.w.Write(openParenBytes)
+	printType(t.w, t.cs, n.typ.String())
+	t.w.Write(closeParenBytes)
+
+	if n.str != "" {
+		t.w.Write(spaceBytes)
+		t.w.Write([]byte(n.str))
+		return n
+	}
+
+	// Containers only write details when they have any.
+	switch n.kind {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Array, reflect.Struct:
+	default:
+		t.w.Write(spaceBytes)
+	}
+
+	switch n.kind {
+	case reflect.Bool:
+		printBool(t.w, t.cs, n.value.(bool))
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		printNumber(t.w, t.cs, n.value.(int64))
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		printNumber(t.w, t.cs, n.value.(uint64))
+
+	case reflect.Uintptr:
+		writeAddress(t.w, t.cs, nil, n.value.(uintptr))
+
+	case reflect.Float32:
+		printFloat(t.w, t.cs, n.value.(float64), 32)
+
+	case reflect.Float64:
+		printFloat(t.w, t.cs, n.value.(float64), 64)
+
+	case reflect.Complex64, reflect.Complex128:
+		printNumber(t.w, t.cs, n.value.(complex128))
+
+	case reflect.String:
+		printString(t.w, t.cs, strconv.Quote(n.value.(string)))
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if n.isNil {
+			t.w.Write(nilAngleBytes)
+		} else {
+			t.ids.write(t.w, n.addr)
+		}
+
+	case reflect.Ptr:
+		switch {
+		case n.isNil:
+			t.w.Write(spaceBytes)
+			t.w.Write(nilAngleBytes)
+		case n.cycle:
+			t.w.Write(spaceBytes)
+			t.w.Write(circularBytes)
+		default:
+			if !t.cs.DisablePointerAddresses {
+				t.w.Write(spaceBytes)
+				t.ids.write(t.w, n.addr)
+			}
+			pointee := n.children[0]
+			switch pointee.kind {
+			case reflect.Slice, reflect.Map, reflect.Array, reflect.Struct:
+				return t.container(pointee)
+			}
+			t.w.Write(spaceBytes)
+			t.w.Write(pointerChainBytes)
+			t.w.Write(spaceBytes)
+			return t.value(pointee)
+		}
+
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Struct:
+		return t.container(n)
+	}
+	return n
+}
+
+// container writes the details of the container n which are not covered by
+// its children, such as its length or why it has no children.
+func (t *treeState) container(n *node) *node {
+	if n.isNil || n.cycle || n.truncated || n.str != "" ||
+		(n.kind != reflect.Struct && len(n.children) > 0) {
+		t.w.Write(spaceBytes)
+	}
+	switch {
+	case n.isNil:
+		t.w.Write(nilAngleBytes)
+	case n.cycle:
+		t.w.Write(circularBytes)
+	case n.truncated:
+		t.w.Write(maxShortBytes)
+	case n.str != "":
+		t.w.Write([]byte(n.str))
+	case n.kind != reflect.Struct && len(n.children) > 0:
+		t.w.Write(openParenBytes)
+		withColor(t.w, lenEqualsBytes, t.cs.Color.Length...)
+		printNumber(t.w, t.cs, len(n.children))
+		t.w.Write(closeParenBytes)
+	}
+	return n
+}
+
+// draw writes the line for n followed by the subtrees of its children.
+func (t *treeState) draw(n *node) {
+	t.label(n)
+	shown := t.value(n)
+	t.w.Write(newlineBytes)
+
+	for i, c := range shown.children {
+		last := i == len(shown.children)-1
+		for level, more := range t.guides {
+			if more {
+				t.guide(treePipeBytes, level)
+			} else {
+				t.w.Write(treeBlankBytes)
+			}
+		}
+		if last {
+			t.guide(treeLastBytes, len(t.guides))
+		} else {
+			t.guide(treeBranchBytes, len(t.guides))
+		}
+		t.guides = append(t.guides, !last)
+		t.draw(c)
+		t.guides = t.guides[:len(t.guides)-1]
+	}
+}
+
+// fdumpTree draws each of the passed arguments as a tree to w.
+func fdumpTree(cs *ConfigState, w io.Writer, a ...interface{}) {
+	cs = cs.resolve()
+	if cs.DisableDumpColor {
+		cs = cs.plain()
+	}
+	var ids *pointerIDs
+	if cs.PointerIDs {
+		ids = &pointerIDs{}
+	}
+	for _, arg := range a {
+		t := &treeState{w: w, cs: cs, ids: ids}
+		if arg == nil {
+			w.Write(nilAngleBytes)
+			w.Write(newlineBytes)
+			continue
+		}
+		t.draw(buildTree(cs, reflect.ValueOf(arg)))
+	}
+	flushSink(w)
+}
+
+/*
+FdumpTree draws the passed arguments as trees to io.Writer w.  Rather than
+nesting values in braces like Dump, each element is written on its own line
+below its container with box-drawing guides, which is easier to follow for
+deeply nested values such as configurations.  The guides for each level of
+nesting are colored with the Depth colors.  For example:
+
+	(main.Config)
+	├── Name: (string) "api"
+	└── Servers: ([]main.Server) (len: 1)
+	    └── [0]: (main.Server)
+	        └── Port: (int) 80
+
+Pointers are collapsed into the line of the value they point to.
+*/
+func (c *ConfigState) FdumpTree(w io.Writer, a ...interface{}) {
+	fdumpTree(c, w, a...)
+}
+
+// SdumpTree returns a string with the passed arguments drawn exactly the same
+// as FdumpTree.
+func (c *ConfigState) SdumpTree(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpTree(c, &buf, a...)
+	return buf.String()
+}
+
+// FdumpTree draws the passed arguments as trees to io.Writer w.  See
+// ConfigState.FdumpTree for details.
+func FdumpTree(w io.Writer, a ...interface{}) {
+	fdumpTree(global(), w, a...)
+}
+
+// SdumpTree returns a string with the passed arguments drawn exactly the same
+// as FdumpTree.
+func SdumpTree(a ...interface{}) string {
+	var buf bytes.Buffer
+	fdumpTree(global(), &buf, a...)
+	return buf.String()
+}
@@ -0,0 +1,67 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type treeServer struct {
+	Host string
+	Port int
+}
+
+type treeConfig struct {
+	Name    string
+	Servers []*treeServer
+	Labels  map[string]string
+	Parent  *treeConfig
+}
+
+var _ = Describe("Tree Tests", func() {
+	cfg := treeConfig{
+		Name:    "api",
+		Servers: []*treeServer{{Host: "a", Port: 80}, {Host: "b", Port: 81}},
+		Labels:  map[string]string{"env": "prod"},
+	}
+
+	It("draws values with box-drawing guides", func() {
+		cs := spew.NewTestConfig()
+		cs.DisablePointerAddresses = true
+		Expect(cs.SdumpTree(cfg)).To(Equal(`(spew_test.treeConfig)
+├── Name: (string) "api"
+├── Servers: ([]*spew_test.treeServer) (len: 2)
+│   ├── [0]: (*spew_test.treeServer)
+│   │   ├── Host: (string) "a"
+│   │   └── Port: (int) 80
+│   └── [1]: (*spew_test.treeServer)
+│       ├── Host: (string) "b"
+│       └── Port: (int) 81
+├── Labels: (map[string]string) (len: 1)
+│   └── "env": (string) "prod"
+└── Parent: (*spew_test.treeConfig) <nil>
+`))
+	})
+
+	It("colors the guides by depth", func() {
+		color.NoColor = false
+		defer func() { color.NoColor = true }()
+
+		cs := spew.NewTestConfig()
+		cs.Color.Depth = []color.Attribute{color.FgBlue, color.FgRed}
+		blue := color.New(color.FgBlue).Sprint
+		red := color.New(color.FgRed).Sprint
+		Expect(cs.SdumpTree([][]int{{1}})).To(Equal("([][]int) (len: 1)\n" +
+			blue("└── ") + "[0]: ([]int) (len: 1)\n" +
+			"    " + red("└── ") + "[0]: (int) 1\n"))
+	})
+
+	It("marks circular references", func() {
+		cs := spew.NewTestConfig()
+		cs.DisablePointerAddresses = true
+		c := &treeConfig{Name: "self"}
+		c.Parent = c
+		Expect(cs.SdumpTree(c)).To(ContainSubstring("└── Parent: (*spew_test.treeConfig) <already shown>\n"))
+	})
+})
@@ -0,0 +1,29 @@
+package spew
+
+import (
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// truncateString returns s shortened to the MaxStringLength option, without
+// splitting a UTF-8 sequence, along with the number of bytes removed.
+func (c *ConfigState) truncateString(s string) (string, int) {
+	if c.MaxStringLength <= 0 || len(s) <= c.MaxStringLength {
+		return s, 0
+	}
+	n := c.MaxStringLength
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n], len(s) - n
+}
+
+// writeTruncation writes the suffix marking a string from which removed bytes
+// were truncated to Writer w.
+func writeTruncation(w io.Writer, cs *ConfigState, removed int) {
+	if removed == 0 {
+		return
+	}
+	withColor(w, []byte("... (+"+strconv.Itoa(removed)+" bytes)"), cs.Color.Length...)
+}
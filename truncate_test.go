@@ -0,0 +1,36 @@
+package spew_test
+
+import (
+	"fmt"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MaxStringLength Tests", func() {
+	It("truncates long strings in Dump output", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxStringLength = 5
+		Expect(cs.Sdump("hello world", "short")).To(Equal(
+			"(string) (len: 11) \"hello\"... (+6 bytes)\n" +
+				"(string) (len: 5) \"short\"\n"))
+	})
+
+	It("doesn't split UTF-8 sequences", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxStringLength = 2
+		Expect(cs.Sdump("héllo")).To(Equal("(string) (len: 6) \"h\"... (+5 bytes)\n"))
+	})
+
+	It("truncates long strings in Formatter output", func() {
+		cs := spew.NewTestConfig()
+		cs.MaxStringLength = 3
+		Expect(fmt.Sprintf("%v", cs.NewFormatter([]string{"abcdef"}))).To(Equal("[abc... (+3 bytes)]"))
+	})
+
+	It("leaves strings alone by default", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump("hello world")).To(Equal("(string) (len: 11) \"hello world\"\n"))
+	})
+})
@@ -0,0 +1,43 @@
+package spew
+
+import (
+	"encoding/hex"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// uuidNameRE matches the names of types and fields holding UUIDs.
+var uuidNameRE = regexp.MustCompile(`(?i)uuid|guid`)
+
+// isUUID returns whether v is a 16 byte array or slice whose type name or
+// the name of the field or map entry holding it suggests it holds a UUID.
+// Names which merely are or end with ID, such as those of trace identifiers,
+// only qualify when the bytes have the version and variant of a UUID.
+func isUUID(v reflect.Value, name string) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		if v.Len() != 16 {
+			return false
+		}
+	default:
+		return false
+	}
+	if uuidNameRE.MatchString(v.Type().Name()) || uuidNameRE.MatchString(name) {
+		return true
+	}
+	if !strings.EqualFold(name, "id") && !strings.HasSuffix(name, "ID") && !strings.HasSuffix(name, "Id") {
+		return false
+	}
+	// The version is held by the high nibble of the seventh byte and the
+	// variant by the high bits of the ninth, which are 10 for RFC 9562.
+	version := v.Index(6).Uint() >> 4
+	return version >= 1 && version <= 8 && v.Index(8).Uint()&0xc0 == 0x80
+}
+
+// formatUUID returns the canonical form of the 16 byte UUID b, as in
+// 6ba7b810-9dad-11d1-80b4-00c04fd430c8.
+func formatUUID(b []byte) string {
+	s := hex.EncodeToString(b)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
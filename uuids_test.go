@@ -0,0 +1,49 @@
+package spew_test
+
+import (
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type uuidsUUID [16]byte
+
+type uuidsRecord struct {
+	RequestID []byte
+	token     uuidsUUID
+	Digest    [16]byte
+	TraceID   [16]byte
+}
+
+var _ = Describe("UUID Tests", func() {
+	id := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	It("displays UUIDs in their canonical form", func() {
+		cs := spew.NewTestConfig()
+		cs.UUIDs = true
+		cs.DisableMethods = true
+		s := cs.Sdump(uuidsRecord{RequestID: id[:], token: id})
+		Expect(s).To(HavePrefix("(spew_test.uuidsRecord) {\n" +
+			"  RequestID: ([]uint8) 6ba7b810-9dad-11d1-80b4-00c04fd430c8,\n" +
+			"  token: (spew_test.uuidsUUID) 6ba7b810-9dad-11d1-80b4-00c04fd430c8,\n" +
+			"  Digest: ([16]uint8) (len: 16 cap: 16) {\n"))
+	})
+
+	It("requires the bits of a UUID for names ending with ID", func() {
+		cs := spew.NewTestConfig()
+		cs.UUIDs = true
+		trace := [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+		s := cs.Sdump(uuidsRecord{TraceID: trace})
+		Expect(s).To(ContainSubstring("  TraceID: ([16]uint8) 4bf92f35-77b3-4da6-a3ce-929d0e0e4736\n"))
+
+		trace[6] = 0xc3
+		s = cs.Sdump(uuidsRecord{TraceID: trace})
+		Expect(s).To(ContainSubstring("  TraceID: ([16]uint8) (len: 16 cap: 16) {\n"))
+		Expect(s).To(ContainSubstring("  token: (spew_test.uuidsUUID) 00000000-0000-0000-0000-000000000000,\n"))
+	})
+
+	It("leaves byte arrays alone by default", func() {
+		cs := spew.NewTestConfig()
+		Expect(cs.Sdump(uuidsUUID(id))).To(HavePrefix("(spew_test.uuidsUUID) (len: 16 cap: 16) {\n"))
+	})
+})
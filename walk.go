@@ -0,0 +1,334 @@
+package spew
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// node describes a single value visited while walking a data structure.  It
+// is the common representation consumed by the renderers which do not write
+// directly from reflection, such as Canonical.
+type node struct {
+	parent *node
+
+	// kind and typ describe the value after any interface has been unpacked.
+	// typ is nil when the node is a nil interface.
+	kind reflect.Kind
+	typ  reflect.Type
+
+	// name, tag and field are set when the node is a struct field, key is
+	// set when the node is a map value, and index is the position of the
+	// node within its parent.  field is the index of the field within the
+	// struct, which differs from index when fields before it are hidden.
+	name  string
+	tag   reflect.StructTag
+	field int
+	key   *node
+	index int
+
+	// depth is the number of nodes between this node and the root.
+	depth int
+
+	// addr is the address pointed to by pointers, channels, functions and
+	// unsafe pointers, or the address of the data of maps and slices.
+	addr uintptr
+
+	// value holds the value of scalar kinds normalized to one of bool, int64,
+	// uint64, float64, complex128, string or uintptr.
+	value interface{}
+
+	// str holds the result of invoking an error or Stringer interface.
+	str string
+
+	children []*node
+
+	isNil     bool
+	cycle     bool
+	truncated bool
+
+	rv reflect.Value
+}
+
+// walker traverses a value in the same order Dump does, reporting each node
+// to the enter and leave callbacks.  Callbacks which are nil are ignored.
+type walker struct {
+	cs        *ConfigState
+	methodsCS *ConfigState
+	level     int
+	maxDepth  int
+	filter    *fieldFilter
+	redactor  *redactor
+	active    map[uintptr]bool
+	visited   map[visitKey]bool
+	enter     func(n *node)
+	leave     func(n *node)
+
+	// forceMethods is set while walking a field whose spew struct tag has
+	// the string option until the value behind any pointers is reached.
+	forceMethods bool
+}
+
+// newWalker returns a walker for the passed config state.
+func newWalker(cs *ConfigState) *walker {
+	cs = cs.resolve()
+	mcs := *cs
+	mcs.ContinueOnMethod = false
+	return &walker{
+		cs:        cs,
+		methodsCS: &mcs,
+		maxDepth:  cs.rootMaxDepth(),
+		filter:    newFieldFilter(cs),
+		redactor:  newRedactor(cs),
+		active:    make(map[uintptr]bool),
+		visited:   make(map[visitKey]bool),
+	}
+}
+
+// child returns a new node which is a child of n.
+func (n *node) child(index int) *node {
+	return &node{parent: n, index: index, depth: n.depth + 1}
+}
+
+// walk fills in n from v and recursively walks its children.
+func (w *walker) walk(n *node, v reflect.Value) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			n.kind = reflect.Interface
+			n.isNil = true
+			n.rv = v
+			w.visit(n, nil)
+			return
+		}
+		v = v.Elem()
+	}
+
+	n.kind = v.Kind()
+	n.rv = v
+	if n.kind == reflect.Invalid {
+		w.visit(n, nil)
+		return
+	}
+	n.typ = v.Type()
+
+	// Apply the options of the spew tag of the struct field holding v.
+	opts := spewTagOptions(n.tag)
+	if opts.redact || w.redactor.redacts(n.redactName(), n.typ) {
+		n.str = redactedText(v)
+		w.visit(n, nil)
+		return
+	}
+	if w.cs.RedactFunc != nil {
+		if text, ok := w.cs.customRedaction(structPath(n), n.keyName(), v); ok {
+			n.str = text
+			w.visit(n, nil)
+			return
+		}
+	}
+	if w.cs.isSkippedType(n.typ) {
+		w.forceMethods = false
+		n.str = string(skippedAngleBytes)
+		w.visit(n, nil)
+		return
+	}
+	if opts.stringer {
+		w.forceMethods = true
+	}
+	forceMethods := w.forceMethods
+	if n.kind != reflect.Ptr {
+		w.forceMethods = false
+	} else {
+		defer func() { w.forceMethods = false }()
+	}
+
+	// Lower the depth limit within values of types or fields with their own
+	// limit.
+	limit := w.cs.typeMaxDepth(w.maxDepth, n.typ, w.level)
+	limit = opts.fieldMaxDepth(limit, w.level)
+	if limit != w.maxDepth {
+		defer func(maxDepth int) { w.maxDepth = maxDepth }(w.maxDepth)
+		w.maxDepth = limit
+	}
+
+	// Call Stringer/error interfaces if they exist and the handle methods
+	// flag is enabled.
+	if !w.cs.DisableMethods || forceMethods {
+		var buf bytes.Buffer
+		if handleMethods(w.methodsCS, &buf, v) {
+			n.str = buf.String()
+			if !w.cs.ContinueOnMethod {
+				w.visit(n, nil)
+				return
+			}
+		}
+	}
+
+	w.describe(n, v)
+	w.visit(n, func() { w.walkValue(n, v) })
+}
+
+// visit reports n to the callbacks around the walk of its children.
+func (w *walker) visit(n *node, children func()) {
+	if w.enter != nil {
+		w.enter(n)
+	}
+	if children != nil {
+		children()
+	}
+	if w.leave != nil {
+		w.leave(n)
+	}
+}
+
+// describe records the value of n based on its kind so it is available to
+// the enter callback.
+func (w *walker) describe(n *node, v reflect.Value) {
+	switch n.kind {
+	case reflect.Bool:
+		n.value = v.Bool()
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		n.value = v.Int()
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		n.value = v.Uint()
+
+	case reflect.Float32, reflect.Float64:
+		n.value = v.Float()
+
+	case reflect.Complex64, reflect.Complex128:
+		n.value = v.Complex()
+
+	case reflect.String:
+		n.value = v.String()
+
+	case reflect.Uintptr:
+		n.value = uintptr(v.Uint())
+
+	case reflect.UnsafePointer, reflect.Chan, reflect.Func:
+		n.addr = v.Pointer()
+		n.isNil = v.IsNil()
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			n.isNil = true
+			return
+		}
+		n.addr = v.Pointer()
+		n.cycle = w.active[n.addr]
+
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			n.isNil = true
+			return
+		}
+		n.addr = v.Pointer()
+		n.cycle = w.visited[newVisitKey(v)]
+		n.truncated = !n.cycle && w.beyondMaxDepth()
+
+	case reflect.Array, reflect.Struct:
+		n.truncated = w.beyondMaxDepth()
+	}
+}
+
+// beyondMaxDepth returns whether the elements of a container visited at the
+// current level are beyond the MaxDepth and MaxDepthTypes options.
+func (w *walker) beyondMaxDepth() bool {
+	return w.level+1 > w.maxDepth
+}
+
+// walkValue walks the children of n based on its kind.
+func (w *walker) walkValue(n *node, v reflect.Value) {
+	if n.isNil || n.cycle {
+		return
+	}
+	switch n.kind {
+	case reflect.Ptr:
+		w.active[n.addr] = true
+		w.walk(n.child(0), v.Elem())
+		delete(w.active, n.addr)
+
+	case reflect.Slice, reflect.Map:
+		key := newVisitKey(v)
+		w.visited[key] = true
+		w.walkContainer(n, v)
+		delete(w.visited, key)
+
+	case reflect.Array, reflect.Struct:
+		w.walkContainer(n, v)
+	}
+}
+
+// walkContainer walks the elements of arrays, slices, maps and structs unless
+// they are truncated due to the MaxDepth option.
+func (w *walker) walkContainer(n *node, v reflect.Value) {
+	if n.truncated {
+		return
+	}
+	w.level++
+	defer func() { w.level-- }()
+
+	switch n.kind {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			w.walk(n.child(i), v.Index(i))
+		}
+
+	case reflect.Map:
+		keys := v.MapKeys()
+		if w.cs.SortKeys {
+			sortValues(keys, w.cs)
+		}
+		for i, key := range keys {
+			c := n.child(i)
+			c.key = w.walkKey(c, key)
+			w.walk(c, v.MapIndex(key))
+		}
+
+	case reflect.Struct:
+		vt := v.Type()
+		fields, _ := w.cs.visibleFields(v, w.filter, structPath(n))
+		for j, i := range fields {
+			c := n.child(j)
+			vtf := vt.Field(i)
+			c.name = vtf.Name
+			c.tag = vtf.Tag
+			c.field = i
+			w.walk(c, v.Field(i))
+		}
+	}
+}
+
+// walkKey builds the complete tree for a map key without reporting it to the
+// callbacks of w.  The returned node is not linked into the tree of the map.
+func (w *walker) walkKey(value *node, key reflect.Value) *node {
+	kw := &walker{
+		cs:        w.cs,
+		methodsCS: w.methodsCS,
+		level:     w.level,
+		maxDepth:  w.maxDepth,
+		filter:    w.filter,
+		redactor:  w.redactor,
+		active:    w.active,
+		visited:   w.visited,
+	}
+	kw.enter = appendChild
+	k := &node{index: value.index, depth: value.depth}
+	kw.walk(k, key)
+	return k
+}
+
+// appendChild is an enter callback which links n into its parent's children.
+func appendChild(n *node) {
+	if n.parent != nil {
+		n.parent.children = append(n.parent.children, n)
+	}
+}
+
+// buildTree walks v and returns the root of the resulting node tree.
+func buildTree(cs *ConfigState, v reflect.Value) *node {
+	w := newWalker(cs)
+	w.enter = appendChild
+	root := &node{}
+	w.walk(root, v)
+	return root
+}
@@ -0,0 +1,316 @@
+package spew
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sqlNullBytes is displayed for sql.Null values which are not valid.
+var sqlNullBytes = []byte("NULL")
+
+// wellKnownTexts houses the functions returning the canonical text of the
+// values of the types displayed by it for the WellKnownTypes option, which
+// are the built-in ones and those registered with RegisterWellKnownType.
+var wellKnownTexts = struct {
+	sync.RWMutex
+	m map[reflect.Type]func(v interface{}) string
+}{m: map[reflect.Type]func(v interface{}) string{
+	reflect.TypeOf(net.IP(nil)):           func(v interface{}) string { return v.(net.IP).String() },
+	reflect.TypeOf(net.IPMask(nil)):       func(v interface{}) string { return v.(net.IPMask).String() },
+	reflect.TypeOf(net.HardwareAddr(nil)): func(v interface{}) string { return v.(net.HardwareAddr).String() },
+	reflect.TypeOf(net.IPNet{}): func(v interface{}) string {
+		n := v.(net.IPNet)
+		return n.String()
+	},
+	reflect.TypeOf(netip.Addr{}):     func(v interface{}) string { return v.(netip.Addr).String() },
+	reflect.TypeOf(netip.AddrPort{}): func(v interface{}) string { return v.(netip.AddrPort).String() },
+	reflect.TypeOf(netip.Prefix{}):   func(v interface{}) string { return v.(netip.Prefix).String() },
+	reflect.TypeOf(url.URL{}): func(v interface{}) string {
+		u := v.(url.URL)
+		return u.Redacted()
+	},
+	reflect.TypeOf((*time.Location)(nil)).Elem(): func(v interface{}) string {
+		l := v.(time.Location)
+		return l.String()
+	},
+	reflect.TypeOf((*os.File)(nil)).Elem(): func(v interface{}) string {
+		f := v.(os.File)
+		return fileText(&f)
+	},
+	reflect.TypeOf((*bytes.Buffer)(nil)).Elem(): func(v interface{}) string {
+		b := v.(bytes.Buffer)
+		return strconv.Quote(b.String())
+	},
+}}
+
+// wellKnownNames houses the functions returning the text of the values of the
+// types displayed by it for the WellKnownTypes option, keyed by the import
+// path and name of the types, for types of packages too heavy to import.  The
+// functions are passed the redactor for the Redact option, since the values
+// they display are not redacted otherwise.
+var wellKnownNames = map[string]func(r *redactor, v reflect.Value) string{
+	"net/http.Header":  headerText,
+	"net/http.Request": requestText,
+}
+
+/*
+RegisterWellKnownType registers text to return the concise text the values of
+the type typ are displayed by when the WellKnownTypes option is set, like the
+built-in well-known types, for every ConfigState.  For example:
+
+	spew.RegisterWellKnownType(reflect.TypeOf(money.Amount{}),
+		func(v interface{}) string {
+			return v.(money.Amount).Format()
+		})
+
+The type is matched exactly, and pointers to it are followed as for the values
+of any other type.  Registering text for a built-in well-known type replaces
+its text, and registering a nil text removes the type, so its values are
+displayed as usual.  Formatters registered with RegisterFormatter take
+precedence.
+*/
+func RegisterWellKnownType(typ reflect.Type, text func(v interface{}) string) {
+	wellKnownTexts.Lock()
+	defer wellKnownTexts.Unlock()
+	if text == nil {
+		delete(wellKnownTexts.m, typ)
+		return
+	}
+	wellKnownTexts.m[typ] = text
+}
+
+// fileText returns the text of the file f, which is its name followed by its
+// descriptor.  The descriptor is read from the fields of f since the Fd
+// method puts the file in blocking mode, and is omitted when their layout is
+// unknown.
+func fileText(f *os.File) string {
+	text := f.Name()
+	v := reflect.ValueOf(f).Elem()
+	if v.NumField() == 0 || v.Field(0).Kind() != reflect.Ptr || v.Field(0).IsNil() {
+		return text
+	}
+	fd := v.Field(0).Elem().FieldByName("pfd").FieldByName("Sysfd")
+	switch fd.Kind() {
+	case reflect.Int:
+		return text + " (fd: " + strconv.FormatInt(fd.Int(), 10) + ")"
+	case reflect.Uintptr:
+		return text + " (fd: " + strconv.FormatUint(fd.Uint(), 10) + ")"
+	}
+	return text
+}
+
+// headerText returns the text of the http.Header v, which is its keys in
+// sorted order with their values, as in Accept: */*; X-Id: 1, 2.  The values
+// of the keys redacted by r are replaced by their placeholder.
+func headerText(r *redactor, v reflect.Value) string {
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return ""
+	}
+	keys := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	slices.Sort(keys)
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(key + ": ")
+		vals := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		if r.redacts(key, vals.Type()) {
+			b.WriteString(redactedText(vals))
+			continue
+		}
+		for j := 0; vals.Kind() == reflect.Slice && j < vals.Len(); j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(vals.Index(j).String())
+		}
+	}
+	return b.String()
+}
+
+// requestText returns the text of the http.Request v, which is its method and
+// URL followed by its headers in braces, as in GET /users {Accept: */*}.
+// Passwords in the URL are redacted, and so are the headers redacted by r.
+func requestText(r *redactor, v reflect.Value) string {
+	text := v.FieldByName("Method").String()
+	if text == "" {
+		text = "GET"
+	}
+	if u := v.FieldByName("URL"); u.Kind() == reflect.Ptr && !u.IsNil() && u.CanInterface() {
+		if u, ok := u.Interface().(*url.URL); ok {
+			text += " " + u.Redacted()
+		}
+	}
+	return text + " {" + headerText(r, v.FieldByName("Header")) + "}"
+}
+
+// The bits of the state of a mutex and the maximum number of readers of a
+// read-write mutex, as defined by the sync package.
+const (
+	mutexLocked       = 1
+	mutexStarving     = 4
+	mutexWaiterShift  = 3
+	rwmutexMaxReaders = 1 << 30
+)
+
+func init() {
+	mutexType := reflect.TypeOf((*sync.Mutex)(nil)).Elem()
+	rwMutexType := reflect.TypeOf((*sync.RWMutex)(nil)).Elem()
+	// The states are read from unexported fields, so the mutexes are only
+	// displayed by them when the layout of the sync package is known.  They
+	// are read by reflection, so the mutexes held by unexported fields are
+	// displayed without the unsafe package too.
+	if _, ok := mutexState(reflect.Zero(mutexType)); ok {
+		wellKnownNames["sync.Mutex"] = func(_ *redactor, v reflect.Value) string {
+			return mutexText(v)
+		}
+	}
+	if _, _, ok := rwMutexState(reflect.Zero(rwMutexType)); ok {
+		wellKnownNames["sync.RWMutex"] = func(_ *redactor, v reflect.Value) string {
+			return rwMutexText(v)
+		}
+	}
+}
+
+// mutexState returns the state word of the sync.Mutex v, which is held by an
+// internal mutex in recent releases and by the mutex itself in older ones,
+// and false when v does not have a known layout.
+func mutexState(v reflect.Value) (int64, bool) {
+	for v.Kind() == reflect.Struct {
+		if f := v.FieldByName("state"); f.IsValid() {
+			if f.Kind() != reflect.Int32 {
+				return 0, false
+			}
+			return f.Int(), true
+		}
+		v = v.FieldByName("mu")
+	}
+	return 0, false
+}
+
+// rwMutexState returns the writer mutex state and the reader count of the
+// sync.RWMutex v, and false when v does not have a known layout.
+func rwMutexState(v reflect.Value) (state, readers int64, ok bool) {
+	if v.Kind() != reflect.Struct {
+		return 0, 0, false
+	}
+	if state, ok = mutexState(v.FieldByName("w")); !ok {
+		return 0, 0, false
+	}
+	// The reader count is an atomic.Int32 in recent releases and an int32
+	// in older ones.
+	f := v.FieldByName("readerCount")
+	if f.Kind() == reflect.Struct {
+		f = f.FieldByName("v")
+	}
+	if f.Kind() != reflect.Int32 {
+		return 0, 0, false
+	}
+	return state, f.Int(), true
+}
+
+// mutexText returns the text of the sync.Mutex v, which is locked or unlocked
+// followed by the number of waiting goroutines when there are any.
+func mutexText(v reflect.Value) string {
+	state, _ := mutexState(v)
+	text := "unlocked"
+	if state&mutexLocked != 0 {
+		text = "locked"
+	}
+	if waiters := state >> mutexWaiterShift; waiters > 0 {
+		text += " (waiters: " + strconv.FormatInt(waiters, 10)
+		if state&mutexStarving != 0 {
+			text += ", starving"
+		}
+		text += ")"
+	}
+	return text
+}
+
+// rwMutexText returns the text of the sync.RWMutex v, which is locked when it
+// is held by a writer, read-locked followed by the number of readers when it
+// is held by readers, and unlocked otherwise.  A writer waiting for the
+// readers to release the mutex is noted.
+func rwMutexText(v reflect.Value) string {
+	state, readers, _ := rwMutexState(v)
+	writer := readers < 0
+	if writer {
+		readers += rwmutexMaxReaders
+	}
+	switch {
+	case readers > 0 && writer:
+		return "read-locked (readers: " + strconv.FormatInt(readers, 10) + ", writer waiting)"
+	case readers > 0:
+		return "read-locked (readers: " + strconv.FormatInt(readers, 10) + ")"
+	case writer || state&mutexLocked != 0:
+		return "locked"
+	}
+	return "unlocked"
+}
+
+// wellKnownFormatter returns the formatter for the values of typ used by the
+// WellKnownTypes option, and false when typ is not displayed by its text.
+func (c *ConfigState) wellKnownFormatter(typ reflect.Type) (func(w io.Writer, v interface{}), bool) {
+	wellKnownTexts.RLock()
+	text, ok := wellKnownTexts.m[typ]
+	wellKnownTexts.RUnlock()
+	if !ok {
+		named, ok := wellKnownNames[typ.PkgPath()+"."+typ.Name()]
+		if !ok {
+			return nil, false
+		}
+		r := newRedactor(c)
+		text = func(v interface{}) string { return named(r, reflect.ValueOf(v)) }
+	}
+	return func(w io.Writer, v interface{}) {
+		withColor(w, []byte(text(v)), c.Color.Number...)
+	}, true
+}
+
+// wellKnownValue returns a function writing the text of v used by the
+// WellKnownTypes option when its type is one of wellKnownNames, and false
+// otherwise.  Their values are read by reflection, so it displays the values
+// which can't be converted to an interface when the unsafe package is not
+// available.
+func (c *ConfigState) wellKnownValue(v reflect.Value) (func(w io.Writer), bool) {
+	if !c.WellKnownTypes {
+		return nil, false
+	}
+	named, ok := wellKnownNames[v.Type().PkgPath()+"."+v.Type().Name()]
+	if !ok {
+		return nil, false
+	}
+	r := newRedactor(c)
+	return func(w io.Writer) {
+		withColor(w, []byte(named(r, v)), c.Color.Number...)
+	}, true
+}
+
+// sqlNull returns the value held by v and whether it is valid when v is one
+// of the database/sql Null types, which are structs holding the value ahead
+// of a Valid field, and false otherwise.
+func sqlNull(v reflect.Value) (value reflect.Value, valid, ok bool) {
+	t := v.Type()
+	if t.Kind() != reflect.Struct || t.PkgPath() != "database/sql" ||
+		!strings.HasPrefix(t.Name(), "Null") || t.NumField() != 2 {
+		return reflect.Value{}, false, false
+	}
+	if f := t.Field(1); f.Name != "Valid" || f.Type.Kind() != reflect.Bool {
+		return reflect.Value{}, false, false
+	}
+	return v.Field(0), v.Field(1).Bool(), true
+}
@@ -0,0 +1,163 @@
+package spew
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxWidthTerminal is the value of the MaxWidth option which wraps output to
+// the width of the terminal.
+const MaxWidthTerminal = -1
+
+// defaultTerminalWidth is the width output is wrapped to when MaxWidth is
+// MaxWidthTerminal and the width of the terminal can't be determined.
+const defaultTerminalWidth = 80
+
+// minWrapWidth is the fewest columns a line of a wrapped value is allowed to
+// span, so deeply indented values are not wrapped into slivers.
+const minWrapWidth = 20
+
+// wrapWidth returns the width the output written to w is wrapped to
+// according to the MaxWidth option, or 0 when it is not wrapped.  The columns
+// taken by the numbers written for the LineNumbers option are left out.
+func wrapWidth(cs *ConfigState, w io.Writer) int {
+	width := outputWidth(cs, w)
+	if width > 0 && cs.LineNumbers {
+		width = max(width-lineNumberWidth, 1)
+	}
+	return width
+}
+
+// outputWidth returns the width of the output written to w according to the
+// MaxWidth option, or 0 when it is not wrapped.
+func outputWidth(cs *ConfigState, w io.Writer) int {
+	switch {
+	case cs.Compact:
+		return 0
+	case cs.MaxWidth > 0:
+		return cs.MaxWidth
+	case cs.MaxWidth != MaxWidthTerminal:
+		return 0
+	}
+	if f, ok := w.(*os.File); ok {
+		if width := terminalWidth(f); width > 0 {
+			return width
+		}
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultTerminalWidth
+}
+
+// columnWriter is an io.Writer which tracks the column the next byte written
+// through it is displayed in.  ANSI escape sequences take up no columns.
+type columnWriter struct {
+	w      io.Writer
+	col    int
+	escape bool
+}
+
+func (c *columnWriter) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); {
+		r, size := utf8.DecodeRune(p[i:])
+		i += size
+		switch {
+		case c.escape:
+			// Escape sequences end with a letter, such as the m of a
+			// color sequence.
+			c.escape = !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+		case r == '\x1b':
+			c.escape = true
+		case r == '\n':
+			c.col = 0
+		case r == '\t':
+			c.col += tabWidth - c.col%tabWidth
+		default:
+			c.col++
+		}
+	}
+	return c.w.Write(p)
+}
+
+// quotedTokens splits the quoted string s into the tokens a line may be
+// broken between, which are single characters and complete escape sequences.
+func quotedTokens(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		size := 1
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case 'x':
+				size = 4
+			case 'u':
+				size = 6
+			case 'U':
+				size = 10
+			default:
+				size = 2
+			}
+			size = min(size, len(s)-i)
+		} else {
+			_, size = utf8.DecodeRuneInString(s[i:])
+		}
+		tokens = append(tokens, s[i:i+size])
+		i += size
+	}
+	return tokens
+}
+
+// fitTokens returns the number of leading tokens which fit within width
+// columns.
+func fitTokens(tokens []string, width int) int {
+	cols := 0
+	for i, token := range tokens {
+		cols += utf8.RuneCountInString(token)
+		if cols > width {
+			return i
+		}
+	}
+	return len(tokens)
+}
+
+// printWrapped outputs the quoted string s, soft-wrapped with hanging
+// indentation so no line runs past the wrap width of d unless the width is
+// too narrow for the indentation.  Lines are broken after a space when there
+// is one in the second half of the line.
+func (d *dumpState) printWrapped(s string) {
+	hang := d.indentation(d.depth + 1)
+	avail := d.width - d.col.col
+	next := max(d.width-visibleWidth(hang), minWrapWidth)
+
+	// Strings which would start too close to the wrap width start on a
+	// line of their own instead.
+	tokens := quotedTokens(s)
+	if avail < minWrapWidth && fitTokens(tokens, avail) < len(tokens) {
+		d.w.Write(newlineBytes)
+		d.w.Write([]byte(hang))
+		avail = next
+	}
+
+	for {
+		end := fitTokens(tokens, avail)
+		if end == len(tokens) {
+			break
+		}
+		end = max(end, 1)
+		for i := end - 1; i >= end/2; i-- {
+			if tokens[i] == " " {
+				end = i + 1
+				break
+			}
+		}
+		printString(d.w, d.cs, strings.Join(tokens[:end], ""))
+		d.w.Write(newlineBytes)
+		d.w.Write([]byte(hang))
+		tokens = tokens[end:]
+		avail = next
+	}
+	printString(d.w, d.cs, strings.Join(tokens, ""))
+}
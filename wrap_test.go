@@ -0,0 +1,84 @@
+package spew_test
+
+import (
+	"os"
+	"strings"
+
+	spew "github.com/ehowe/rainbow-spew"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type wrapTester struct {
+	Body string
+}
+
+var _ = Describe("MaxWidth Tests", func() {
+	It("wraps long strings with hanging indentation", func() {
+		cfg := spew.NewTestConfig()
+		cfg.MaxWidth = 50
+		v := wrapTester{Body: "the quick brown fox jumps over the lazy dog again and again"}
+		Expect(cfg.Sdump(v)).To(Equal(`(spew_test.wrapTester) {
+  Body: (string) (len: 59) "the quick brown fox ` + `
+    jumps over the lazy dog again and again"
+}
+`))
+	})
+
+	It("starts strings close to the wrap width on their own line without splitting escapes", func() {
+		cfg := spew.NewTestConfig()
+		cfg.MaxWidth = 30
+		s := strings.Repeat("\x00", 10)
+		out := cfg.Sdump(s)
+		Expect(out).To(Equal("(string) (len: 10) \n" +
+			"  \"\\x00\\x00\\x00\\x00\\x00\\x00\n" +
+			"  \\x00\\x00\\x00\\x00\"\n"))
+		for _, line := range strings.Split(out, "\n") {
+			Expect(len(line)).To(BeNumerically("<=", 30))
+		}
+	})
+
+	It("leaves strings alone by default and in compact mode", func() {
+		s := strings.Repeat("a", 200)
+		cfg := spew.NewTestConfig()
+		Expect(cfg.Sdump(s)).To(Equal(`(string) (len: 200) "` + s + "\"\n"))
+
+		cfg.MaxWidth = 40
+		cfg.Compact = true
+		Expect(cfg.Sdump(s)).To(Equal(`(string) (len: 200) "` + s + "\"\n"))
+	})
+
+	It("wraps to the COLUMNS environment variable when not writing to a terminal", func() {
+		columns, ok := os.LookupEnv("COLUMNS")
+		defer func() {
+			if ok {
+				os.Setenv("COLUMNS", columns)
+			} else {
+				os.Unsetenv("COLUMNS")
+			}
+		}()
+		os.Setenv("COLUMNS", "30")
+
+		cfg := spew.NewTestConfig()
+		cfg.MaxWidth = spew.MaxWidthTerminal
+		out := cfg.Sdump(strings.Repeat("a", 50))
+		Expect(strings.Split(out, "\n")[1]).To(HaveLen(30))
+	})
+
+	It("leaves room for line numbers", func() {
+		cfg := spew.NewTestConfig()
+		cfg.MaxWidth = 50
+		cfg.LineNumbers = true
+		v := wrapTester{Body: "the quick brown fox jumps over the lazy dog again and again"}
+		out := cfg.Sdump(v)
+		Expect(out).To(Equal(`   1  (spew_test.wrapTester) {
+   2    Body: (string) (len: 59) ` + `
+   3      "the quick brown fox jumps over the ` + `
+   4      lazy dog again and again"
+   5  }
+`))
+		for _, line := range strings.Split(out, "\n") {
+			Expect(len(line)).To(BeNumerically("<=", 50))
+		}
+	})
+})